@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+type (
+	// CanaryPhase represents the state of a canary rollout of a collector configuration.
+	// +kubebuilder:validation:Enum=Progressing;Promoted;Aborted
+	CanaryPhase string
+)
+
+const (
+	// CanaryPhaseProgressing indicates that the canary workload is running and being analyzed.
+	CanaryPhaseProgressing CanaryPhase = "Progressing"
+
+	// CanaryPhasePromoted indicates that the canary configuration passed the analysis and
+	// has been promoted to the main collector workload.
+	CanaryPhasePromoted CanaryPhase = "Promoted"
+
+	// CanaryPhaseAborted indicates that the canary configuration failed the analysis and
+	// the canary workload has been removed.
+	CanaryPhaseAborted CanaryPhase = "Aborted"
+)
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+const (
+	// CollectorConditionReady indicates that all the collector's pods are ready.
+	CollectorConditionReady = "Ready"
+
+	// CollectorConditionConfigValid indicates whether the collector's configuration could be parsed.
+	CollectorConditionConfigValid = "ConfigValid"
+
+	// CollectorConditionReconciled indicates whether the last reconciliation of the collector's objects succeeded.
+	CollectorConditionReconciled = "Reconciled"
+
+	// CollectorConditionTargetAllocatorReady indicates that all the target allocator's pods are ready.
+	// It is only set when the target allocator is enabled.
+	CollectorConditionTargetAllocatorReady = "TargetAllocatorReady"
+
+	// CollectorConditionProgressing indicates that a rollout of the collector's workload is in progress.
+	CollectorConditionProgressing = "Progressing"
+
+	// CollectorConditionDegraded indicates that the collector failed to reconcile or its workload reports a failure.
+	CollectorConditionDegraded = "Degraded"
+)
@@ -17,16 +17,22 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"path"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/strings/slices"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
 	ta "github.com/open-telemetry/opentelemetry-operator/internal/manifests/targetallocator/adapters"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
@@ -145,6 +151,9 @@ func (c CollectorWebhook) defaulter(r *OpenTelemetryCollector) error {
 	if r.Spec.Ingress.Type == IngressTypeNginx && r.Spec.Ingress.RuleType == "" {
 		r.Spec.Ingress.RuleType = IngressRuleTypePath
 	}
+	if r.Spec.Canary != nil && r.Spec.Canary.Replicas == nil {
+		r.Spec.Canary.Replicas = &one
+	}
 	// If someone upgrades to a later version without upgrading their CRD they will not have a management state set.
 	// This results in a default state of unmanaged preventing reconciliation from continuing.
 	if len(r.Spec.ManagementState) == 0 {
@@ -179,6 +188,97 @@ func (c CollectorWebhook) validate(r *OpenTelemetryCollector) (admission.Warning
 		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'AdditionalContainers'", r.Spec.Mode)
 	}
 
+	// validate sidecarPlacement
+	if r.Spec.Mode != ModeSidecar && r.Spec.SidecarPlacement != "" {
+		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'sidecarPlacement'", r.Spec.Mode)
+	}
+
+	// validate sidecarConfigReload
+	if r.Spec.Mode != ModeSidecar && r.Spec.SidecarConfigReload {
+		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'sidecarConfigReload'", r.Spec.Mode)
+	}
+
+	// validate sidecarSelector
+	if r.Spec.SidecarSelector != nil {
+		if r.Spec.Mode != ModeSidecar {
+			return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'sidecarSelector'", r.Spec.Mode)
+		}
+		if _, err := metav1.LabelSelectorAsSelector(r.Spec.SidecarSelector.PodSelector); err != nil {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec sidecarSelector configuration is incorrect, invalid podSelector: %w", err)
+		}
+		if _, err := metav1.LabelSelectorAsSelector(r.Spec.SidecarSelector.NamespaceSelector); err != nil {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec sidecarSelector configuration is incorrect, invalid namespaceSelector: %w", err)
+		}
+		if r.Spec.SidecarSelector.NamespaceSelector != nil && !slices.Contains(c.cfg.CrossNamespaceSelectorNamespaces(), r.Namespace) {
+			warnings = append(warnings, fmt.Sprintf("the namespace %s isn't allowed to select the pods of other namespaces, the sidecarSelector only selects the pods of the collector's namespace", r.Namespace))
+		}
+	}
+
+	// validate sidecarAllowedNamespaces
+	if len(r.Spec.SidecarAllowedNamespaces) > 0 {
+		if r.Spec.Mode != ModeSidecar {
+			return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'sidecarAllowedNamespaces'", r.Spec.Mode)
+		}
+		for _, ns := range r.Spec.SidecarAllowedNamespaces {
+			if ns == "*" {
+				continue
+			}
+			if errs := validation.IsDNS1123Label(ns); len(errs) > 0 {
+				return warnings, fmt.Errorf("the OpenTelemetry Spec sidecarAllowedNamespaces configuration is incorrect, '%s' is not a namespace name: %s", ns, strings.Join(errs, ", "))
+			}
+		}
+	}
+
+	if r.Spec.Mode != ModeSidecar && r.Spec.InjectionFailurePolicy != "" {
+		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'injectionFailurePolicy'", r.Spec.Mode)
+	}
+
+	if r.Spec.Mode != ModeDaemonSet && r.Spec.Agent.Enabled {
+		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'agent'", r.Spec.Mode)
+	}
+
+	if r.Spec.Mode == ModeSidecar && r.Spec.RBAC.Enabled {
+		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'rbac'", r.Spec.Mode)
+	}
+
+	// validate persistence
+	if r.Spec.Persistence.Enabled {
+		if r.Spec.Mode == ModeSidecar {
+			return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'persistence'", r.Spec.Mode)
+		}
+		if r.Spec.Persistence.HostPath != "" && !path.IsAbs(r.Spec.Persistence.HostPath) {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec persistence configuration is incorrect, hostPath should be an absolute path")
+		}
+		if r.Spec.Mode == ModeDeployment {
+			warnings = append(warnings, "the sending queues are stored in an emptyDir volume in deployment mode, the queued data is lost when a pod is deleted")
+		}
+		if rollingUpdate := r.Spec.UpdateStrategy.RollingUpdate; r.Spec.Mode == ModeDaemonSet && rollingUpdate != nil && rollingUpdate.MaxSurge != nil &&
+			rollingUpdate.MaxSurge.String() != "0" && rollingUpdate.MaxSurge.String() != "0%" {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec persistence configuration is incorrect, updateStrategy.rollingUpdate.maxSurge should be 0 as the pods of a node share the storage directory")
+		}
+	}
+
+	// validate drain
+	if r.Spec.Drain.Enabled {
+		if r.Spec.Mode == ModeSidecar {
+			return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'drain'", r.Spec.Mode)
+		}
+		if r.Spec.Drain.Duration != nil && r.Spec.Drain.Duration.Duration < time.Second {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec drain configuration is incorrect, duration should be one second or more")
+		}
+		if r.Spec.Drain.Image == "" && (r.Spec.Lifecycle == nil || r.Spec.Lifecycle.PreStop == nil) {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec drain configuration is incorrect, the collector images have no sleep command for the preStop hook, set drain.image or lifecycle.preStop")
+		}
+	}
+
+	// validate network policy
+	if r.Spec.Mode == ModeSidecar && r.Spec.NetworkPolicy.Enabled {
+		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'networkPolicy'", r.Spec.Mode)
+	}
+	if r.Spec.NetworkPolicy.RestrictEgress && !r.Spec.NetworkPolicy.Enabled {
+		return warnings, fmt.Errorf("the OpenTelemetry Spec networkPolicy.restrictEgress requires networkPolicy.enabled to be set")
+	}
+
 	// validate target allocation
 	if r.Spec.TargetAllocator.Enabled && r.Spec.Mode != ModeStatefulSet {
 		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the target allocation deployment", r.Spec.Mode)
@@ -273,6 +373,19 @@ func (c CollectorWebhook) validate(r *OpenTelemetryCollector) (admission.Warning
 	if r.Spec.Ingress.RuleType == IngressRuleTypeSubdomain && (r.Spec.Ingress.Hostname == "" || r.Spec.Ingress.Hostname == "*") {
 		return warnings, fmt.Errorf("a valid Ingress hostname has to be defined for subdomain ruleType")
 	}
+	if r.Spec.Ingress.Type == IngressTypeGateway {
+		if r.Spec.Mode == ModeSidecar {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec Ingress configuration is incorrect. Gateway routes can only be used in combination with the modes: %s, %s, %s",
+				ModeDeployment, ModeDaemonSet, ModeStatefulSet,
+			)
+		}
+		if r.Spec.Ingress.Gateway.Name == "" {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec Ingress configuration is incorrect, a gateway name has to be defined for the gateway type")
+		}
+		if r.Spec.Ingress.Hostname == "" || r.Spec.Ingress.Hostname == "*" {
+			return warnings, fmt.Errorf("a valid Ingress hostname has to be defined for the gateway type")
+		}
+	}
 
 	if r.Spec.LivenessProbe != nil {
 		if r.Spec.LivenessProbe.InitialDelaySeconds != nil && *r.Spec.LivenessProbe.InitialDelaySeconds < 0 {
@@ -300,6 +413,22 @@ func (c CollectorWebhook) validate(r *OpenTelemetryCollector) (admission.Warning
 		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'updateStrategy'", r.Spec.Mode)
 	}
 
+	// validate canary
+	if r.Spec.Canary != nil {
+		if r.Spec.Mode != ModeDeployment {
+			return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'canary'", r.Spec.Mode)
+		}
+		if _, err := adapters.ConfigFromString(r.Spec.Canary.Config); err != nil {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec Canary configuration is incorrect, %w", err)
+		}
+		if r.Spec.Canary.Replicas != nil && *r.Spec.Canary.Replicas < 1 {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec Canary configuration is incorrect, replicas should be one or more")
+		}
+		if r.Spec.Canary.Config == r.Spec.Config {
+			warnings = append(warnings, "the canary configuration is identical to the collector configuration")
+		}
+	}
+
 	return warnings, nil
 }
 
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
@@ -757,6 +758,186 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 			},
 			expectedErr: "the OpenTelemetry Collector mode is set to sidecar, which does not support the attribute 'AdditionalContainers'",
 		},
+		{
+			name: "invalid mode with sidecarPlacement",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:             ModeDeployment,
+					SidecarPlacement: SidecarPlacementInitContainer,
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to deployment, which does not support the attribute 'sidecarPlacement'",
+		},
+		{
+			name: "invalid mode with sidecarConfigReload",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:                ModeStatefulSet,
+					SidecarConfigReload: true,
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to statefulset, which does not support the attribute 'sidecarConfigReload'",
+		},
+		{
+			name: "invalid mode with sidecarSelector",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:            ModeDaemonSet,
+					SidecarSelector: &SidecarSelector{},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to daemonset, which does not support the attribute 'sidecarSelector'",
+		},
+		{
+			name: "invalid sidecarSelector podSelector",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeSidecar,
+					SidecarSelector: &SidecarSelector{
+						PodSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Unknown"}},
+						},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec sidecarSelector configuration is incorrect, invalid podSelector",
+		},
+		{
+			name: "invalid mode with sidecarAllowedNamespaces",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:                     ModeDeployment,
+					SidecarAllowedNamespaces: []string{"*"},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to deployment, which does not support the attribute 'sidecarAllowedNamespaces'",
+		},
+		{
+			name: "invalid sidecarAllowedNamespaces entry",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:                     ModeSidecar,
+					SidecarAllowedNamespaces: []string{"team-a", "Team_B"},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec sidecarAllowedNamespaces configuration is incorrect, 'Team_B' is not a namespace name",
+		},
+		{
+			name: "invalid mode with injectionFailurePolicy",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:                   ModeDeployment,
+					InjectionFailurePolicy: InjectionFailurePolicyFail,
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to deployment, which does not support the attribute 'injectionFailurePolicy'",
+		},
+		{
+			name: "invalid mode with agent",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:  ModeDeployment,
+					Agent: AgentSpec{Enabled: true},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to deployment, which does not support the attribute 'agent'",
+		},
+		{
+			name: "invalid mode with rbac",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeSidecar,
+					RBAC: CollectorRBACSpec{Enabled: true},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to sidecar, which does not support the attribute 'rbac'",
+		},
+		{
+			name: "invalid mode with persistence",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:        ModeSidecar,
+					Persistence: PersistenceSpec{Enabled: true},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to sidecar, which does not support the attribute 'persistence'",
+		},
+		{
+			name: "relative persistence hostPath",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:        ModeDaemonSet,
+					Persistence: PersistenceSpec{Enabled: true, HostPath: "otelcol"},
+				},
+			},
+			expectedErr: "hostPath should be an absolute path",
+		},
+		{
+			name: "persistence with a surge of daemonset pods",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:        ModeDaemonSet,
+					Persistence: PersistenceSpec{Enabled: true},
+					UpdateStrategy: appsv1.DaemonSetUpdateStrategy{
+						Type: appsv1.RollingUpdateDaemonSetStrategyType,
+						RollingUpdate: &appsv1.RollingUpdateDaemonSet{
+							MaxSurge:       &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+							MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 0},
+						},
+					},
+				},
+			},
+			expectedErr: "updateStrategy.rollingUpdate.maxSurge should be 0",
+		},
+		{
+			name: "invalid mode with drain",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:  ModeSidecar,
+					Drain: DrainSpec{Enabled: true},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to sidecar, which does not support the attribute 'drain'",
+		},
+		{
+			name: "invalid drain duration",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:  ModeStatefulSet,
+					Drain: DrainSpec{Enabled: true, Duration: &metav1.Duration{Duration: time.Millisecond}},
+				},
+			},
+			expectedErr: "duration should be one second or more",
+		},
+		{
+			name: "drain without an image or a preStop hook",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:  ModeStatefulSet,
+					Drain: DrainSpec{Enabled: true},
+				},
+			},
+			expectedErr: "the collector images have no sleep command for the preStop hook, set drain.image or lifecycle.preStop",
+		},
+		{
+			name: "invalid mode with networkPolicy",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:          ModeSidecar,
+					NetworkPolicy: CollectorNetworkPolicySpec{Enabled: true},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to sidecar, which does not support the attribute 'networkPolicy'",
+		},
+		{
+			name: "restrictEgress without networkPolicy",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					NetworkPolicy: CollectorNetworkPolicySpec{RestrictEgress: true},
+				},
+			},
+			expectedErr: "networkPolicy.restrictEgress requires networkPolicy.enabled",
+		},
 		{
 			name: "missing ingress hostname for subdomain ruleType",
 			otelcol: OpenTelemetryCollector{
@@ -768,6 +949,30 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 			},
 			expectedErr: "a valid Ingress hostname has to be defined for subdomain ruleType",
 		},
+		{
+			name: "missing gateway name for gateway type",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Ingress: Ingress{
+						Type:     IngressTypeGateway,
+						Hostname: "example.com",
+					},
+				},
+			},
+			expectedErr: "a gateway name has to be defined for the gateway type",
+		},
+		{
+			name: "missing ingress hostname for gateway type",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Ingress: Ingress{
+						Type:    IngressTypeGateway,
+						Gateway: GatewayRoute{Name: "public"},
+					},
+				},
+			},
+			expectedErr: "a valid Ingress hostname has to be defined for the gateway type",
+		},
 		{
 			name: "invalid updateStrategy for Deployment mode",
 			otelcol: OpenTelemetryCollector{
@@ -784,6 +989,43 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 			},
 			expectedErr: "the OpenTelemetry Collector mode is set to deployment, which does not support the attribute 'updateStrategy'",
 		},
+		{
+			name: "invalid mode with canary",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDaemonSet,
+					Canary: &CanarySpec{
+						Config: "receivers: {}",
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to daemonset, which does not support the attribute 'canary'",
+		},
+		{
+			name: "invalid canary config",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDeployment,
+					Canary: &CanarySpec{
+						Config: "receivers: [",
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec Canary configuration is incorrect",
+		},
+		{
+			name: "invalid canary replicas",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDeployment,
+					Canary: &CanarySpec{
+						Config:   "receivers: {}",
+						Replicas: &zero,
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec Canary configuration is incorrect, replicas should be one or more",
+		},
 	}
 
 	for _, test := range tests {
@@ -812,3 +1054,60 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 		})
 	}
 }
+
+func TestOTELColValidatingWebhookPersistenceWarning(t *testing.T) {
+	cvw := &CollectorWebhook{
+		logger: logr.Discard(),
+		scheme: testScheme,
+		cfg:    config.New(),
+	}
+	otelcol := OpenTelemetryCollector{
+		Spec: OpenTelemetryCollectorSpec{
+			Mode:        ModeDeployment,
+			Persistence: PersistenceSpec{Enabled: true},
+		},
+	}
+
+	warnings, err := cvw.ValidateCreate(context.Background(), &otelcol)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"the sending queues are stored in an emptyDir volume in deployment mode, the queued data is lost when a pod is deleted"}, warnings)
+}
+
+func TestOTELColValidatingWebhookSidecarSelectorWarning(t *testing.T) {
+	otelcol := OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "tenant"},
+		Spec: OpenTelemetryCollectorSpec{
+			Mode: ModeSidecar,
+			SidecarSelector: &SidecarSelector{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name     string
+		allowed  []string
+		warnings []string
+	}{
+		{
+			name:     "namespace not allowed to select other namespaces",
+			allowed:  []string{"platform"},
+			warnings: []string{"the namespace tenant isn't allowed to select the pods of other namespaces, the sidecarSelector only selects the pods of the collector's namespace"},
+		},
+		{
+			name:    "namespace allowed to select other namespaces",
+			allowed: []string{"platform", "tenant"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cvw := &CollectorWebhook{
+				logger: logr.Discard(),
+				scheme: testScheme,
+				cfg:    config.New(config.WithCrossNamespaceSelectorNamespaces(tt.allowed)),
+			}
+			warnings, err := cvw.ValidateCreate(context.Background(), &otelcol)
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, tt.warnings, warnings)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+type (
+	// InjectionFailurePolicy represents what happens to a pod when the injection it requested fails.
+	// +kubebuilder:validation:Enum=ignore;fail
+	InjectionFailurePolicy string
+)
+
+const (
+	// InjectionFailurePolicyIgnore specifies that the pod is created without the requested injection.
+	InjectionFailurePolicyIgnore InjectionFailurePolicy = "ignore"
+
+	// InjectionFailurePolicyFail specifies that the pod is rejected, guaranteeing that the pods run with the requested injection.
+	InjectionFailurePolicyFail InjectionFailurePolicy = "fail"
+)
@@ -76,6 +76,51 @@ type InstrumentationSpec struct {
 	// Nginx defines configuration for Nginx auto-instrumentation.
 	// +optional
 	Nginx Nginx `json:"nginx,omitempty"`
+
+	// Ruby defines configuration for Ruby auto-instrumentation.
+	// +optional
+	Ruby Ruby `json:"ruby,omitempty"`
+
+	// PHP defines configuration for PHP auto-instrumentation.
+	// +optional
+	PHP PHP `json:"php,omitempty"`
+
+	// InjectionFailurePolicy decides what happens to the pods this instrumentation can't be injected into:
+	// with "fail", their creation is rejected. A "fail" policy set with the "opentelemetry.io/injection-failure-policy"
+	// annotation on the pods' namespace takes precedence, and is the only one applied when no instrumentation
+	// could be selected for a pod. Default is "ignore".
+	// +optional
+	InjectionFailurePolicy InjectionFailurePolicy `json:"injectionFailurePolicy,omitempty"`
+
+	// LanguageDetection defines how the language of the containers is detected for the pods annotated with
+	// "instrumentation.opentelemetry.io/inject-auto".
+	// +optional
+	LanguageDetection LanguageDetection `json:"languageDetection,omitempty"`
+
+	// Selectors select the pods to inject the auto-instrumentation of the given languages into without the injection
+	// annotations.
+	// +optional
+	Selectors []InjectionSelector `json:"selectors,omitempty"`
+}
+
+// LanguageDetection defines how the language of the containers is detected.
+type LanguageDetection struct {
+	// Images maps container images to languages. The first mapping matching a container's image decides its
+	// language, before the container's command, env vars and image name are looked at.
+	// +optional
+	// +listType=atomic
+	Images []ImageLanguage `json:"images,omitempty"`
+}
+
+// ImageLanguage maps the container images matching a pattern to a language.
+type ImageLanguage struct {
+	// Image is a pattern matched against the container's image, e.g. "registry.example.com/payments/*".
+	// The syntax is the one of path.Match, where "*" doesn't match "/".
+	Image string `json:"image"`
+
+	// Language is the language of the containers running a matching image.
+	// +kubebuilder:validation:Enum=java;nodejs;python;dotnet;go;apache-httpd;nginx;ruby;php
+	Language string `json:"language"`
 }
 
 // Resource defines the configuration for the resource attributes, as defined by the OpenTelemetry specification.
@@ -96,6 +141,138 @@ type Exporter struct {
 	// Endpoint is address of the collector with OTLP endpoint.
 	// +optional
 	Endpoint string `json:"endpoint,omitempty"`
+
+	// NodeLocal sends the telemetry to the collector running on the node of the instrumented pods, reached
+	// through the node's IP. It replaces Endpoint.
+	// +optional
+	NodeLocal *NodeLocal `json:"nodeLocal,omitempty"`
+
+	// Collector references the OpenTelemetryCollector to send the telemetry to, through its Service. The port of
+	// its OTLP receiver for the exporter's protocol is used, the protocol of each language's auto-instrumentation
+	// being preferred when it isn't set. It replaces Endpoint.
+	// +optional
+	Collector *CollectorReference `json:"collector,omitempty"`
+
+	// Protocol is the transport protocol of the exporter.
+	// The value will be set in the OTEL_EXPORTER_OTLP_PROTOCOL env var.
+	// +optional
+	Protocol ExporterProtocol `json:"protocol,omitempty"`
+
+	// Headers are added to the requests of the exporter.
+	// The values will be set in the OTEL_EXPORTER_OTLP_HEADERS env var.
+	// +optional
+	// +listType=atomic
+	Headers []ExporterHeader `json:"headers,omitempty"`
+
+	// TLS defines the certificates used to connect to the endpoint.
+	// +optional
+	TLS *TLS `json:"tls,omitempty"`
+}
+
+// NodeLocal defines the collector running on the node of the instrumented pods. The collector has to listen on
+// the node's network, with hostNetwork or host ports.
+type NodeLocal struct {
+	// Collector is the name of an OpenTelemetryCollector in daemonset mode using the host network or host ports, in
+	// the namespace of the Instrumentation. The port of its OTLP receiver for the exporter's protocol is used, or the
+	// host port it's bound to, the protocol of each language's auto-instrumentation being preferred when it isn't set.
+	// +optional
+	Collector string `json:"collector,omitempty"`
+
+	// Port is the port of the collector when Collector isn't set.
+	// The default is 4317, or 4318 when the exporter's protocol is http/protobuf or http/json.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port,omitempty"`
+}
+
+// InjectionLanguage identifies a language as in its injection annotation, e.g. "java" for
+// "instrumentation.opentelemetry.io/inject-java".
+// +kubebuilder:validation:Enum=java;nodejs;python;dotnet;go;apache-httpd;nginx;ruby;php
+type InjectionLanguage string
+
+// InjectionSelector selects the pods the auto-instrumentation of some languages is injected into, as if they were
+// annotated with the languages' injection annotations naming the Instrumentation. The annotations set on the pods or
+// their namespaces take precedence, e.g. "false" excludes a pod.
+type InjectionSelector struct {
+	// Languages are the languages whose auto-instrumentation is injected into the selected pods.
+	// +kubebuilder:validation:MinItems=1
+	Languages []InjectionLanguage `json:"languages"`
+
+	// PodSelector selects the pods by their labels. All the pods of the selected namespaces are selected when it
+	// isn't set.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+
+	// NamespaceSelector selects the namespaces of the pods by their labels. Only the pods in the namespace of the
+	// Instrumentation are selected when it isn't set, or when the namespace of the Instrumentation isn't listed by the
+	// operator's --cross-namespace-selector-namespaces flag.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// ExcludePodSelector excludes the pods matching it.
+	// +optional
+	ExcludePodSelector *metav1.LabelSelector `json:"excludePodSelector,omitempty"`
+
+	// ExcludeNamespaceSelector excludes the pods of the namespaces matching it.
+	// +optional
+	ExcludeNamespaceSelector *metav1.LabelSelector `json:"excludeNamespaceSelector,omitempty"`
+}
+
+// CollectorReference references an OpenTelemetryCollector.
+type CollectorReference struct {
+	// Name is the name of the OpenTelemetryCollector.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the OpenTelemetryCollector, the namespace of the Instrumentation by default.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ExporterHeader defines a header of the requests of the exporter.
+type ExporterHeader struct {
+	// Name is the name of the header.
+	Name string `json:"name"`
+
+	// Value is the value of the header. Prefer SecretKeyRef for credentials.
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// SecretKeyRef selects the key of a Secret holding the value of the header.
+	// The Secret must be in the namespace of the instrumented pods.
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// TLS defines the certificates of the exporter. The Secret and ConfigMap holding them must be in the namespace of
+// the instrumented pods, they're mounted into the instrumented containers.
+type TLS struct {
+	// SecretName is the name of the Secret holding the client certificate and key, and the CA certificate
+	// when ConfigMapName isn't set.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// ConfigMapName is the name of the ConfigMap holding the CA certificate.
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// CA is the key of the CA certificate (e.g. ca.crt) in the ConfigMap or the Secret, or the absolute path
+	// of a certificate present in the containers already.
+	// The value will be set in the OTEL_EXPORTER_OTLP_CERTIFICATE env var.
+	// +optional
+	CA string `json:"ca,omitempty"`
+
+	// Cert is the key of the client certificate (e.g. tls.crt) in the Secret, or the absolute path of a
+	// certificate present in the containers already.
+	// The value will be set in the OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE env var.
+	// +optional
+	Cert string `json:"cert,omitempty"`
+
+	// Key is the key of the client private key (e.g. tls.key) in the Secret, or the absolute path of a
+	// private key present in the containers already.
+	// The value will be set in the OTEL_EXPORTER_OTLP_CLIENT_KEY env var.
+	// +optional
+	Key string `json:"key,omitempty"`
 }
 
 // Sampler defines sampling configuration.
@@ -130,6 +307,18 @@ type Java struct {
 	// +optional
 	Env []corev1.EnvVar `json:"env,omitempty"`
 
+	// Exporter overrides the exporter configuration of the Instrumentation for Java.
+	// +optional
+	Exporter *Exporter `json:"exporter,omitempty"`
+
+	// Sampler overrides the sampling configuration of the Instrumentation for Java.
+	// +optional
+	Sampler *Sampler `json:"sampler,omitempty"`
+
+	// Propagators overrides the propagators of the Instrumentation for Java.
+	// +optional
+	Propagators []Propagator `json:"propagators,omitempty"`
+
 	// Resources describes the compute resource requirements.
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
@@ -151,6 +340,18 @@ type NodeJS struct {
 	// +optional
 	Env []corev1.EnvVar `json:"env,omitempty"`
 
+	// Exporter overrides the exporter configuration of the Instrumentation for NodeJS.
+	// +optional
+	Exporter *Exporter `json:"exporter,omitempty"`
+
+	// Sampler overrides the sampling configuration of the Instrumentation for NodeJS.
+	// +optional
+	Sampler *Sampler `json:"sampler,omitempty"`
+
+	// Propagators overrides the propagators of the Instrumentation for NodeJS.
+	// +optional
+	Propagators []Propagator `json:"propagators,omitempty"`
+
 	// Resources describes the compute resource requirements.
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resourceRequirements,omitempty"`
@@ -172,6 +373,84 @@ type Python struct {
 	// +optional
 	Env []corev1.EnvVar `json:"env,omitempty"`
 
+	// Exporter overrides the exporter configuration of the Instrumentation for Python.
+	// +optional
+	Exporter *Exporter `json:"exporter,omitempty"`
+
+	// Sampler overrides the sampling configuration of the Instrumentation for Python.
+	// +optional
+	Sampler *Sampler `json:"sampler,omitempty"`
+
+	// Propagators overrides the propagators of the Instrumentation for Python.
+	// +optional
+	Propagators []Propagator `json:"propagators,omitempty"`
+
+	// Resources describes the compute resource requirements.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resourceRequirements,omitempty"`
+}
+
+// Ruby defines Ruby SDK and instrumentation configuration.
+type Ruby struct {
+	// Image is a container image with Ruby SDK and auto-instrumentation.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// VolumeSizeLimit defines size limit for volume used for auto-instrumentation.
+	// The default size is 200Mi.
+	VolumeSizeLimit *resource.Quantity `json:"volumeLimitSize,omitempty"`
+
+	// Env defines Ruby specific env vars. There are four layers for env vars' definitions and
+	// the precedence order is: `original container env vars` > `language specific env vars` > `common env vars` > `instrument spec configs' vars`.
+	// If the former var had been defined, then the other vars would be ignored.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Exporter overrides the exporter configuration of the Instrumentation for Ruby.
+	// +optional
+	Exporter *Exporter `json:"exporter,omitempty"`
+
+	// Sampler overrides the sampling configuration of the Instrumentation for Ruby.
+	// +optional
+	Sampler *Sampler `json:"sampler,omitempty"`
+
+	// Propagators overrides the propagators of the Instrumentation for Ruby.
+	// +optional
+	Propagators []Propagator `json:"propagators,omitempty"`
+
+	// Resources describes the compute resource requirements.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resourceRequirements,omitempty"`
+}
+
+// PHP defines PHP SDK and instrumentation configuration.
+type PHP struct {
+	// Image is a container image with PHP SDK and auto-instrumentation.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// VolumeSizeLimit defines size limit for volume used for auto-instrumentation.
+	// The default size is 200Mi.
+	VolumeSizeLimit *resource.Quantity `json:"volumeLimitSize,omitempty"`
+
+	// Env defines PHP specific env vars. There are four layers for env vars' definitions and
+	// the precedence order is: `original container env vars` > `language specific env vars` > `common env vars` > `instrument spec configs' vars`.
+	// If the former var had been defined, then the other vars would be ignored.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Exporter overrides the exporter configuration of the Instrumentation for PHP.
+	// +optional
+	Exporter *Exporter `json:"exporter,omitempty"`
+
+	// Sampler overrides the sampling configuration of the Instrumentation for PHP.
+	// +optional
+	Sampler *Sampler `json:"sampler,omitempty"`
+
+	// Propagators overrides the propagators of the Instrumentation for PHP.
+	// +optional
+	Propagators []Propagator `json:"propagators,omitempty"`
+
 	// Resources describes the compute resource requirements.
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resourceRequirements,omitempty"`
@@ -192,6 +471,19 @@ type DotNet struct {
 	// If the former var had been defined, then the other vars would be ignored.
 	// +optional
 	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Exporter overrides the exporter configuration of the Instrumentation for DotNet.
+	// +optional
+	Exporter *Exporter `json:"exporter,omitempty"`
+
+	// Sampler overrides the sampling configuration of the Instrumentation for DotNet.
+	// +optional
+	Sampler *Sampler `json:"sampler,omitempty"`
+
+	// Propagators overrides the propagators of the Instrumentation for DotNet.
+	// +optional
+	Propagators []Propagator `json:"propagators,omitempty"`
+
 	// Resources describes the compute resource requirements.
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resourceRequirements,omitempty"`
@@ -212,6 +504,18 @@ type Go struct {
 	// +optional
 	Env []corev1.EnvVar `json:"env,omitempty"`
 
+	// Exporter overrides the exporter configuration of the Instrumentation for Go.
+	// +optional
+	Exporter *Exporter `json:"exporter,omitempty"`
+
+	// Sampler overrides the sampling configuration of the Instrumentation for Go.
+	// +optional
+	Sampler *Sampler `json:"sampler,omitempty"`
+
+	// Propagators overrides the propagators of the Instrumentation for Go.
+	// +optional
+	Propagators []Propagator `json:"propagators,omitempty"`
+
 	// Resources describes the compute resource requirements.
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resourceRequirements,omitempty"`
@@ -233,6 +537,18 @@ type ApacheHttpd struct {
 	// +optional
 	Env []corev1.EnvVar `json:"env,omitempty"`
 
+	// Exporter overrides the exporter configuration of the Instrumentation for Apache HTTPD.
+	// +optional
+	Exporter *Exporter `json:"exporter,omitempty"`
+
+	// Sampler overrides the sampling configuration of the Instrumentation for Apache HTTPD.
+	// +optional
+	Sampler *Sampler `json:"sampler,omitempty"`
+
+	// Propagators overrides the propagators of the Instrumentation for Apache HTTPD.
+	// +optional
+	Propagators []Propagator `json:"propagators,omitempty"`
+
 	// Attrs defines Apache HTTPD agent specific attributes. The precedence is:
 	// `agent default attributes` > `instrument spec attributes` .
 	// Attributes are documented at https://github.com/open-telemetry/opentelemetry-cpp-contrib/tree/main/instrumentation/otel-webserver-module
@@ -269,6 +585,18 @@ type Nginx struct {
 	// +optional
 	Env []corev1.EnvVar `json:"env,omitempty"`
 
+	// Exporter overrides the exporter configuration of the Instrumentation for Nginx.
+	// +optional
+	Exporter *Exporter `json:"exporter,omitempty"`
+
+	// Sampler overrides the sampling configuration of the Instrumentation for Nginx.
+	// +optional
+	Sampler *Sampler `json:"sampler,omitempty"`
+
+	// Propagators overrides the propagators of the Instrumentation for Nginx.
+	// +optional
+	Propagators []Propagator `json:"propagators,omitempty"`
+
 	// Attrs defines Nginx agent specific attributes. The precedence order is:
 	// `agent default attributes` > `instrument spec attributes` .
 	// Attributes are documented at https://github.com/open-telemetry/opentelemetry-cpp-contrib/tree/main/instrumentation/otel-webserver-module
@@ -287,6 +615,84 @@ type Nginx struct {
 
 // InstrumentationStatus defines status of the instrumentation.
 type InstrumentationStatus struct {
+	// InstrumentedPods is the number of pods the Instrumentation was injected into.
+	// +optional
+	InstrumentedPods int32 `json:"instrumentedPods,omitempty"`
+
+	// Languages reports the pods instrumented with each language, sorted by language.
+	// +optional
+	// +listType=map
+	// +listMapKey=language
+	Languages []InstrumentationLanguageStatus `json:"languages,omitempty"`
+
+	// RecentFailures are the latest injections of the Instrumentation that failed, most recent first.
+	// At most 10 failures are listed, and they're forgotten once their events expire.
+	// +optional
+	// +listType=atomic
+	RecentFailures []InjectionFailure `json:"recentFailures,omitempty"`
+
+	// ObservedGeneration is the most recent generation of the Instrumentation observed by the operator.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the Instrumentation's state.
+	// Known condition types are Valid.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// InstrumentationLanguageStatus defines the state of the pods instrumented with a language.
+type InstrumentationLanguageStatus struct {
+	// Language is the instrumented language, e.g. "java" for the pods annotated with "instrumentation.opentelemetry.io/inject-java".
+	Language string `json:"language"`
+
+	// Pods is the number of pods instrumented with the language.
+	Pods int32 `json:"pods"`
+
+	// Images are the auto-instrumentation images the pods were injected with, sorted. Pods injected with
+	// another image than the one of the spec run an outdated agent until they're restarted.
+	// +optional
+	// +listType=atomic
+	Images []string `json:"images,omitempty"`
+
+	// Workloads are the workloads owning the instrumented pods, sorted. At most 100 workloads are listed.
+	// +optional
+	// +listType=atomic
+	Workloads []InstrumentedWorkload `json:"workloads,omitempty"`
+}
+
+// InstrumentedWorkload defines a workload owning instrumented pods.
+type InstrumentedWorkload struct {
+	// Kind is the kind of the workload, e.g. Deployment, or Pod for the pods without an owner.
+	Kind string `json:"kind"`
+
+	// Namespace is the namespace of the workload.
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the workload.
+	Name string `json:"name"`
+
+	// Pods is the number of instrumented pods of the workload.
+	Pods int32 `json:"pods"`
+}
+
+// InjectionFailure defines injections of the Instrumentation that failed for the same reason.
+type InjectionFailure struct {
+	// Reason is the reason of the failure, InstrumentationInjectionFailed or InstrumentationRequestRejected.
+	Reason string `json:"reason"`
+
+	// Message describes the failure and the workload it happened for.
+	Message string `json:"message"`
+
+	// Count is the number of times the failure happened.
+	Count int32 `json:"count"`
+
+	// LastTimestamp is the time the failure last happened.
+	LastTimestamp metav1.Time `json:"lastTimestamp"`
 }
 
 // +kubebuilder:object:root=true
@@ -296,6 +702,7 @@ type InstrumentationStatus struct {
 // +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".spec.exporter.endpoint"
 // +kubebuilder:printcolumn:name="Sampler",type="string",JSONPath=".spec.sampler.type"
 // +kubebuilder:printcolumn:name="Sampler Arg",type="string",JSONPath=".spec.sampler.argument"
+// +kubebuilder:printcolumn:name="Instrumented",type="integer",JSONPath=".status.instrumentedPods",description="Instrumented pods"
 // +operator-sdk:csv:customresourcedefinitions:displayName="OpenTelemetry Instrumentation"
 // +operator-sdk:csv:customresourcedefinitions:resources={{Pod,v1}}
 
@@ -17,17 +17,25 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"path"
+	"path/filepath"
 	"strconv"
 	"strings"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/strings/slices"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
 )
 
@@ -58,6 +66,8 @@ type InstrumentationWebhook struct {
 	logger logr.Logger
 	cfg    config.Config
 	scheme *runtime.Scheme
+	// reader gets the OpenTelemetryCollectors referenced by the exporters, which aren't checked when it's nil.
+	reader client.Reader
 }
 
 func (w InstrumentationWebhook) Default(ctx context.Context, obj runtime.Object) error {
@@ -73,7 +83,7 @@ func (w InstrumentationWebhook) ValidateCreate(ctx context.Context, obj runtime.
 	if !ok {
 		return nil, fmt.Errorf("expected an Instrumentation, received %T", obj)
 	}
-	return w.validate(inst)
+	return w.validate(ctx, inst)
 }
 
 func (w InstrumentationWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
@@ -81,7 +91,7 @@ func (w InstrumentationWebhook) ValidateUpdate(ctx context.Context, oldObj, newO
 	if !ok {
 		return nil, fmt.Errorf("expected an Instrumentation, received %T", newObj)
 	}
-	return w.validate(inst)
+	return w.validate(ctx, inst)
 }
 
 func (w InstrumentationWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
@@ -89,7 +99,7 @@ func (w InstrumentationWebhook) ValidateDelete(ctx context.Context, obj runtime.
 	if !ok || inst == nil {
 		return nil, fmt.Errorf("expected an Instrumentation, received %T", obj)
 	}
-	return w.validate(inst)
+	return w.validate(ctx, inst)
 }
 
 func (w InstrumentationWebhook) defaulter(r *Instrumentation) error {
@@ -202,6 +212,36 @@ func (w InstrumentationWebhook) defaulter(r *Instrumentation) error {
 	if r.Spec.Nginx.ConfigFile == "" {
 		r.Spec.Nginx.ConfigFile = "/etc/nginx/nginx.conf"
 	}
+	if r.Spec.Ruby.Image == "" {
+		r.Spec.Ruby.Image = w.cfg.AutoInstrumentationRubyImage()
+	}
+	if r.Spec.Ruby.Resources.Limits == nil {
+		r.Spec.Ruby.Resources.Limits = corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: resource.MustParse("64Mi"),
+		}
+	}
+	if r.Spec.Ruby.Resources.Requests == nil {
+		r.Spec.Ruby.Resources.Requests = corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("50m"),
+			corev1.ResourceMemory: resource.MustParse("64Mi"),
+		}
+	}
+	if r.Spec.PHP.Image == "" {
+		r.Spec.PHP.Image = w.cfg.AutoInstrumentationPHPImage()
+	}
+	if r.Spec.PHP.Resources.Limits == nil {
+		r.Spec.PHP.Resources.Limits = corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: resource.MustParse("64Mi"),
+		}
+	}
+	if r.Spec.PHP.Resources.Requests == nil {
+		r.Spec.PHP.Resources.Requests = corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("50m"),
+			corev1.ResourceMemory: resource.MustParse("64Mi"),
+		}
+	}
 	// Set the defaulting annotations
 	if r.Annotations == nil {
 		r.Annotations = map[string]string{}
@@ -213,37 +253,58 @@ func (w InstrumentationWebhook) defaulter(r *Instrumentation) error {
 	r.Annotations[constants.AnnotationDefaultAutoInstrumentationGo] = w.cfg.AutoInstrumentationGoImage()
 	r.Annotations[constants.AnnotationDefaultAutoInstrumentationApacheHttpd] = w.cfg.AutoInstrumentationApacheHttpdImage()
 	r.Annotations[constants.AnnotationDefaultAutoInstrumentationNginx] = w.cfg.AutoInstrumentationNginxImage()
+	r.Annotations[constants.AnnotationDefaultAutoInstrumentationRuby] = w.cfg.AutoInstrumentationRubyImage()
+	r.Annotations[constants.AnnotationDefaultAutoInstrumentationPHP] = w.cfg.AutoInstrumentationPHPImage()
 	return nil
 }
 
-func (w InstrumentationWebhook) validate(r *Instrumentation) (admission.Warnings, error) {
+func (w InstrumentationWebhook) validate(ctx context.Context, r *Instrumentation) (admission.Warnings, error) {
 	var warnings []string
-	switch r.Spec.Sampler.Type {
-	case "":
+	if r.Spec.Sampler.Type == "" {
 		warnings = append(warnings, "sampler type not set")
-	case TraceIDRatio, ParentBasedTraceIDRatio:
-		if r.Spec.Sampler.Argument != "" {
-			rate, err := strconv.ParseFloat(r.Spec.Sampler.Argument, 64)
-			if err != nil {
-				return warnings, fmt.Errorf("spec.sampler.argument is not a number: %s", r.Spec.Sampler.Argument)
-			}
-			if rate < 0 || rate > 1 {
-				return warnings, fmt.Errorf("spec.sampler.argument should be in rage [0..1]: %s", r.Spec.Sampler.Argument)
+	} else if err := validateSampler("spec.sampler", r.Spec.Sampler); err != nil {
+		return warnings, err
+	}
+	if err := validateExporter("spec.exporter", r.Spec.Exporter); err != nil {
+		return warnings, err
+	}
+	warnings = append(warnings, w.collectorWarnings(ctx, "spec.exporter", r.Namespace, r.Spec.Exporter)...)
+	languages := []struct {
+		field    string
+		exporter *Exporter
+		sampler  *Sampler
+	}{
+		{"spec.java", r.Spec.Java.Exporter, r.Spec.Java.Sampler},
+		{"spec.nodejs", r.Spec.NodeJS.Exporter, r.Spec.NodeJS.Sampler},
+		{"spec.python", r.Spec.Python.Exporter, r.Spec.Python.Sampler},
+		{"spec.dotnet", r.Spec.DotNet.Exporter, r.Spec.DotNet.Sampler},
+		{"spec.go", r.Spec.Go.Exporter, r.Spec.Go.Sampler},
+		{"spec.apacheHttpd", r.Spec.ApacheHttpd.Exporter, r.Spec.ApacheHttpd.Sampler},
+		{"spec.nginx", r.Spec.Nginx.Exporter, r.Spec.Nginx.Sampler},
+		{"spec.ruby", r.Spec.Ruby.Exporter, r.Spec.Ruby.Sampler},
+		{"spec.php", r.Spec.PHP.Exporter, r.Spec.PHP.Sampler},
+	}
+	for _, l := range languages {
+		if l.exporter != nil {
+			if err := validateExporter(l.field+".exporter", *l.exporter); err != nil {
+				return warnings, err
 			}
+			warnings = append(warnings, w.collectorWarnings(ctx, l.field+".exporter", r.Namespace, *l.exporter)...)
 		}
-	case JaegerRemote, ParentBasedJaegerRemote:
-		// value is a comma separated list of endpoint, pollingIntervalMs, initialSamplingRate
-		// Example: `endpoint=http://localhost:14250,pollingIntervalMs=5000,initialSamplingRate=0.25`
-		if r.Spec.Sampler.Argument != "" {
-			err := validateJaegerRemoteSamplerArgument(r.Spec.Sampler.Argument)
-
-			if err != nil {
-				return warnings, fmt.Errorf("spec.sampler.argument is not a valid argument for sampler %s: %w", r.Spec.Sampler.Type, err)
+		if l.sampler != nil {
+			if err := validateSampler(l.field+".sampler", *l.sampler); err != nil {
+				return warnings, err
 			}
 		}
-	case AlwaysOn, AlwaysOff, ParentBasedAlwaysOn, ParentBasedAlwaysOff, XRaySampler:
-	default:
-		return warnings, fmt.Errorf("spec.sampler.type is not valid: %s", r.Spec.Sampler.Type)
+	}
+	for i, selector := range r.Spec.Selectors {
+		field := fmt.Sprintf("spec.selectors[%d]", i)
+		if err := validateInjectionSelector(field, selector); err != nil {
+			return warnings, err
+		}
+		if selector.NamespaceSelector != nil && !slices.Contains(w.cfg.CrossNamespaceSelectorNamespaces(), r.Namespace) {
+			warnings = append(warnings, fmt.Sprintf("the namespace %s isn't allowed to select the pods of other namespaces, %s only selects the pods of the Instrumentation's namespace", r.Namespace, field))
+		}
 	}
 
 	// validate env vars
@@ -271,9 +332,162 @@ func (w InstrumentationWebhook) validate(r *Instrumentation) (admission.Warnings
 	if err := w.validateEnv(r.Spec.Nginx.Env); err != nil {
 		return warnings, err
 	}
+	if err := w.validateEnv(r.Spec.Ruby.Env); err != nil {
+		return warnings, err
+	}
+	if err := w.validateEnv(r.Spec.PHP.Env); err != nil {
+		return warnings, err
+	}
+
+	for _, mapping := range r.Spec.LanguageDetection.Images {
+		if _, err := path.Match(mapping.Image, ""); err != nil {
+			return warnings, fmt.Errorf("spec.languageDetection.images has an invalid image pattern: %s", mapping.Image)
+		}
+	}
 	return warnings, nil
 }
 
+// validateExporter validates the exporter set in the given field of the spec.
+func validateExporter(field string, exporter Exporter) error {
+	if exporter.Collector != nil {
+		if exporter.Endpoint != "" {
+			return fmt.Errorf("%s sets both an endpoint and a collector", field)
+		}
+		if exporter.NodeLocal != nil {
+			return fmt.Errorf("%s sets both nodeLocal and a collector", field)
+		}
+		if exporter.Collector.Name == "" {
+			return fmt.Errorf("%s.collector has no name", field)
+		}
+	}
+	if exporter.NodeLocal != nil {
+		if exporter.Endpoint != "" {
+			return fmt.Errorf("%s sets both an endpoint and nodeLocal", field)
+		}
+		if exporter.NodeLocal.Collector != "" && exporter.NodeLocal.Port != 0 {
+			return fmt.Errorf("%s.nodeLocal sets both a collector and a port", field)
+		}
+	}
+
+	for _, header := range exporter.Headers {
+		if header.Name == "" {
+			return fmt.Errorf("%s.headers has a header without name", field)
+		}
+		if header.Value != "" && header.SecretKeyRef != nil {
+			return fmt.Errorf("%s.headers sets both a value and a secretKeyRef for the header %s", field, header.Name)
+		}
+	}
+
+	if exporter.TLS == nil {
+		return nil
+	}
+	tls := exporter.TLS
+	if (tls.Cert == "") != (tls.Key == "") {
+		return fmt.Errorf("%s.tls should set both the cert and the key", field)
+	}
+	for _, file := range []struct {
+		name, value string
+	}{{"cert", tls.Cert}, {"key", tls.Key}} {
+		if file.value != "" && !filepath.IsAbs(file.value) && tls.SecretName == "" {
+			return fmt.Errorf("%s.tls.%s is not an absolute path and no secretName is set: %s", field, file.name, file.value)
+		}
+	}
+	if tls.CA != "" && !filepath.IsAbs(tls.CA) && tls.SecretName == "" && tls.ConfigMapName == "" {
+		return fmt.Errorf("%s.tls.ca is not an absolute path and no secretName or configMapName is set: %s", field, tls.CA)
+	}
+	return nil
+}
+
+// collectorWarnings returns the warnings about the OpenTelemetryCollector referenced by the exporter set in the
+// given field of the spec. The collector is resolved again at each injection, so it may be created or fixed later.
+func (w InstrumentationWebhook) collectorWarnings(ctx context.Context, field, namespace string, exporter Exporter) []string {
+	if w.reader == nil || exporter.Collector == nil {
+		return nil
+	}
+	name := types.NamespacedName{Namespace: exporter.Collector.Namespace, Name: exporter.Collector.Name}
+	if name.Namespace == "" {
+		name.Namespace = namespace
+	}
+	otelcol := OpenTelemetryCollector{}
+	if err := w.reader.Get(ctx, name, &otelcol); err != nil {
+		if apierrors.IsNotFound(err) {
+			return []string{fmt.Sprintf("%s.collector references the OpenTelemetryCollector %s, which doesn't exist", field, name)}
+		}
+		w.logger.Error(err, "failed to get the OpenTelemetryCollector of the exporter", "collector", name)
+		return nil
+	}
+	if otelcol.Spec.Mode == ModeSidecar {
+		return []string{fmt.Sprintf("%s.collector references the OpenTelemetryCollector %s, which is a sidecar", field, name)}
+	}
+
+	otlp := false
+	if config, err := adapters.ConfigFromString(otelcol.Spec.Config); err == nil {
+		ports, err := adapters.ConfigToOTLPPorts(w.logger, config)
+		otlp = err == nil && len(ports) > 0
+	}
+	if !otlp {
+		return []string{fmt.Sprintf("%s.collector references the OpenTelemetryCollector %s, which doesn't expose OTLP", field, name)}
+	}
+	return nil
+}
+
+// validateInjectionSelector validates the injection selector set in the given field of the spec.
+func validateInjectionSelector(field string, selector InjectionSelector) error {
+	if len(selector.Languages) == 0 {
+		return fmt.Errorf("%s should set the languages to inject", field)
+	}
+	if selector.PodSelector == nil && selector.NamespaceSelector == nil {
+		return fmt.Errorf("%s should set a podSelector or a namespaceSelector", field)
+	}
+	for _, s := range []struct {
+		name     string
+		selector *metav1.LabelSelector
+	}{
+		{"podSelector", selector.PodSelector},
+		{"namespaceSelector", selector.NamespaceSelector},
+		{"excludePodSelector", selector.ExcludePodSelector},
+		{"excludeNamespaceSelector", selector.ExcludeNamespaceSelector},
+	} {
+		if s.selector == nil {
+			continue
+		}
+		if _, err := metav1.LabelSelectorAsSelector(s.selector); err != nil {
+			return fmt.Errorf("%s.%s is invalid: %w", field, s.name, err)
+		}
+	}
+	return nil
+}
+
+// validateSampler validates the sampler set in the given field of the spec.
+func validateSampler(field string, sampler Sampler) error {
+	switch sampler.Type {
+	case TraceIDRatio, ParentBasedTraceIDRatio:
+		if sampler.Argument != "" {
+			rate, err := strconv.ParseFloat(sampler.Argument, 64)
+			if err != nil {
+				return fmt.Errorf("%s.argument is not a number: %s", field, sampler.Argument)
+			}
+			if rate < 0 || rate > 1 {
+				return fmt.Errorf("%s.argument should be in rage [0..1]: %s", field, sampler.Argument)
+			}
+		}
+	case JaegerRemote, ParentBasedJaegerRemote:
+		// value is a comma separated list of endpoint, pollingIntervalMs, initialSamplingRate
+		// Example: `endpoint=http://localhost:14250,pollingIntervalMs=5000,initialSamplingRate=0.25`
+		if sampler.Argument != "" {
+			err := validateJaegerRemoteSamplerArgument(sampler.Argument)
+
+			if err != nil {
+				return fmt.Errorf("%s.argument is not a valid argument for sampler %s: %w", field, sampler.Type, err)
+			}
+		}
+	case AlwaysOn, AlwaysOff, ParentBasedAlwaysOn, ParentBasedAlwaysOff, XRaySampler:
+	default:
+		return fmt.Errorf("%s.type is not valid: %s", field, sampler.Type)
+	}
+	return nil
+}
+
 func (w InstrumentationWebhook) validateEnv(envs []corev1.EnvVar) error {
 	for _, env := range envs {
 		if !strings.HasPrefix(env.Name, envPrefix) && !strings.HasPrefix(env.Name, envSplunkPrefix) {
@@ -314,11 +528,12 @@ func validateJaegerRemoteSamplerArgument(argument string) error {
 	return nil
 }
 
-func NewInstrumentationWebhook(logger logr.Logger, scheme *runtime.Scheme, cfg config.Config) *InstrumentationWebhook {
+func NewInstrumentationWebhook(logger logr.Logger, scheme *runtime.Scheme, cfg config.Config, reader client.Reader) *InstrumentationWebhook {
 	return &InstrumentationWebhook{
 		logger: logger,
 		scheme: scheme,
 		cfg:    cfg,
+		reader: reader,
 	}
 }
 
@@ -327,6 +542,7 @@ func SetupInstrumentationWebhook(mgr ctrl.Manager, cfg config.Config) error {
 		mgr.GetLogger().WithValues("handler", "InstrumentationWebhook"),
 		mgr.GetScheme(),
 		cfg,
+		mgr.GetClient(),
 	)
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&Instrumentation{}).
@@ -18,7 +18,13 @@ import (
 	"context"
 	"testing"
 
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
@@ -34,6 +40,8 @@ func TestInstrumentationDefaultingWebhook(t *testing.T) {
 			config.WithAutoInstrumentationDotNetImage("dotnet-img:1"),
 			config.WithAutoInstrumentationApacheHttpdImage("apache-httpd-img:1"),
 			config.WithAutoInstrumentationNginxImage("nginx-img:1"),
+			config.WithAutoInstrumentationRubyImage("ruby-img:1"),
+			config.WithAutoInstrumentationPHPImage("php-img:1"),
 		),
 	}.Default(context.Background(), inst)
 	assert.NoError(t, err)
@@ -43,6 +51,8 @@ func TestInstrumentationDefaultingWebhook(t *testing.T) {
 	assert.Equal(t, "dotnet-img:1", inst.Spec.DotNet.Image)
 	assert.Equal(t, "apache-httpd-img:1", inst.Spec.ApacheHttpd.Image)
 	assert.Equal(t, "nginx-img:1", inst.Spec.Nginx.Image)
+	assert.Equal(t, "ruby-img:1", inst.Spec.Ruby.Image)
+	assert.Equal(t, "php-img:1", inst.Spec.PHP.Image)
 }
 
 func TestInstrumentationValidatingWebhook(t *testing.T) {
@@ -113,6 +123,302 @@ func TestInstrumentationValidatingWebhook(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "language sampler",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedTraceIDRatio,
+					},
+					Nginx: Nginx{
+						Sampler: &Sampler{Type: AlwaysOff},
+					},
+				},
+			},
+		},
+		{
+			name: "language sampler argument is a wrong number",
+			err:  "spec.java.sampler.argument should be in rage [0..1]",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedTraceIDRatio,
+					},
+					Java: Java{
+						Sampler: &Sampler{Type: TraceIDRatio, Argument: "2"},
+					},
+				},
+			},
+		},
+		{
+			name: "language sampler type is missing",
+			err:  "spec.nodejs.sampler.type is not valid",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					NodeJS: NodeJS{
+						Sampler: &Sampler{Argument: "0.5"},
+					},
+				},
+			},
+			warnings: []string{"sampler type not set"},
+		},
+		{
+			name: "exporter headers and tls",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedTraceIDRatio,
+					},
+					Exporter: Exporter{
+						Endpoint: "https://gateway:4317",
+						Protocol: ExporterProtocolGRPC,
+						Headers: []ExporterHeader{{Name: "authorization", SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "otlp-auth"},
+							Key:                  "token",
+						}}},
+						TLS: &TLS{SecretName: "otlp-certs", ConfigMapName: "otlp-ca", CA: "ca.crt", Cert: "tls.crt", Key: "tls.key"},
+					},
+				},
+			},
+		},
+		{
+			name: "exporter header without name",
+			err:  "spec.exporter.headers has a header without name",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedTraceIDRatio,
+					},
+					Exporter: Exporter{
+						Headers: []ExporterHeader{{Value: "payments"}},
+					},
+				},
+			},
+		},
+		{
+			name: "exporter cert without key",
+			err:  "spec.exporter.tls should set both the cert and the key",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedTraceIDRatio,
+					},
+					Exporter: Exporter{
+						TLS: &TLS{SecretName: "otlp-certs", Cert: "tls.crt"},
+					},
+				},
+			},
+		},
+		{
+			name: "language exporter ca without secret",
+			err:  "spec.python.exporter.tls.ca is not an absolute path and no secretName or configMapName is set: ca.crt",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedTraceIDRatio,
+					},
+					Python: Python{
+						Exporter: &Exporter{TLS: &TLS{CA: "ca.crt"}},
+					},
+				},
+			},
+		},
+		{
+			name: "node-local exporter",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedTraceIDRatio,
+					},
+					Exporter: Exporter{
+						NodeLocal: &NodeLocal{Collector: "agent"},
+					},
+				},
+			},
+		},
+		{
+			name: "node-local exporter with an endpoint",
+			err:  "spec.exporter sets both an endpoint and nodeLocal",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedTraceIDRatio,
+					},
+					Exporter: Exporter{
+						Endpoint:  "http://collector:4317",
+						NodeLocal: &NodeLocal{Port: 4317},
+					},
+				},
+			},
+		},
+		{
+			name: "node-local exporter with a collector and a port",
+			err:  "spec.go.exporter.nodeLocal sets both a collector and a port",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedTraceIDRatio,
+					},
+					Go: Go{
+						Exporter: &Exporter{NodeLocal: &NodeLocal{Collector: "agent", Port: 4317}},
+					},
+				},
+			},
+		},
+		{
+			name: "injection selector",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedTraceIDRatio,
+					},
+					Selectors: []InjectionSelector{{
+						Languages:          []InjectionLanguage{"java", "python"},
+						PodSelector:        &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+						ExcludePodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "batch"}},
+					}},
+				},
+			},
+		},
+		{
+			name: "injection selector without pod or namespace selector",
+			err:  "spec.selectors[0] should set a podSelector or a namespaceSelector",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedTraceIDRatio,
+					},
+					Selectors: []InjectionSelector{{
+						Languages:          []InjectionLanguage{"python"},
+						ExcludePodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "batch"}},
+					}},
+				},
+			},
+		},
+		{
+			name: "invalid injection selector",
+			err:  "spec.selectors[1].excludeNamespaceSelector is invalid",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedTraceIDRatio,
+					},
+					Selectors: []InjectionSelector{
+						{
+							Languages:   []InjectionLanguage{"java"},
+							PodSelector: &metav1.LabelSelector{},
+						},
+						{
+							Languages:   []InjectionLanguage{"nodejs"},
+							PodSelector: &metav1.LabelSelector{},
+							ExcludeNamespaceSelector: &metav1.LabelSelector{
+								MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Unknown"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "injection selector without languages",
+			err:  "spec.selectors[0] should set the languages to inject",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedTraceIDRatio,
+					},
+					Selectors: []InjectionSelector{{
+						PodSelector: &metav1.LabelSelector{},
+					}},
+				},
+			},
+		},
+		{
+			name: "collector exporter",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedTraceIDRatio,
+					},
+					Exporter: Exporter{
+						Collector: &CollectorReference{Name: "gateway", Namespace: "observability"},
+					},
+				},
+			},
+		},
+		{
+			name: "collector exporter with an endpoint",
+			err:  "spec.exporter sets both an endpoint and a collector",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedTraceIDRatio,
+					},
+					Exporter: Exporter{
+						Endpoint:  "http://collector:4317",
+						Collector: &CollectorReference{Name: "gateway"},
+					},
+				},
+			},
+		},
+		{
+			name: "collector exporter with nodeLocal",
+			err:  "spec.java.exporter sets both nodeLocal and a collector",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedTraceIDRatio,
+					},
+					Java: Java{
+						Exporter: &Exporter{
+							NodeLocal: &NodeLocal{Port: 4317},
+							Collector: &CollectorReference{Name: "gateway"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "collector exporter without name",
+			err:  "spec.exporter.collector has no name",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedTraceIDRatio,
+					},
+					Exporter: Exporter{
+						Collector: &CollectorReference{Namespace: "observability"},
+					},
+				},
+			},
+		},
+		{
+			name: "image language mapping",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedTraceIDRatio,
+					},
+					LanguageDetection: LanguageDetection{
+						Images: []ImageLanguage{{Image: "registry.example.com/payments/*", Language: "java"}},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid image pattern",
+			err:  "spec.languageDetection.images has an invalid image pattern: registry.example.com/[payments",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedTraceIDRatio,
+					},
+					LanguageDetection: LanguageDetection{
+						Images: []ImageLanguage{{Image: "registry.example.com/[payments", Language: "java"}},
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -138,6 +444,114 @@ func TestInstrumentationValidatingWebhook(t *testing.T) {
 	}
 }
 
+func TestInstrumentationCollectorWarnings(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, AddToScheme(scheme))
+	collector := func(name string, mode Mode, config string) *OpenTelemetryCollector {
+		return &OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "observability"},
+			Spec:       OpenTelemetryCollectorSpec{Mode: mode, Config: config},
+		}
+	}
+	otlpConfig := "receivers:\n  otlp:\n    protocols:\n      grpc:\nexporters:\n  debug:\nservice:\n  pipelines:\n    traces:\n      receivers: [otlp]\n      exporters: [debug]\n"
+	zipkinConfig := "receivers:\n  zipkin:\nexporters:\n  debug:\nservice:\n  pipelines:\n    traces:\n      receivers: [zipkin]\n      exporters: [debug]\n"
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		collector("gateway", ModeDeployment, otlpConfig),
+		collector("zipkin", ModeDeployment, zipkinConfig),
+		collector("sidecar", ModeSidecar, otlpConfig),
+	).Build()
+	webhook := NewInstrumentationWebhook(logr.Discard(), scheme, config.New(), cli)
+
+	tests := []struct {
+		name      string
+		namespace string
+		exporter  Exporter
+		java      *Exporter
+		warnings  admission.Warnings
+	}{
+		{
+			name:      "collector in the namespace of the instrumentation",
+			namespace: "observability",
+			exporter:  Exporter{Collector: &CollectorReference{Name: "gateway"}},
+		},
+		{
+			name:      "collector in another namespace",
+			namespace: "app",
+			exporter:  Exporter{Collector: &CollectorReference{Name: "gateway", Namespace: "observability"}},
+		},
+		{
+			name:      "missing collector",
+			namespace: "app",
+			exporter:  Exporter{Collector: &CollectorReference{Name: "gateway"}},
+			warnings:  admission.Warnings{"spec.exporter.collector references the OpenTelemetryCollector app/gateway, which doesn't exist"},
+		},
+		{
+			name:      "collector without OTLP receiver",
+			namespace: "observability",
+			exporter:  Exporter{Endpoint: "http://collector:4317"},
+			java:      &Exporter{Collector: &CollectorReference{Name: "zipkin"}},
+			warnings:  admission.Warnings{"spec.java.exporter.collector references the OpenTelemetryCollector observability/zipkin, which doesn't expose OTLP"},
+		},
+		{
+			name:      "sidecar collector",
+			namespace: "observability",
+			exporter:  Exporter{Collector: &CollectorReference{Name: "sidecar"}},
+			warnings:  admission.Warnings{"spec.exporter.collector references the OpenTelemetryCollector observability/sidecar, which is a sidecar"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			inst := Instrumentation{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-inst", Namespace: test.namespace},
+				Spec: InstrumentationSpec{
+					Sampler:  Sampler{Type: ParentBasedAlwaysOn},
+					Exporter: test.exporter,
+					Java:     Java{Exporter: test.java},
+				},
+			}
+			warnings, err := webhook.ValidateCreate(context.Background(), &inst)
+			require.NoError(t, err)
+			assert.Equal(t, test.warnings, warnings)
+		})
+	}
+}
+
+func TestInstrumentationSelectorWarning(t *testing.T) {
+	inst := Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "tenant"},
+		Spec: InstrumentationSpec{
+			Sampler: Sampler{Type: ParentBasedAlwaysOn},
+			Selectors: []InjectionSelector{{
+				Languages:         []InjectionLanguage{"java"},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+			}},
+		},
+	}
+
+	for _, tt := range []struct {
+		name     string
+		allowed  []string
+		warnings admission.Warnings
+	}{
+		{
+			name:     "namespace not allowed to select other namespaces",
+			allowed:  []string{"observability"},
+			warnings: admission.Warnings{"the namespace tenant isn't allowed to select the pods of other namespaces, spec.selectors[0] only selects the pods of the Instrumentation's namespace"},
+		},
+		{
+			name:    "namespace allowed to select other namespaces",
+			allowed: []string{"observability", "tenant"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			webhook := NewInstrumentationWebhook(logr.Discard(), testScheme, config.New(config.WithCrossNamespaceSelectorNamespaces(tt.allowed)), nil)
+			warnings, err := webhook.ValidateCreate(context.Background(), &inst)
+			require.NoError(t, err)
+			assert.Equal(t, tt.warnings, warnings)
+		})
+	}
+}
+
 func TestInstrumentationJaegerRemote(t *testing.T) {
 	tests := []struct {
 		name string
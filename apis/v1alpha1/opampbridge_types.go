@@ -105,6 +105,9 @@ type OpAMPBridgeSpec struct {
 	// https://kubernetes.io/docs/concepts/workloads/pods/pod-topology-spread-constraints/
 	// +optional
 	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// NetworkPolicy configures the NetworkPolicy generated for the OpAMP Bridge.
+	// +optional
+	NetworkPolicy NetworkPolicySpec `json:"networkPolicy,omitempty"`
 }
 
 // OpAMPBridgeStatus defines the observed state of OpAMPBridge.
@@ -19,6 +19,7 @@ import (
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -50,7 +51,7 @@ const (
 // SEE: OpenTelemetryCollector.spec.ports[index].
 type Ingress struct {
 	// Type default value is: ""
-	// Supported types are: ingress, route
+	// Supported types are: ingress, route, gateway
 	Type IngressType `json:"type,omitempty"`
 
 	// RuleType defines how Ingress exposes collector receivers.
@@ -82,6 +83,11 @@ type Ingress struct {
 	// type "route" is used.
 	// +optional
 	Route OpenShiftRoute `json:"route,omitempty"`
+
+	// Gateway is a Gateway API specific section that is only considered when
+	// type "gateway" is used.
+	// +optional
+	Gateway GatewayRoute `json:"gateway,omitempty"`
 }
 
 // OpenShiftRoute defines openshift route specific settings.
@@ -90,6 +96,21 @@ type OpenShiftRoute struct {
 	Termination TLSRouteTerminationType `json:"termination,omitempty"`
 }
 
+// GatewayRoute defines Gateway API specific settings.
+type GatewayRoute struct {
+	// Name of the Gateway the routes attach to.
+	Name string `json:"name,omitempty"`
+
+	// Namespace of the Gateway the routes attach to. Defaults to the namespace of the collector.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName is the name of the Gateway listener the routes attach to.
+	// When unset, the routes attach to all the listeners allowing them.
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+}
+
 // OpenTelemetryCollectorSpec defines the desired state of OpenTelemetryCollector.
 type OpenTelemetryCollectorSpec struct {
 	// ManagementState defines if the CR should be managed by the operator or not.
@@ -160,6 +181,40 @@ type OpenTelemetryCollectorSpec struct {
 	// Mode represents how the collector should be deployed (deployment, daemonset, statefulset or sidecar)
 	// +optional
 	Mode Mode `json:"mode,omitempty"`
+	// SidecarPlacement represents where the collector is injected in the pods (auto, container or initContainer).
+	// As native sidecar containers, the collector starts before and stops after the application containers,
+	// and doesn't prevent Jobs from completing. Default is "auto".
+	// This is only applicable to Sidecar mode.
+	// +optional
+	SidecarPlacement SidecarPlacement `json:"sidecarPlacement,omitempty"`
+	// SidecarConfigReload makes the injected sidecars read their configuration from the collector's ConfigMap
+	// and reload it whenever it changes, so that configuration updates don't require the pods to be restarted:
+	// a reloader container sends a SIGHUP to the collector, which reloads it without restarting. The pods share
+	// their process namespace for the reloader to signal the collector.
+	// ConfigMap volumes can't reference other namespaces: pods in other namespaces keep getting the
+	// configuration at injection time.
+	// This is only applicable to Sidecar mode.
+	// +optional
+	SidecarConfigReload bool `json:"sidecarConfigReload,omitempty"`
+	// SidecarSelector selects the pods the collector is injected into, without the pods or their namespace
+	// naming it in the "sidecar.opentelemetry.io/inject" annotation, which takes precedence when it names an instance.
+	// This is only applicable to Sidecar mode.
+	// +optional
+	SidecarSelector *SidecarSelector `json:"sidecarSelector,omitempty"`
+	// SidecarAllowedNamespaces restricts the namespaces whose pods may use the collector as a sidecar, whether they
+	// reference it as "<namespace>/<name>" in the "sidecar.opentelemetry.io/inject" annotation or are selected by
+	// its sidecarSelector. "*" allows all the namespaces, and pods in the collector's namespace are always allowed.
+	// When not set, all the namespaces are allowed.
+	// This is only applicable to Sidecar mode.
+	// +optional
+	// +listType=set
+	SidecarAllowedNamespaces []string `json:"sidecarAllowedNamespaces,omitempty"`
+	// InjectionFailurePolicy decides what happens to the pods the sidecar can't be injected into once the collector
+	// was selected for them: with "fail", their creation is rejected. A "fail" policy set with the
+	// "opentelemetry.io/injection-failure-policy" annotation on the pods' namespace takes precedence. Default is "ignore".
+	// This is only applicable to Sidecar mode.
+	// +optional
+	InjectionFailurePolicy InjectionFailurePolicy `json:"injectionFailurePolicy,omitempty"`
 	// ServiceAccount indicates the name of an existing service account to use with this instance. When set,
 	// the operator will not automatically create a ServiceAccount for the collector.
 	// +optional
@@ -281,6 +336,34 @@ type OpenTelemetryCollectorSpec struct {
 	// This is only applicable to Daemonset mode.
 	// +optional
 	UpdateStrategy appsv1.DaemonSetUpdateStrategy `json:"updateStrategy,omitempty"`
+	// Canary configures a canary rollout of a new collector configuration. When set, the operator
+	// runs Canary.Config on a second, small workload behind the same Service, analyzes its health
+	// and either promotes the configuration to the main workload or aborts the rollout.
+	// This is only applicable to Deployment mode.
+	// +optional
+	Canary *CanarySpec `json:"canary,omitempty"`
+	// NetworkPolicy configures the NetworkPolicies generated for the collector and its target allocator.
+	// This is not applicable to Sidecar mode.
+	// +optional
+	NetworkPolicy CollectorNetworkPolicySpec `json:"networkPolicy,omitempty"`
+	// Agent configures the collector as a node agent, with the host-level defaults its receivers need.
+	// This is only applicable to DaemonSet mode.
+	// +optional
+	Agent AgentSpec `json:"agent,omitempty"`
+	// RBAC configures the RBAC objects generated for the collector's service account.
+	// This is not applicable to Sidecar mode.
+	// +optional
+	RBAC CollectorRBACSpec `json:"rbac,omitempty"`
+	// Persistence configures the storage of the exporters' sending queues, so that the queued data survives
+	// the collector's restarts.
+	// This is not applicable to Sidecar mode.
+	// +optional
+	Persistence PersistenceSpec `json:"persistence,omitempty"`
+	// Drain configures the graceful termination of the collector's pods, letting them flush their sending queues
+	// and the target allocator reassign their targets before they stop.
+	// This is not applicable to Sidecar mode.
+	// +optional
+	Drain DrainSpec `json:"drain,omitempty"`
 }
 
 // OpenTelemetryTargetAllocator defines the configurations for the Prometheus target allocator.
@@ -408,6 +491,31 @@ type OpenTelemetryCollectorStatus struct {
 	// +optional
 	// Deprecated: use "OpenTelemetryCollector.Status.Scale.Replicas" instead.
 	Replicas int32 `json:"replicas,omitempty"`
+
+	// Canary is the status of the latest canary rollout of a collector configuration.
+	// +optional
+	Canary *CanaryStatus `json:"canary,omitempty"`
+
+	// PodHealth aggregates the health of the collector pods. It is not set in sidecar mode.
+	// +optional
+	PodHealth *CollectorPodHealth `json:"podHealth,omitempty"`
+
+	// Sidecar reports the pods the collector was injected into. It is only set in sidecar mode.
+	// +optional
+	Sidecar *SidecarStatus `json:"sidecar,omitempty"`
+
+	// ObservedGeneration is the most recent generation of the OpenTelemetryCollector observed by the operator.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the OpenTelemetryCollector's state.
+	// Known condition types are Ready, ConfigValid, Reconciled, TargetAllocatorReady, Progressing and Degraded.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
 // +kubebuilder:object:root=true
@@ -506,6 +614,100 @@ type ObservabilitySpec struct {
 	Metrics MetricsConfigSpec `json:"metrics,omitempty"`
 }
 
+// NetworkPolicySpec defines the NetworkPolicy generated for an operand.
+type NetworkPolicySpec struct {
+	// Enabled indicates whether a NetworkPolicy should be generated, allowing ingress traffic only
+	// on the ports the operand is known to listen on.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// AgentSpec defines the host-level defaults of a collector running as a node agent.
+type AgentSpec struct {
+	// Enabled indicates whether the collector gets the defaults of a node agent: the K8S_NODE_NAME and K8S_NODE_IP
+	// environment variables, and the host paths needed by the filelog and hostmetrics receivers
+	// of its configuration, mounted read-only. The host's root filesystem is mounted at /hostfs for the
+	// hostmetrics receiver, whose root_path has to be set accordingly.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// HostPorts indicates whether the ports of the receivers should be bound on the node's IP too, with host ports
+	// equal to the container ports, so that the pods of the node can reach the collector without the host network.
+	// +optional
+	HostPorts bool `json:"hostPorts,omitempty"`
+}
+
+// CollectorRBACSpec defines the RBAC objects generated for the collector's service account.
+type CollectorRBACSpec struct {
+	// Enabled indicates whether a ClusterRole and ClusterRoleBinding granting the permissions needed by the
+	// k8s_cluster, k8sobjects, k8s_events and kubeletstats receivers and the k8sattributes processor of the
+	// configuration should be generated, with a Role and RoleBinding for the k8sobjects objects only watched in the
+	// collector's namespace. They are only generated when the operator holds these permissions itself, and never
+	// grant secrets.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// PersistenceSpec defines the storage of the exporters' sending queues.
+type PersistenceSpec struct {
+	// Enabled indicates whether the sending queues of the exporters should be stored on disk, with a file_storage
+	// extension added to the configuration. The storage is a persistent volume claim per replica in StatefulSet mode,
+	// a directory of the node in DaemonSet mode and an emptyDir volume, lost with the pod, in Deployment mode.
+	// The volume is owned by the pod's fsGroup, 10001 unless it's set, or by the collector's user in DaemonSet mode,
+	// where the pods of a node share the directory: the rolling updates don't surge, a maxSurge other than 0 is rejected.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// Size is the size of the persistent volume claims in StatefulSet mode. Default is 1Gi.
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+	// StorageClassName is the storage class of the persistent volume claims in StatefulSet mode.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// HostPath is the directory of the node storing the queues in DaemonSet mode.
+	// Default is /var/lib/otelcol/<namespace>/<name>.
+	// +optional
+	HostPath string `json:"hostPath,omitempty"`
+}
+
+// DrainSpec defines the graceful termination of the collector's pods.
+type DrainSpec struct {
+	// Enabled indicates whether the collector's container should wait before stopping, with a preStop hook, unless
+	// the lifecycle already defines one, and whether the termination grace period should be sized to that wait and
+	// to the shutdown of the exporters unless it is set. The target allocator stops assigning targets to the
+	// terminating pods. The collector images are built from scratch, without a sleep command: the image of the
+	// drain or a preStop hook has to be set.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// Image is an image providing a statically linked /bin/sleep, such as busybox, copied by an init container for
+	// the preStop hook to run it.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Duration is the wait of the preStop hook. Default is the longest timeout of the exporters with a sending
+	// queue, plus a few seconds for the pod to be removed from the endpoints and the targets to be reassigned.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+// CollectorNetworkPolicySpec defines the NetworkPolicies generated for the collector and its target allocator.
+type CollectorNetworkPolicySpec struct {
+	// Enabled indicates whether NetworkPolicies should be generated. The collector then only accepts ingress
+	// traffic on the ports of its receivers, the ports of the CR and its metrics port, and the target
+	// allocator only accepts traffic from the collector, and from the pods of the cluster scraping its metrics
+	// on the same port.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// RestrictEgress restricts the egress traffic of the collector to DNS, the target allocator, the ports
+	// of the targets configured in its receivers, such as the kubelet's port for the kubeletstats receiver
+	// and the static scrape targets of the prometheus receiver, and the ports of the endpoints configured in
+	// its exporters. As a NetworkPolicy cannot match host names, the traffic to those ports is allowed towards
+	// any destination. When a receiver discovers its targets at runtime, through a service discovery or the
+	// target allocator, all the egress traffic is allowed. The ports 443 and 6443 of the
+	// Kubernetes API server are allowed as well when a component of the configuration watches it, such as
+	// the k8s_cluster, k8s_events and k8sobjects receivers or the k8sattributes processor; an API server
+	// listening on another port needs an additional NetworkPolicy.
+	// +optional
+	RestrictEgress bool `json:"restrictEgress,omitempty"`
+}
+
 // Probe defines the OpenTelemetry's pod probe config. Only Liveness probe is supported currently.
 type Probe struct {
 	// Number of seconds after the container has started before liveness probes are initiated.
@@ -552,6 +754,147 @@ type MetricSpec struct {
 	Pods *autoscalingv2.PodsMetricSource `json:"pods,omitempty"`
 }
 
+// CollectorPodHealth defines the aggregated health of the collector pods.
+type CollectorPodHealth struct {
+	// Total is the number of collector pods.
+	// +optional
+	Total int32 `json:"total,omitempty"`
+	// Ready is the number of collector pods with a Ready condition.
+	// +optional
+	Ready int32 `json:"ready,omitempty"`
+	// Restarts is the total number of restarts of the collector containers.
+	// +optional
+	Restarts int32 `json:"restarts,omitempty"`
+	// RecentlyOOMKilled is the number of pods whose collector container was OOMKilled in the last hour.
+	// +optional
+	RecentlyOOMKilled int32 `json:"recentlyOOMKilled,omitempty"`
+	// RecentlyCrashed is the number of pods whose collector container terminated with an error in the last hour.
+	// +optional
+	RecentlyCrashed int32 `json:"recentlyCrashed,omitempty"`
+	// Summary is a human-readable summary of the pods health, e.g. "2/3 pods OOMKilled in the last hour".
+	// +optional
+	Summary string `json:"summary,omitempty"`
+	// Pods holds the health of the individual collector pods, sorted by name. At most 100 pods are listed.
+	// +optional
+	// +listType=atomic
+	Pods []CollectorPodStatus `json:"pods,omitempty"`
+}
+
+// CollectorPodStatus defines the health of a single collector pod.
+type CollectorPodStatus struct {
+	// Name of the pod.
+	Name string `json:"name"`
+	// Ready indicates whether the pod has a Ready condition.
+	// +optional
+	Ready bool `json:"ready,omitempty"`
+	// Restarts is the number of restarts of the collector container.
+	// +optional
+	Restarts int32 `json:"restarts,omitempty"`
+	// LastTerminationReason is the reason of the last termination of the collector container, e.g. OOMKilled or Error.
+	// +optional
+	LastTerminationReason string `json:"lastTerminationReason,omitempty"`
+	// LastTerminationTime is the time of the last termination of the collector container.
+	// +optional
+	LastTerminationTime *metav1.Time `json:"lastTerminationTime,omitempty"`
+	// Image is the image the collector container is actually running.
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// SidecarSelector selects the pods a sidecar collector is injected into.
+type SidecarSelector struct {
+	// PodSelector selects the pods by their labels. When not set, all the pods are selected.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+	// NamespaceSelector selects the namespaces of the pods by their labels. It only selects other namespaces than the
+	// collector's one when the operator allows its namespace to, with the --cross-namespace-selector-namespaces flag.
+	// When not set, only the pods in the collector's namespace are selected.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// Priority decides which collector is injected when several select the same pod: the highest priority wins,
+	// then the collector in the pod's namespace. Pods selected by several collectors of the same precedence
+	// don't get any sidecar. Default is 0.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+}
+
+// SidecarStatus defines the state of the pods a sidecar collector was injected into.
+type SidecarStatus struct {
+	// ConfigHash is the hash of the sidecar currently injected into the pods of the collector's namespace, covering
+	// its configuration, image and environment. Injected pods record the hash of the sidecar they were created with
+	// in the "sidecar.opentelemetry.io/config-hash" annotation.
+	// +optional
+	ConfigHash string `json:"configHash,omitempty"`
+	// InjectedPods is the number of pods the collector is injected into.
+	// +optional
+	InjectedPods int32 `json:"injectedPods,omitempty"`
+	// StalePods is the number of injected pods running an outdated sidecar, which need to be restarted
+	// to pick up the current one. Configuration changes don't make pods reloading their configuration stale.
+	// +optional
+	StalePods int32 `json:"stalePods,omitempty"`
+	// StalePodNames lists the stale pods as namespace/name, sorted. At most 100 pods are listed.
+	// +optional
+	// +listType=atomic
+	StalePodNames []string `json:"stalePodNames,omitempty"`
+}
+
+// CanarySpec defines a canary rollout of a collector configuration.
+type CanarySpec struct {
+	// Config is the collector configuration to run on the canary workload. Once the canary
+	// is promoted, the main workload runs it instead of the OpenTelemetryCollector's config,
+	// as long as the canary is set. The spec is never updated by the operator: to complete the
+	// rollout, set the config to this configuration and remove the canary.
+	// +required
+	Config string `json:"config"`
+	// Replicas is the number of canary pods. Traffic is split between the main and the canary
+	// pods behind the shared Service, so the ratio of replicas determines the canary's share of traffic.
+	// Default is 1.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// AnalysisDuration is how long the canary must stay healthy before it is promoted.
+	// Default is 5m.
+	// +optional
+	// +kubebuilder:validation:Format:=duration
+	AnalysisDuration *metav1.Duration `json:"analysisDuration,omitempty"`
+	// MaxRestarts is the number of container restarts across the canary pods after which the canary is aborted.
+	// Default is 0.
+	// +optional
+	MaxRestarts *int32 `json:"maxRestarts,omitempty"`
+	// MaxExporterSendFailures is the number of spans, metric points and log records that the canary's
+	// exporters may fail to send before the canary is aborted. Default is 0.
+	// +optional
+	MaxExporterSendFailures *int64 `json:"maxExporterSendFailures,omitempty"`
+	// MaxRefused is the number of spans, metric points and log records that the canary's
+	// receivers may refuse before the canary is aborted. Default is 0.
+	// +optional
+	MaxRefused *int64 `json:"maxRefused,omitempty"`
+}
+
+// CanaryStatus defines the observed state of a canary rollout.
+type CanaryStatus struct {
+	// Phase of the canary rollout.
+	// +optional
+	Phase CanaryPhase `json:"phase,omitempty"`
+	// ConfigHash is the sha256 of the canary configuration this status refers to.
+	// +optional
+	ConfigHash string `json:"configHash,omitempty"`
+	// StartTime is the time at which the analysis of the canary started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// Restarts is the number of container restarts observed on the canary pods.
+	// +optional
+	Restarts int32 `json:"restarts,omitempty"`
+	// ExporterSendFailures is the number of items the canary's exporters failed to send.
+	// +optional
+	ExporterSendFailures int64 `json:"exporterSendFailures,omitempty"`
+	// Refused is the number of items refused by the canary's receivers.
+	// +optional
+	Refused int64 `json:"refused,omitempty"`
+	// Message is a human-readable explanation of the current phase.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 type ConfigMapsSpec struct {
 	// Configmap defines name and path where the configMaps should be mounted.
 	Name      string `json:"name"`
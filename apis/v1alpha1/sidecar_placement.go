@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+type (
+	// SidecarPlacement represents where a sidecar collector is injected in the pod (containers vs. init containers).
+	// +kubebuilder:validation:Enum=auto;container;initContainer
+	SidecarPlacement string
+)
+
+const (
+	// SidecarPlacementAuto specifies that the sidecar is injected as a native sidecar container when the
+	// cluster enables them by default, and as a regular container otherwise.
+	SidecarPlacementAuto SidecarPlacement = "auto"
+
+	// SidecarPlacementContainer specifies that the sidecar is injected as a regular container.
+	SidecarPlacementContainer SidecarPlacement = "container"
+
+	// SidecarPlacementInitContainer specifies that the sidecar is injected as a native sidecar container,
+	// i.e. an init container with an "Always" restart policy. The SidecarContainers feature has to be enabled in the cluster.
+	SidecarPlacementInitContainer SidecarPlacement = "initContainer"
+)
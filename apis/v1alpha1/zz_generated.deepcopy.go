@@ -28,6 +28,21 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentSpec) DeepCopyInto(out *AgentSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentSpec.
+func (in *AgentSpec) DeepCopy() *AgentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApacheHttpd) DeepCopyInto(out *ApacheHttpd) {
 	*out = *in
@@ -43,6 +58,21 @@ func (in *ApacheHttpd) DeepCopyInto(out *ApacheHttpd) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Exporter != nil {
+		in, out := &in.Exporter, &out.Exporter
+		*out = new(Exporter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sampler != nil {
+		in, out := &in.Sampler, &out.Sampler
+		*out = new(Sampler)
+		**out = **in
+	}
+	if in.Propagators != nil {
+		in, out := &in.Propagators, &out.Propagators
+		*out = make([]Propagator, len(*in))
+		copy(*out, *in)
+	}
 	if in.Attrs != nil {
 		in, out := &in.Attrs, &out.Attrs
 		*out = make([]v1.EnvVar, len(*in))
@@ -110,6 +140,151 @@ func (in *AutoscalerSpec) DeepCopy() *AutoscalerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanarySpec) DeepCopyInto(out *CanarySpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AnalysisDuration != nil {
+		in, out := &in.AnalysisDuration, &out.AnalysisDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxRestarts != nil {
+		in, out := &in.MaxRestarts, &out.MaxRestarts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxExporterSendFailures != nil {
+		in, out := &in.MaxExporterSendFailures, &out.MaxExporterSendFailures
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxRefused != nil {
+		in, out := &in.MaxRefused, &out.MaxRefused
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanarySpec.
+func (in *CanarySpec) DeepCopy() *CanarySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CanarySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryStatus) DeepCopyInto(out *CanaryStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryStatus.
+func (in *CanaryStatus) DeepCopy() *CanaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectorNetworkPolicySpec) DeepCopyInto(out *CollectorNetworkPolicySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectorNetworkPolicySpec.
+func (in *CollectorNetworkPolicySpec) DeepCopy() *CollectorNetworkPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectorNetworkPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectorPodHealth) DeepCopyInto(out *CollectorPodHealth) {
+	*out = *in
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = make([]CollectorPodStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectorPodHealth.
+func (in *CollectorPodHealth) DeepCopy() *CollectorPodHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectorPodHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectorPodStatus) DeepCopyInto(out *CollectorPodStatus) {
+	*out = *in
+	if in.LastTerminationTime != nil {
+		in, out := &in.LastTerminationTime, &out.LastTerminationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectorPodStatus.
+func (in *CollectorPodStatus) DeepCopy() *CollectorPodStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectorPodStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectorRBACSpec) DeepCopyInto(out *CollectorRBACSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectorRBACSpec.
+func (in *CollectorRBACSpec) DeepCopy() *CollectorRBACSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectorRBACSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectorReference) DeepCopyInto(out *CollectorReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectorReference.
+func (in *CollectorReference) DeepCopy() *CollectorReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectorReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigMapsSpec) DeepCopyInto(out *ConfigMapsSpec) {
 	*out = *in
@@ -140,6 +315,21 @@ func (in *DotNet) DeepCopyInto(out *DotNet) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Exporter != nil {
+		in, out := &in.Exporter, &out.Exporter
+		*out = new(Exporter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sampler != nil {
+		in, out := &in.Sampler, &out.Sampler
+		*out = new(Sampler)
+		**out = **in
+	}
+	if in.Propagators != nil {
+		in, out := &in.Propagators, &out.Propagators
+		*out = make([]Propagator, len(*in))
+		copy(*out, *in)
+	}
 	in.Resources.DeepCopyInto(&out.Resources)
 }
 
@@ -153,9 +343,51 @@ func (in *DotNet) DeepCopy() *DotNet {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainSpec) DeepCopyInto(out *DrainSpec) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainSpec.
+func (in *DrainSpec) DeepCopy() *DrainSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Exporter) DeepCopyInto(out *Exporter) {
 	*out = *in
+	if in.NodeLocal != nil {
+		in, out := &in.NodeLocal, &out.NodeLocal
+		*out = new(NodeLocal)
+		**out = **in
+	}
+	if in.Collector != nil {
+		in, out := &in.Collector, &out.Collector
+		*out = new(CollectorReference)
+		**out = **in
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]ExporterHeader, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLS)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Exporter.
@@ -168,6 +400,41 @@ func (in *Exporter) DeepCopy() *Exporter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExporterHeader) DeepCopyInto(out *ExporterHeader) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExporterHeader.
+func (in *ExporterHeader) DeepCopy() *ExporterHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(ExporterHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayRoute) DeepCopyInto(out *GatewayRoute) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayRoute.
+func (in *GatewayRoute) DeepCopy() *GatewayRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Go) DeepCopyInto(out *Go) {
 	*out = *in
@@ -183,6 +450,21 @@ func (in *Go) DeepCopyInto(out *Go) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Exporter != nil {
+		in, out := &in.Exporter, &out.Exporter
+		*out = new(Exporter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sampler != nil {
+		in, out := &in.Sampler, &out.Sampler
+		*out = new(Sampler)
+		**out = **in
+	}
+	if in.Propagators != nil {
+		in, out := &in.Propagators, &out.Propagators
+		*out = make([]Propagator, len(*in))
+		copy(*out, *in)
+	}
 	in.Resources.DeepCopyInto(&out.Resources)
 }
 
@@ -196,6 +478,21 @@ func (in *Go) DeepCopy() *Go {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageLanguage) DeepCopyInto(out *ImageLanguage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageLanguage.
+func (in *ImageLanguage) DeepCopy() *ImageLanguage {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageLanguage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Ingress) DeepCopyInto(out *Ingress) {
 	*out = *in
@@ -219,6 +516,7 @@ func (in *Ingress) DeepCopyInto(out *Ingress) {
 		**out = **in
 	}
 	out.Route = in.Route
+	out.Gateway = in.Gateway
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ingress.
@@ -231,10 +529,66 @@ func (in *Ingress) DeepCopy() *Ingress {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InjectionFailure) DeepCopyInto(out *InjectionFailure) {
+	*out = *in
+	in.LastTimestamp.DeepCopyInto(&out.LastTimestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InjectionFailure.
+func (in *InjectionFailure) DeepCopy() *InjectionFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(InjectionFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InjectionSelector) DeepCopyInto(out *InjectionSelector) {
+	*out = *in
+	if in.Languages != nil {
+		in, out := &in.Languages, &out.Languages
+		*out = make([]InjectionLanguage, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExcludePodSelector != nil {
+		in, out := &in.ExcludePodSelector, &out.ExcludePodSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExcludeNamespaceSelector != nil {
+		in, out := &in.ExcludeNamespaceSelector, &out.ExcludeNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InjectionSelector.
+func (in *InjectionSelector) DeepCopy() *InjectionSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(InjectionSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Instrumentation) DeepCopyInto(out *Instrumentation) {
 	*out = *in
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	out.TypeMeta = in.TypeMeta
 	in.Spec.DeepCopyInto(&out.Spec)
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -258,6 +612,31 @@ func (in *Instrumentation) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstrumentationLanguageStatus) DeepCopyInto(out *InstrumentationLanguageStatus) {
+	*out = *in
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = make([]InstrumentedWorkload, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstrumentationLanguageStatus.
+func (in *InstrumentationLanguageStatus) DeepCopy() *InstrumentationLanguageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InstrumentationLanguageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstrumentationList) DeepCopyInto(out *InstrumentationList) {
 	*out = *in
@@ -293,7 +672,7 @@ func (in *InstrumentationList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstrumentationSpec) DeepCopyInto(out *InstrumentationSpec) {
 	*out = *in
-	out.Exporter = in.Exporter
+	in.Exporter.DeepCopyInto(&out.Exporter)
 	in.Resource.DeepCopyInto(&out.Resource)
 	if in.Propagators != nil {
 		in, out := &in.Propagators, &out.Propagators
@@ -315,6 +694,16 @@ func (in *InstrumentationSpec) DeepCopyInto(out *InstrumentationSpec) {
 	in.Go.DeepCopyInto(&out.Go)
 	in.ApacheHttpd.DeepCopyInto(&out.ApacheHttpd)
 	in.Nginx.DeepCopyInto(&out.Nginx)
+	in.Ruby.DeepCopyInto(&out.Ruby)
+	in.PHP.DeepCopyInto(&out.PHP)
+	in.LanguageDetection.DeepCopyInto(&out.LanguageDetection)
+	if in.Selectors != nil {
+		in, out := &in.Selectors, &out.Selectors
+		*out = make([]InjectionSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstrumentationSpec.
@@ -330,6 +719,27 @@ func (in *InstrumentationSpec) DeepCopy() *InstrumentationSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstrumentationStatus) DeepCopyInto(out *InstrumentationStatus) {
 	*out = *in
+	if in.Languages != nil {
+		in, out := &in.Languages, &out.Languages
+		*out = make([]InstrumentationLanguageStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RecentFailures != nil {
+		in, out := &in.RecentFailures, &out.RecentFailures
+		*out = make([]InjectionFailure, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstrumentationStatus.
@@ -342,6 +752,21 @@ func (in *InstrumentationStatus) DeepCopy() *InstrumentationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstrumentedWorkload) DeepCopyInto(out *InstrumentedWorkload) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstrumentedWorkload.
+func (in *InstrumentedWorkload) DeepCopy() *InstrumentedWorkload {
+	if in == nil {
+		return nil
+	}
+	out := new(InstrumentedWorkload)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Java) DeepCopyInto(out *Java) {
 	*out = *in
@@ -357,6 +782,21 @@ func (in *Java) DeepCopyInto(out *Java) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Exporter != nil {
+		in, out := &in.Exporter, &out.Exporter
+		*out = new(Exporter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sampler != nil {
+		in, out := &in.Sampler, &out.Sampler
+		*out = new(Sampler)
+		**out = **in
+	}
+	if in.Propagators != nil {
+		in, out := &in.Propagators, &out.Propagators
+		*out = make([]Propagator, len(*in))
+		copy(*out, *in)
+	}
 	in.Resources.DeepCopyInto(&out.Resources)
 }
 
@@ -370,6 +810,26 @@ func (in *Java) DeepCopy() *Java {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LanguageDetection) DeepCopyInto(out *LanguageDetection) {
+	*out = *in
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]ImageLanguage, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LanguageDetection.
+func (in *LanguageDetection) DeepCopy() *LanguageDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(LanguageDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricSpec) DeepCopyInto(out *MetricSpec) {
 	*out = *in
@@ -405,6 +865,21 @@ func (in *MetricsConfigSpec) DeepCopy() *MetricsConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicySpec) DeepCopyInto(out *NetworkPolicySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicySpec.
+func (in *NetworkPolicySpec) DeepCopy() *NetworkPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Nginx) DeepCopyInto(out *Nginx) {
 	*out = *in
@@ -420,6 +895,21 @@ func (in *Nginx) DeepCopyInto(out *Nginx) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Exporter != nil {
+		in, out := &in.Exporter, &out.Exporter
+		*out = new(Exporter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sampler != nil {
+		in, out := &in.Sampler, &out.Sampler
+		*out = new(Sampler)
+		**out = **in
+	}
+	if in.Propagators != nil {
+		in, out := &in.Propagators, &out.Propagators
+		*out = make([]Propagator, len(*in))
+		copy(*out, *in)
+	}
 	if in.Attrs != nil {
 		in, out := &in.Attrs, &out.Attrs
 		*out = make([]v1.EnvVar, len(*in))
@@ -455,6 +945,21 @@ func (in *NodeJS) DeepCopyInto(out *NodeJS) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Exporter != nil {
+		in, out := &in.Exporter, &out.Exporter
+		*out = new(Exporter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sampler != nil {
+		in, out := &in.Sampler, &out.Sampler
+		*out = new(Sampler)
+		**out = **in
+	}
+	if in.Propagators != nil {
+		in, out := &in.Propagators, &out.Propagators
+		*out = make([]Propagator, len(*in))
+		copy(*out, *in)
+	}
 	in.Resources.DeepCopyInto(&out.Resources)
 }
 
@@ -468,6 +973,21 @@ func (in *NodeJS) DeepCopy() *NodeJS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeLocal) DeepCopyInto(out *NodeLocal) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeLocal.
+func (in *NodeLocal) DeepCopy() *NodeLocal {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeLocal)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObservabilitySpec) DeepCopyInto(out *ObservabilitySpec) {
 	*out = *in
@@ -659,6 +1179,7 @@ func (in *OpAMPBridgeSpec) DeepCopyInto(out *OpAMPBridgeSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	out.NetworkPolicy = in.NetworkPolicy
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpAMPBridgeSpec.
@@ -821,6 +1342,16 @@ func (in *OpenTelemetryCollectorSpec) DeepCopyInto(out *OpenTelemetryCollectorSp
 		}
 	}
 	in.TargetAllocator.DeepCopyInto(&out.TargetAllocator)
+	if in.SidecarSelector != nil {
+		in, out := &in.SidecarSelector, &out.SidecarSelector
+		*out = new(SidecarSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SidecarAllowedNamespaces != nil {
+		in, out := &in.SidecarAllowedNamespaces, &out.SidecarAllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.VolumeMounts != nil {
 		in, out := &in.VolumeMounts, &out.VolumeMounts
 		*out = make([]v1.VolumeMount, len(*in))
@@ -919,6 +1450,16 @@ func (in *OpenTelemetryCollectorSpec) DeepCopyInto(out *OpenTelemetryCollectorSp
 		copy(*out, *in)
 	}
 	in.UpdateStrategy.DeepCopyInto(&out.UpdateStrategy)
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanarySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	out.NetworkPolicy = in.NetworkPolicy
+	out.Agent = in.Agent
+	out.RBAC = in.RBAC
+	in.Persistence.DeepCopyInto(&out.Persistence)
+	in.Drain.DeepCopyInto(&out.Drain)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenTelemetryCollectorSpec.
@@ -940,6 +1481,28 @@ func (in *OpenTelemetryCollectorStatus) DeepCopyInto(out *OpenTelemetryCollector
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanaryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodHealth != nil {
+		in, out := &in.PodHealth, &out.PodHealth
+		*out = new(CollectorPodHealth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sidecar != nil {
+		in, out := &in.Sidecar, &out.Sidecar
+		*out = new(SidecarStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenTelemetryCollectorStatus.
@@ -1046,6 +1609,74 @@ func (in *OpenTelemetryTargetAllocatorPrometheusCR) DeepCopy() *OpenTelemetryTar
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PHP) DeepCopyInto(out *PHP) {
+	*out = *in
+	if in.VolumeSizeLimit != nil {
+		in, out := &in.VolumeSizeLimit, &out.VolumeSizeLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Exporter != nil {
+		in, out := &in.Exporter, &out.Exporter
+		*out = new(Exporter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sampler != nil {
+		in, out := &in.Sampler, &out.Sampler
+		*out = new(Sampler)
+		**out = **in
+	}
+	if in.Propagators != nil {
+		in, out := &in.Propagators, &out.Propagators
+		*out = make([]Propagator, len(*in))
+		copy(*out, *in)
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PHP.
+func (in *PHP) DeepCopy() *PHP {
+	if in == nil {
+		return nil
+	}
+	out := new(PHP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistenceSpec) DeepCopyInto(out *PersistenceSpec) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistenceSpec.
+func (in *PersistenceSpec) DeepCopy() *PersistenceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistenceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodDisruptionBudgetSpec) DeepCopyInto(out *PodDisruptionBudgetSpec) {
 	*out = *in
@@ -1131,6 +1762,21 @@ func (in *Python) DeepCopyInto(out *Python) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Exporter != nil {
+		in, out := &in.Exporter, &out.Exporter
+		*out = new(Exporter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sampler != nil {
+		in, out := &in.Sampler, &out.Sampler
+		*out = new(Sampler)
+		**out = **in
+	}
+	if in.Propagators != nil {
+		in, out := &in.Propagators, &out.Propagators
+		*out = make([]Propagator, len(*in))
+		copy(*out, *in)
+	}
 	in.Resources.DeepCopyInto(&out.Resources)
 }
 
@@ -1166,6 +1812,49 @@ func (in *Resource) DeepCopy() *Resource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Ruby) DeepCopyInto(out *Ruby) {
+	*out = *in
+	if in.VolumeSizeLimit != nil {
+		in, out := &in.VolumeSizeLimit, &out.VolumeSizeLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Exporter != nil {
+		in, out := &in.Exporter, &out.Exporter
+		*out = new(Exporter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sampler != nil {
+		in, out := &in.Sampler, &out.Sampler
+		*out = new(Sampler)
+		**out = **in
+	}
+	if in.Propagators != nil {
+		in, out := &in.Propagators, &out.Propagators
+		*out = make([]Propagator, len(*in))
+		copy(*out, *in)
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ruby.
+func (in *Ruby) DeepCopy() *Ruby {
+	if in == nil {
+		return nil
+	}
+	out := new(Ruby)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Sampler) DeepCopyInto(out *Sampler) {
 	*out = *in
@@ -1195,3 +1884,63 @@ func (in *ScaleSubresourceStatus) DeepCopy() *ScaleSubresourceStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SidecarSelector) DeepCopyInto(out *SidecarSelector) {
+	*out = *in
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarSelector.
+func (in *SidecarSelector) DeepCopy() *SidecarSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(SidecarSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SidecarStatus) DeepCopyInto(out *SidecarStatus) {
+	*out = *in
+	if in.StalePodNames != nil {
+		in, out := &in.StalePodNames, &out.StalePodNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarStatus.
+func (in *SidecarStatus) DeepCopy() *SidecarStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SidecarStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLS) DeepCopyInto(out *TLS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLS.
+func (in *TLS) DeepCopy() *TLS {
+	if in == nil {
+		return nil
+	}
+	out := new(TLS)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,8 @@
+# Loaded through RUBYOPT by the init container injected by the OpenTelemetry Operator.
+require_relative "bundle/bundler/setup"
+
+require "opentelemetry/sdk"
+require "opentelemetry/exporter/otlp"
+require "opentelemetry/instrumentation/all"
+
+OpenTelemetry::SDK.configure(&:use_all)
@@ -76,7 +76,7 @@ func (k *Client) Watch(ctx context.Context, labelMap map[string]string, fn func(
 	}
 	for i := range pods.Items {
 		pod := pods.Items[i]
-		if pod.GetObjectMeta().GetDeletionTimestamp() == nil {
+		if !isDraining(&pod) {
 			collectorMap[pod.Name] = allocation.NewCollector(pod.Name)
 		}
 	}
@@ -130,7 +130,15 @@ func runWatch(ctx context.Context, k *Client, c <-chan watch.Event, collectorMap
 
 			switch event.Type { //nolint:exhaustive
 			case watch.Added:
-				collectorMap[pod.Name] = allocation.NewCollector(pod.Name)
+				if !isDraining(pod) {
+					collectorMap[pod.Name] = allocation.NewCollector(pod.Name)
+				}
+			case watch.Modified:
+				// a terminating collector drains its queues before stopping, its targets are reassigned meanwhile
+				if _, ok := collectorMap[pod.Name]; ok && isDraining(pod) {
+					k.log.Info("Collector pod is terminating, reassigning its targets", "pod", pod.Name)
+					delete(collectorMap, pod.Name)
+				}
 			case watch.Deleted:
 				delete(collectorMap, pod.Name)
 			}
@@ -139,6 +147,11 @@ func runWatch(ctx context.Context, k *Client, c <-chan watch.Event, collectorMap
 	}
 }
 
+// isDraining returns whether the collector of the given pod is terminating, and shouldn't get targets anymore.
+func isDraining(pod *v1.Pod) bool {
+	return pod.GetDeletionTimestamp() != nil
+}
+
 func (k *Client) Close() {
 	close(k.close)
 }
@@ -136,6 +136,31 @@ func Test_runWatch(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "pod draining",
+			args: args{
+				kubeFn: func(t *testing.T, client Client, group *sync.WaitGroup) {
+					p := pod("test-pod2")
+					p.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+					group.Add(1)
+					_, err := client.k8sClient.CoreV1().Pods("test-ns").Update(context.Background(), p, metav1.UpdateOptions{})
+					assert.NoError(t, err)
+				},
+				collectorMap: map[string]*allocation.Collector{
+					"test-pod1": {
+						Name: "test-pod1",
+					},
+					"test-pod2": {
+						Name: "test-pod2",
+					},
+				},
+			},
+			want: map[string]*allocation.Collector{
+				"test-pod1": {
+					Name: "test-pod1",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// collectorFinalizer lets the operator delete the cluster-scoped objects of a collector, which can't be owned by it.
+const collectorFinalizer = "opentelemetry.io/collector-cluster-objects"
+
+// manageClusterRBAC is the permission the operator needs to manage the cluster roles of the collectors.
+var manageClusterRBAC = rbacv1.PolicyRule{
+	APIGroups: []string{rbacv1.GroupName},
+	Resources: []string{"clusterroles", "clusterrolebindings"},
+	Verbs:     []string{"create", "update", "delete"},
+}
+
+// manageRBAC is the permission the operator needs to manage the roles of the collectors in their namespace.
+var manageRBAC = rbacv1.PolicyRule{
+	APIGroups: []string{rbacv1.GroupName},
+	Resources: []string{"roles", "rolebindings"},
+	Verbs:     []string{"create", "update", "delete"},
+}
+
+// reconcileFinalizer adds the finalizer to the collectors generating cluster-scoped objects, and removes it from the
+// other ones once their cluster-scoped objects have been deleted.
+func (r *OpenTelemetryCollectorReconciler) reconcileFinalizer(ctx context.Context, instance *v1alpha1.OpenTelemetryCollector) error {
+	hasFinalizer := controllerutil.ContainsFinalizer(instance, collectorFinalizer)
+	if instance.Spec.RBAC.Enabled && !hasFinalizer {
+		controllerutil.AddFinalizer(instance, collectorFinalizer)
+		return r.Update(ctx, instance)
+	}
+	if !instance.Spec.RBAC.Enabled && hasFinalizer {
+		return r.finalize(ctx, instance)
+	}
+	return nil
+}
+
+// finalize deletes the cluster-scoped objects of the collector and removes its finalizer.
+func (r *OpenTelemetryCollectorReconciler) finalize(ctx context.Context, instance *v1alpha1.OpenTelemetryCollector) error {
+	if !controllerutil.ContainsFinalizer(instance, collectorFinalizer) {
+		return nil
+	}
+	if err := r.deleteClusterRBAC(ctx, *instance); err != nil {
+		return err
+	}
+	if err := r.deleteRBAC(ctx, *instance); err != nil {
+		return err
+	}
+	controllerutil.RemoveFinalizer(instance, collectorFinalizer)
+	return r.Update(ctx, instance)
+}
+
+// reconcileRBAC removes the collector's cluster role, role and their bindings from the desired objects when the
+// operator doesn't hold the permissions they grant, as it can't grant them, and deletes them when they aren't desired.
+func (r *OpenTelemetryCollectorReconciler) reconcileRBAC(ctx context.Context, log logr.Logger, instance *v1alpha1.OpenTelemetryCollector, desired []client.Object) ([]client.Object, error) {
+	if !instance.Spec.RBAC.Enabled {
+		return desired, nil
+	}
+
+	var clusterRole *rbacv1.ClusterRole
+	var role *rbacv1.Role
+	for _, obj := range desired {
+		switch o := obj.(type) {
+		case *rbacv1.ClusterRole:
+			clusterRole = o
+		case *rbacv1.Role:
+			role = o
+		}
+	}
+
+	keepClusterRole := false
+	if clusterRole != nil {
+		missing, err := r.reviewer.MissingPermissions(ctx, append(clusterRole.Rules, manageClusterRBAC)...)
+		if err != nil {
+			return nil, err
+		}
+		keepClusterRole = r.permitted(log, instance, "cluster role", missing)
+	}
+	keepRole := false
+	if role != nil {
+		missing, err := r.reviewer.MissingPermissionsIn(ctx, instance.Namespace, append(role.Rules, manageRBAC)...)
+		if err != nil {
+			return nil, err
+		}
+		keepRole = r.permitted(log, instance, "role", missing)
+	}
+
+	var permitted []client.Object
+	for _, obj := range desired {
+		switch obj.(type) {
+		case *rbacv1.ClusterRole, *rbacv1.ClusterRoleBinding:
+			if !keepClusterRole {
+				continue
+			}
+		case *rbacv1.Role, *rbacv1.RoleBinding:
+			if !keepRole {
+				continue
+			}
+		}
+		permitted = append(permitted, obj)
+	}
+
+	if !keepClusterRole {
+		if err := r.deleteClusterRBAC(ctx, *instance); err != nil {
+			return nil, err
+		}
+	}
+	if !keepRole {
+		if err := r.deleteRBAC(ctx, *instance); err != nil {
+			return nil, err
+		}
+	}
+	return permitted, nil
+}
+
+// permitted returns whether the operator holds all the permissions granted by the collector's role of the given
+// kind, and reports the missing ones otherwise.
+func (r *OpenTelemetryCollectorReconciler) permitted(log logr.Logger, instance *v1alpha1.OpenTelemetryCollector, kind string, missing []string) bool {
+	if len(missing) == 0 {
+		return true
+	}
+	msg := fmt.Sprintf("the collector's %s can't be generated, the operator is missing the permissions: %s", kind, strings.Join(missing, ", "))
+	log.Info(msg)
+	r.recorder.Event(instance, corev1.EventTypeWarning, "RBACNotGenerated", msg)
+	return false
+}
+
+// deleteClusterRBAC deletes the collector's cluster role and binding, if any.
+func (r *OpenTelemetryCollectorReconciler) deleteClusterRBAC(ctx context.Context, instance v1alpha1.OpenTelemetryCollector) error {
+	for _, obj := range []client.Object{
+		&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: naming.ClusterRoleBinding(instance.Name, instance.Namespace)}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: naming.ClusterRole(instance.Name, instance.Namespace)}},
+	} {
+		err := r.Delete(ctx, obj)
+		if apierrors.IsForbidden(err) {
+			// the operator couldn't have created it either
+			r.log.V(1).Info("not allowed to delete the collector's cluster-scoped object", "name", obj.GetName(), "error", err.Error())
+			continue
+		}
+		if client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete %s: %w", obj.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// deleteRBAC deletes the collector's role and binding, if any.
+func (r *OpenTelemetryCollectorReconciler) deleteRBAC(ctx context.Context, instance v1alpha1.OpenTelemetryCollector) error {
+	for _, obj := range []client.Object{
+		&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: naming.RoleBinding(instance.Name), Namespace: instance.Namespace}},
+		&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: naming.Role(instance.Name), Namespace: instance.Namespace}},
+	} {
+		err := r.Delete(ctx, obj)
+		if apierrors.IsForbidden(err) {
+			// the operator couldn't have created it either
+			r.log.V(1).Info("not allowed to delete the collector's role", "name", obj.GetName(), "error", err.Error())
+			continue
+		}
+		if client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete %s: %w", obj.GetName(), err)
+		}
+	}
+	return nil
+}
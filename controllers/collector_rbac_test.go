@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestReconcileClusterRBAC(t *testing.T) {
+	otelcol := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-instance", Namespace: "my-namespace"},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeDeployment,
+			RBAC: v1alpha1.CollectorRBACSpec{Enabled: true},
+		},
+	}
+	clusterRole := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-instance-my-namespace-collector"},
+		Rules:      []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"nodes/stats"}, Verbs: []string{"get"}}},
+	}
+	clusterRoleBinding := &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "my-instance-my-namespace-collector"}}
+	serviceAccount := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "my-instance-collector", Namespace: "my-namespace"}}
+
+	for _, tt := range []struct {
+		desc     string
+		allowed  bool
+		expected []client.Object
+		event    string
+	}{
+		{
+			desc:     "permitted",
+			allowed:  true,
+			expected: []client.Object{serviceAccount, clusterRole, clusterRoleBinding},
+		},
+		{
+			desc:     "missing permissions",
+			expected: []client.Object{serviceAccount},
+			event:    "Warning RBACNotGenerated the collector's cluster role can't be generated, the operator is missing the permissions: get nodes/stats, create rbac.authorization.k8s.io/clusterroles",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			cli := fake.NewClientBuilder().WithScheme(rolloutScheme(t)).WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					ssar := obj.(*authorizationv1.SelfSubjectAccessReview)
+					// the operator is only missing the permissions to create cluster roles and read node stats
+					attributes := ssar.Spec.ResourceAttributes
+					ssar.Status.Allowed = tt.allowed || !(attributes.Verb == "get" && attributes.Subresource == "stats") &&
+						!(attributes.Verb == "create" && attributes.Resource == "clusterroles")
+					return nil
+				},
+			}).WithObjects(clusterRole.DeepCopy(), clusterRoleBinding.DeepCopy()).Build()
+			recorder := record.NewFakeRecorder(1)
+			r := NewReconciler(Params{Client: cli, Recorder: recorder, Log: logf.Log, Config: config.New()})
+
+			desired, err := r.reconcileRBAC(context.Background(), logf.Log, otelcol, []client.Object{serviceAccount, clusterRole, clusterRoleBinding})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, desired)
+			if tt.event == "" {
+				assert.Empty(t, recorder.Events)
+				return
+			}
+			require.Len(t, recorder.Events, 1)
+			assert.Equal(t, tt.event, <-recorder.Events)
+			// the objects generated before are deleted
+			err = cli.Get(context.Background(), client.ObjectKeyFromObject(clusterRole), &rbacv1.ClusterRole{})
+			assert.True(t, apierrors.IsNotFound(err))
+			err = cli.Get(context.Background(), client.ObjectKeyFromObject(clusterRoleBinding), &rbacv1.ClusterRoleBinding{})
+			assert.True(t, apierrors.IsNotFound(err))
+		})
+	}
+}
+
+func TestReconcileRole(t *testing.T) {
+	otelcol := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-instance", Namespace: "my-namespace"},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeDeployment,
+			RBAC: v1alpha1.CollectorRBACSpec{Enabled: true},
+		},
+	}
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-instance-collector", Namespace: "my-namespace"},
+		Rules:      []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"list"}}},
+	}
+	roleBinding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "my-instance-collector", Namespace: "my-namespace"}}
+
+	for _, tt := range []struct {
+		desc      string
+		namespace string
+		expected  []client.Object
+		event     string
+	}{
+		{
+			desc:      "permitted in the collector's namespace",
+			namespace: "my-namespace",
+			expected:  []client.Object{role, roleBinding},
+		},
+		{
+			desc:      "missing permissions",
+			namespace: "other-namespace",
+			event:     "Warning RBACNotGenerated the collector's role can't be generated, the operator is missing the permissions: list configmaps, create rbac.authorization.k8s.io/roles, update rbac.authorization.k8s.io/roles, delete rbac.authorization.k8s.io/roles, create rbac.authorization.k8s.io/rolebindings, update rbac.authorization.k8s.io/rolebindings, delete rbac.authorization.k8s.io/rolebindings",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			cli := fake.NewClientBuilder().WithScheme(rolloutScheme(t)).WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					ssar := obj.(*authorizationv1.SelfSubjectAccessReview)
+					// the operator only holds permissions in a single namespace
+					ssar.Status.Allowed = ssar.Spec.ResourceAttributes.Namespace == tt.namespace
+					return nil
+				},
+			}).WithObjects(role.DeepCopy(), roleBinding.DeepCopy()).Build()
+			recorder := record.NewFakeRecorder(1)
+			r := NewReconciler(Params{Client: cli, Recorder: recorder, Log: logf.Log, Config: config.New()})
+
+			desired, err := r.reconcileRBAC(context.Background(), logf.Log, otelcol, []client.Object{role, roleBinding})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, desired)
+			if tt.event == "" {
+				assert.Empty(t, recorder.Events)
+				return
+			}
+			require.Len(t, recorder.Events, 1)
+			assert.Equal(t, tt.event, <-recorder.Events)
+			// the objects generated before are deleted
+			err = cli.Get(context.Background(), client.ObjectKeyFromObject(role), &rbacv1.Role{})
+			assert.True(t, apierrors.IsNotFound(err))
+			err = cli.Get(context.Background(), client.ObjectKeyFromObject(roleBinding), &rbacv1.RoleBinding{})
+			assert.True(t, apierrors.IsNotFound(err))
+		})
+	}
+}
+
+func TestReconcileFinalizer(t *testing.T) {
+	otelcol := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-instance", Namespace: "my-namespace"},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeDeployment,
+			RBAC: v1alpha1.CollectorRBACSpec{Enabled: true},
+		},
+	}
+	clusterRole := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "my-instance-my-namespace-collector"}}
+	role := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "my-instance-collector", Namespace: "my-namespace"}}
+	cli := fake.NewClientBuilder().WithScheme(rolloutScheme(t)).WithObjects(otelcol, clusterRole, role).Build()
+	r := NewReconciler(Params{Client: cli, Recorder: record.NewFakeRecorder(1), Log: logf.Log, Config: config.New()})
+
+	instance := &v1alpha1.OpenTelemetryCollector{}
+	require.NoError(t, cli.Get(context.Background(), client.ObjectKeyFromObject(otelcol), instance))
+	require.NoError(t, r.reconcileFinalizer(context.Background(), instance))
+	require.NoError(t, cli.Get(context.Background(), client.ObjectKeyFromObject(otelcol), instance))
+	assert.Equal(t, []string{collectorFinalizer}, instance.Finalizers)
+
+	// disabling the generation deletes the objects generated before
+	instance.Spec.RBAC.Enabled = false
+	require.NoError(t, r.reconcileFinalizer(context.Background(), instance))
+	require.NoError(t, cli.Get(context.Background(), client.ObjectKeyFromObject(otelcol), instance))
+	assert.Empty(t, instance.Finalizers)
+	err := cli.Get(context.Background(), client.ObjectKeyFromObject(clusterRole), &rbacv1.ClusterRole{})
+	assert.True(t, apierrors.IsNotFound(err))
+	err = cli.Get(context.Background(), client.ObjectKeyFromObject(role), &rbacv1.Role{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
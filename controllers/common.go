@@ -20,8 +20,10 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -43,6 +45,24 @@ func isNamespaceScoped(obj client.Object) bool {
 	}
 }
 
+// deleteOptions returns the options deleting an object whose immutable fields changed. The pods of a Deployment
+// still matched by the new selector are orphaned rather than deleted, for the new Deployment to adopt them.
+func deleteOptions(existing, desired client.Object) []client.DeleteOption {
+	existingDeployment, ok := existing.(*appsv1.Deployment)
+	if !ok {
+		return nil
+	}
+	desiredDeployment, ok := desired.(*appsv1.Deployment)
+	if !ok || desiredDeployment.Spec.Selector == nil {
+		return nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(desiredDeployment.Spec.Selector)
+	if err != nil || !selector.Matches(labels.Set(existingDeployment.Spec.Template.Labels)) {
+		return nil
+	}
+	return []client.DeleteOption{client.PropagationPolicy(metav1.DeletePropagationOrphan)}
+}
+
 // BuildCollector returns the generation and collected errors of all manifests for a given instance.
 func BuildCollector(params manifests.Params) ([]client.Object, error) {
 	builders := []manifests.Builder{
@@ -104,7 +124,7 @@ func reconcileDesiredObjects(ctx context.Context, kubeClient client.Client, logg
 		})
 		if crudErr != nil && errors.Is(crudErr, manifests.ImmutableChangeErr) {
 			l.Error(crudErr, "detected immutable field change, trying to delete, new object will be created on next reconcile", "existing", existing.GetName())
-			delErr := kubeClient.Delete(ctx, existing)
+			delErr := kubeClient.Delete(ctx, existing, deleteOptions(existing, desired)...)
 			if delErr != nil {
 				return delErr
 			}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestDeleteOptions(t *testing.T) {
+	existing := &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "collector"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "collector"}},
+			},
+		},
+	}
+
+	// the pods still matched by the new selector are adopted by the new deployment
+	desired := existing.DeepCopy()
+	desired.Spec.Selector.MatchExpressions = []metav1.LabelSelectorRequirement{{Key: "canary", Operator: metav1.LabelSelectorOpDoesNotExist}}
+	assert.Equal(t, []client.DeleteOption{client.PropagationPolicy(metav1.DeletePropagationOrphan)}, deleteOptions(existing, desired))
+
+	// the other ones are deleted with the deployment
+	desired.Spec.Selector.MatchLabels = map[string]string{"app": "other"}
+	assert.Empty(t, deleteOptions(existing, desired))
+
+	// as well as the objects of the other kinds
+	assert.Empty(t, deleteOptions(&appsv1.DaemonSet{}, &appsv1.DaemonSet{}))
+}
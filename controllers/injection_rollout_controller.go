@@ -0,0 +1,345 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/time/rate"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/sidecar"
+)
+
+const (
+	// annotationInjectionRollout set to "false" on a namespace or a workload opts it out of the injection rollout.
+	annotationInjectionRollout = "opentelemetry.io/injection-rollout"
+	// annotationSidecarRolloutHash is set on the pod template of the workloads restarted to pick up a sidecar change.
+	annotationSidecarRolloutHash = "sidecar.opentelemetry.io/rollout-hash"
+	// annotationInstrumentationRolloutHash is set on the pod template of the workloads restarted to pick up an
+	// auto-instrumentation change.
+	annotationInstrumentationRolloutHash = "instrumentation.opentelemetry.io/rollout-hash"
+	// annotationRestartedAt is set on the pod template of the restarted workloads, like `kubectl rollout restart` does.
+	annotationRestartedAt = "opentelemetry.io/restartedAt"
+
+	reasonInjectionRollout = "InjectionRollout"
+
+	// defaultInjectionRolloutInterval is the default minimum time between two workload restarts.
+	defaultInjectionRolloutInterval = 30 * time.Second
+)
+
+// InjectionRolloutReconciler restarts the Deployments, StatefulSets and DaemonSets whose pods run an outdated sidecar
+// or auto-instrumentation, after the OpenTelemetryCollector or Instrumentation they were injected from changed.
+type InjectionRolloutReconciler struct {
+	client.Client
+	log      logr.Logger
+	recorder record.EventRecorder
+	config   config.Config
+	limiter  *rate.Limiter
+}
+
+// InjectionRolloutReconcilerParams is the set of options to build a new InjectionRolloutReconciler.
+type InjectionRolloutReconcilerParams struct {
+	client.Client
+	Recorder record.EventRecorder
+	Log      logr.Logger
+	Config   config.Config
+	// Interval is the minimum time between two workload restarts. Default is 30s.
+	Interval time.Duration
+}
+
+// NewInjectionRolloutReconciler creates a new reconciler restarting the workloads running an outdated injection.
+func NewInjectionRolloutReconciler(p InjectionRolloutReconcilerParams) *InjectionRolloutReconciler {
+	interval := p.Interval
+	if interval <= 0 {
+		interval = defaultInjectionRolloutInterval
+	}
+	return &InjectionRolloutReconciler{
+		Client:   p.Client,
+		log:      p.Log,
+		recorder: p.Recorder,
+		config:   p.Config,
+		limiter:  rate.NewLimiter(rate.Every(interval), 1),
+	}
+}
+
+// stalePod is a pod running an outdated injection.
+type stalePod struct {
+	pod corev1.Pod
+	// hash is the hash of the injection the pod should be running.
+	hash string
+}
+
+// staleWorkload is a workload with pods running an outdated injection.
+type staleWorkload struct {
+	object client.Object
+	// hash is the hash of the injection the workload is restarted for.
+	hash string
+}
+
+// reconcileSidecar restarts the workloads running an outdated sidecar of the given OpenTelemetryCollector.
+func (r *InjectionRolloutReconciler) reconcileSidecar(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("opentelemetrycollector", req.NamespacedName)
+
+	var otelcol v1alpha1.OpenTelemetryCollector
+	if err := r.Get(ctx, req.NamespacedName, &otelcol); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if otelcol.Spec.Mode != v1alpha1.ModeSidecar || otelcol.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	pods := &corev1.PodList{}
+	injected := naming.Truncate("%s.%s", 63, otelcol.Namespace, otelcol.Name)
+	if err := r.List(ctx, pods, client.MatchingLabels{sidecar.InjectedLabel: injected}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list the pods with an injected sidecar: %w", err)
+	}
+
+	hashes := map[string]string{}
+	var stale []stalePod
+	for _, pod := range pods.Items {
+		podHash, annotated := pod.Annotations[sidecar.ConfigHashAnnotation]
+		if !annotated || pod.DeletionTimestamp != nil {
+			// the sidecar of pods injected before the hash annotation was introduced is unknown
+			continue
+		}
+		expected, ok := hashes[pod.Namespace]
+		if !ok {
+			var err error
+			if expected, err = sidecar.ConfigHash(r.config, otelcol, pod.Namespace); err != nil {
+				// an invalid configuration is reported in the collector's status, don't restart anything
+				log.V(2).Info("can't determine the sidecar injected into the pods", "error", err.Error())
+				return ctrl.Result{}, nil
+			}
+			hashes[pod.Namespace] = expected
+		}
+		if podHash != expected {
+			stale = append(stale, stalePod{pod: pod, hash: expected})
+		}
+	}
+
+	reason := fmt.Sprintf("Restarting to pick up the updated sidecar of the OpenTelemetryCollector %s/%s", otelcol.Namespace, otelcol.Name)
+	return r.rollout(ctx, log, stale, annotationSidecarRolloutHash, reason)
+}
+
+// reconcileInstrumentation restarts the workloads running an outdated auto-instrumentation of the given Instrumentation.
+func (r *InjectionRolloutReconciler) reconcileInstrumentation(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("instrumentation", req.NamespacedName)
+
+	var inst v1alpha1.Instrumentation
+	if err := r.Get(ctx, req.NamespacedName, &inst); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if inst.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.MatchingLabels{instrumentation.InjectedLabel: "true"}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list the instrumented pods: %w", err)
+	}
+
+	// the pods are only outdated when the settings of the languages they were injected with changed
+	prefix := fmt.Sprintf("%s/%s/", inst.Namespace, inst.Name)
+	hashes := map[string]string{}
+	var stale []stalePod
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		var expected []string
+		outdated := false
+		for key, podHash := range instrumentation.InjectionHashes(pod) {
+			language, ok := strings.CutPrefix(key, prefix)
+			if !ok {
+				continue
+			}
+			hash, ok := hashes[language]
+			if !ok {
+				var err error
+				if hash, err = instrumentation.InjectionHash(inst, language); err != nil {
+					return ctrl.Result{}, err
+				}
+				hashes[language] = hash
+			}
+			expected = append(expected, fmt.Sprintf("%s=%s", key, hash))
+			outdated = outdated || podHash != hash
+		}
+		if outdated {
+			sort.Strings(expected)
+			stale = append(stale, stalePod{pod: pod, hash: strings.Join(expected, ",")})
+		}
+	}
+
+	reason := fmt.Sprintf("Restarting to pick up the updated auto-instrumentation of the Instrumentation %s/%s", inst.Namespace, inst.Name)
+	return r.rollout(ctx, log, stale, annotationInstrumentationRolloutHash, reason)
+}
+
+// rollout restarts the workloads owning the given pods, at the pace allowed by the rate limiter. The hash the workloads
+// are restarted for is recorded in their pod template with the given annotation, so that each workload is restarted once.
+func (r *InjectionRolloutReconciler) rollout(ctx context.Context, log logr.Logger, pods []stalePod, annotation, reason string) (ctrl.Result, error) {
+	workloads := map[string]staleWorkload{}
+	for _, stale := range pods {
+		workload, err := r.workloadFor(ctx, stale.pod)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if workload == nil {
+			// bare pods and pods of other controllers, e.g. Jobs, can't be restarted
+			continue
+		}
+		key := fmt.Sprintf("%T/%s/%s", workload, workload.GetNamespace(), workload.GetName())
+		workloads[key] = staleWorkload{object: workload, hash: stale.hash}
+	}
+
+	keys := make([]string, 0, len(workloads))
+	for key := range workloads {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	optedOut := map[string]bool{}
+	for _, key := range keys {
+		workload := workloads[key]
+		template := podTemplate(workload.object)
+		if template.Annotations[annotation] == workload.hash {
+			// the workload was already restarted for this hash, its rollout might still be in progress
+			continue
+		}
+		out, err := r.optedOut(ctx, workload.object, optedOut)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if out {
+			continue
+		}
+
+		reservation := r.limiter.Reserve()
+		if delay := reservation.Delay(); delay > 0 {
+			reservation.Cancel()
+			log.V(1).Info("rate limiting the injection rollout", "requeue-after", delay)
+			return ctrl.Result{RequeueAfter: delay}, nil
+		}
+
+		patch := client.MergeFrom(workload.object.DeepCopyObject().(client.Object))
+		if template.Annotations == nil {
+			template.Annotations = map[string]string{}
+		}
+		template.Annotations[annotation] = workload.hash
+		template.Annotations[annotationRestartedAt] = time.Now().Format(time.RFC3339)
+		if err := r.Patch(ctx, workload.object, patch); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to restart %s/%s: %w", workload.object.GetNamespace(), workload.object.GetName(), err)
+		}
+		log.Info("restarted a workload running an outdated injection", "namespace", workload.object.GetNamespace(), "name", workload.object.GetName())
+		r.recorder.Event(workload.object, corev1.EventTypeNormal, reasonInjectionRollout, reason)
+	}
+	return ctrl.Result{}, nil
+}
+
+// workloadFor returns the Deployment, StatefulSet or DaemonSet owning the given pod, if any.
+func (r *InjectionRolloutReconciler) workloadFor(ctx context.Context, pod corev1.Pod) (client.Object, error) {
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		return nil, nil
+	}
+
+	var workload client.Object
+	name := owner.Name
+	switch owner.Kind {
+	case "ReplicaSet":
+		rs := &appsv1.ReplicaSet{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: owner.Name}, rs); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+		rsOwner := metav1.GetControllerOf(rs)
+		if rsOwner == nil || rsOwner.Kind != "Deployment" {
+			return nil, nil
+		}
+		workload = &appsv1.Deployment{}
+		name = rsOwner.Name
+	case "StatefulSet":
+		workload = &appsv1.StatefulSet{}
+	case "DaemonSet":
+		workload = &appsv1.DaemonSet{}
+	default:
+		return nil, nil
+	}
+
+	if err := r.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: name}, workload); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return workload, nil
+}
+
+// optedOut returns whether the given workload, or its namespace, opted out of the injection rollout.
+// The namespaces are cached in the given map for the duration of a reconciliation.
+func (r *InjectionRolloutReconciler) optedOut(ctx context.Context, workload client.Object, namespaces map[string]bool) (bool, error) {
+	if workload.GetAnnotations()[annotationInjectionRollout] == "false" {
+		return true, nil
+	}
+	out, ok := namespaces[workload.GetNamespace()]
+	if !ok {
+		ns := &corev1.Namespace{}
+		if err := r.Get(ctx, types.NamespacedName{Name: workload.GetNamespace()}, ns); err != nil {
+			return false, err
+		}
+		out = ns.Annotations[annotationInjectionRollout] == "false"
+		namespaces[workload.GetNamespace()] = out
+	}
+	return out, nil
+}
+
+func podTemplate(workload client.Object) *corev1.PodTemplateSpec {
+	switch w := workload.(type) {
+	case *appsv1.Deployment:
+		return &w.Spec.Template
+	case *appsv1.StatefulSet:
+		return &w.Spec.Template
+	case *appsv1.DaemonSet:
+		return &w.Spec.Template
+	}
+	return &corev1.PodTemplateSpec{}
+}
+
+// SetupWithManager sets up the controllers with the Manager, one for each kind of injection.
+func (r *InjectionRolloutReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewControllerManagedBy(mgr).
+		Named("sidecar-rollout").
+		For(&v1alpha1.OpenTelemetryCollector{}, ctrlbuilder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(reconcile.Func(r.reconcileSidecar)); err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("instrumentation-rollout").
+		For(&v1alpha1.Instrumentation{}, ctrlbuilder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(reconcile.Func(r.reconcileInstrumentation))
+}
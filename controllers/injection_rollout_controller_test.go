@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/sidecar"
+)
+
+func rolloutScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	return scheme
+}
+
+// deploymentWithPod returns a deployment, along with its replica set and a pod with the given annotations and labels.
+func deploymentWithPod(namespace, name string, labels, annotations map[string]string) []client.Object {
+	isController := true
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	rs := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Name:            name + "-1234",
+		Namespace:       namespace,
+		OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: name, Controller: &isController}},
+	}}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:            name + "-1234-abcd",
+		Namespace:       namespace,
+		Labels:          labels,
+		Annotations:     annotations,
+		OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: rs.Name, Controller: &isController}},
+	}}
+	return []client.Object{deployment, rs, pod}
+}
+
+func TestInjectionRolloutSidecar(t *testing.T) {
+	cfg := config.New(config.WithCollectorImage("collector:0.1"))
+	otelcol := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "sidecar", Namespace: "observability"},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:   v1alpha1.ModeSidecar,
+			Config: "receivers:\n  otlp:\n",
+		},
+	}
+	currentHash, err := sidecar.ConfigHash(cfg, *otelcol, "app")
+	require.NoError(t, err)
+	injected := map[string]string{sidecar.InjectedLabel: "observability.sidecar"}
+
+	objects := []client.Object{
+		otelcol,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "opted-out", Annotations: map[string]string{annotationInjectionRollout: "false"}}},
+	}
+	objects = append(objects, deploymentWithPod("app", "outdated", injected, map[string]string{sidecar.ConfigHashAnnotation: "old-hash"})...)
+	objects = append(objects, deploymentWithPod("app", "up-to-date", injected, map[string]string{sidecar.ConfigHashAnnotation: currentHash})...)
+	objects = append(objects, deploymentWithPod("app", "unknown", injected, nil)...)
+	objects = append(objects, deploymentWithPod("opted-out", "outdated", injected, map[string]string{sidecar.ConfigHashAnnotation: "old-hash"})...)
+	cli := fake.NewClientBuilder().WithScheme(rolloutScheme(t)).WithObjects(objects...).Build()
+	recorder := record.NewFakeRecorder(10)
+
+	r := NewInjectionRolloutReconciler(InjectionRolloutReconcilerParams{
+		Client:   cli,
+		Log:      logf.Log.WithName("injection-rollout-unit-tests"),
+		Config:   cfg,
+		Recorder: recorder,
+		Interval: time.Nanosecond,
+	})
+
+	// test
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "observability", Name: "sidecar"}}
+	result, err := r.reconcileSidecar(context.Background(), req)
+
+	// verify
+	require.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter)
+
+	restarted := &appsv1.Deployment{}
+	require.NoError(t, cli.Get(context.Background(), types.NamespacedName{Namespace: "app", Name: "outdated"}, restarted))
+	assert.Equal(t, currentHash, restarted.Spec.Template.Annotations[annotationSidecarRolloutHash])
+	restartedAt := restarted.Spec.Template.Annotations[annotationRestartedAt]
+	assert.NotEmpty(t, restartedAt)
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "OpenTelemetryCollector observability/sidecar")
+
+	for _, nsn := range []types.NamespacedName{{Namespace: "app", Name: "up-to-date"}, {Namespace: "app", Name: "unknown"}, {Namespace: "opted-out", Name: "outdated"}} {
+		untouched := &appsv1.Deployment{}
+		require.NoError(t, cli.Get(context.Background(), nsn, untouched))
+		assert.Empty(t, untouched.Spec.Template.Annotations, nsn.String())
+	}
+
+	// the workload is restarted only once, while its rollout is in progress
+	_, err = r.reconcileSidecar(context.Background(), req)
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(context.Background(), types.NamespacedName{Namespace: "app", Name: "outdated"}, restarted))
+	assert.Equal(t, restartedAt, restarted.Spec.Template.Annotations[annotationRestartedAt])
+	assert.Empty(t, recorder.Events)
+}
+
+func TestInjectionRolloutInstrumentationRateLimited(t *testing.T) {
+	inst := &v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{Name: "java", Namespace: "app"},
+		Spec:       v1alpha1.InstrumentationSpec{Exporter: v1alpha1.Exporter{Endpoint: "http://collector:4317"}},
+	}
+	currentHash, err := instrumentation.InjectionHash(*inst, "java")
+	require.NoError(t, err)
+	labels := map[string]string{instrumentation.InjectedLabel: "true"}
+	outdated := map[string]string{instrumentation.InjectionHashesAnnotation: "app/java/java=old-hash,app/python/python=other-hash"}
+
+	objects := []client.Object{inst, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}}}
+	objects = append(objects, deploymentWithPod("app", "first", labels, outdated)...)
+	objects = append(objects, deploymentWithPod("app", "second", labels, outdated)...)
+	objects = append(objects, deploymentWithPod("app", "other", labels, map[string]string{instrumentation.InjectionHashesAnnotation: "app/python/python=other-hash"})...)
+	cli := fake.NewClientBuilder().WithScheme(rolloutScheme(t)).WithObjects(objects...).Build()
+
+	r := NewInjectionRolloutReconciler(InjectionRolloutReconcilerParams{
+		Client:   cli,
+		Log:      logf.Log.WithName("injection-rollout-unit-tests"),
+		Recorder: record.NewFakeRecorder(10),
+		Interval: time.Hour,
+	})
+
+	// test
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "app", Name: "java"}}
+	result, err := r.reconcileInstrumentation(context.Background(), req)
+
+	// verify
+	require.NoError(t, err)
+	assert.Greater(t, result.RequeueAfter, time.Duration(0))
+
+	first := &appsv1.Deployment{}
+	require.NoError(t, cli.Get(context.Background(), types.NamespacedName{Namespace: "app", Name: "first"}, first))
+	assert.Equal(t, "app/java/java="+currentHash, first.Spec.Template.Annotations[annotationInstrumentationRolloutHash])
+
+	for _, name := range []string{"second", "other"} {
+		untouched := &appsv1.Deployment{}
+		require.NoError(t, cli.Get(context.Background(), types.NamespacedName{Namespace: "app", Name: name}, untouched))
+		assert.Empty(t, untouched.Spec.Template.Annotations, name)
+	}
+}
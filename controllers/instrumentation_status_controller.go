@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	instrumentationStatus "github.com/open-telemetry/opentelemetry-operator/internal/status/instrumentation"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
+)
+
+// instrumentationStatusResyncInterval is the interval the status of the Instrumentations is refreshed at, to pick up
+// the injection failures, which are only recorded as events.
+const instrumentationStatusResyncInterval = time.Minute
+
+// InstrumentationStatusReconciler reports in the status of the Instrumentations the pods they were injected into,
+// the injections that failed recently and whether they're valid.
+type InstrumentationStatusReconciler struct {
+	client.Client
+	events client.Reader
+	scheme *runtime.Scheme
+	log    logr.Logger
+	config config.Config
+}
+
+// InstrumentationStatusReconcilerParams is the set of options to build a new InstrumentationStatusReconciler.
+type InstrumentationStatusReconcilerParams struct {
+	client.Client
+	// Events reads the events recorded on the Instrumentations, usually the API reader of the manager, so that
+	// the events aren't cached.
+	Events client.Reader
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+	Config config.Config
+}
+
+// NewInstrumentationStatusReconciler creates a new reconciler updating the status of the Instrumentations.
+func NewInstrumentationStatusReconciler(p InstrumentationStatusReconcilerParams) *InstrumentationStatusReconciler {
+	return &InstrumentationStatusReconciler{
+		Client: p.Client,
+		events: p.Events,
+		scheme: p.Scheme,
+		log:    p.Log,
+		config: p.Config,
+	}
+}
+
+//+kubebuilder:rbac:groups=opentelemetry.io,resources=instrumentations/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch
+
+// Reconcile updates the status of the given Instrumentation.
+func (r *InstrumentationStatusReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("instrumentation", req.NamespacedName)
+
+	var inst v1alpha1.Instrumentation
+	if err := r.Get(ctx, req.NamespacedName, &inst); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if inst.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	// the Instrumentations created while the webhook was disabled may not be valid
+	validator := v1alpha1.NewInstrumentationWebhook(log, r.scheme, r.config, nil)
+	_, validationErr := validator.ValidateCreate(ctx, &inst)
+
+	changed := inst.DeepCopy()
+	if err := instrumentationStatus.UpdateInstrumentationStatus(ctx, r.Client, r.events, changed, validationErr); err != nil {
+		return ctrl.Result{}, err
+	}
+	if !apiequality.Semantic.DeepEqual(inst.Status, changed.Status) {
+		if err := r.Status().Patch(ctx, changed, client.MergeFrom(&inst)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to apply status changes to the Instrumentation: %w", err)
+		}
+		log.V(2).Info("updated the instrumentation status")
+	}
+	return ctrl.Result{RequeueAfter: instrumentationStatusResyncInterval}, nil
+}
+
+// instrumentationsOf returns the Instrumentations the given pod was injected with.
+func instrumentationsOf(_ context.Context, obj client.Object) []reconcile.Request {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return nil
+	}
+	seen := map[string]bool{}
+	var requests []reconcile.Request
+	for _, key := range instrumentation.InjectedLanguages(*pod) {
+		namespace, name, found := strings.Cut(key, "/")
+		if !found || seen[key] {
+			continue
+		}
+		seen[key] = true
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}})
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager, reconciling the Instrumentations when the pods they
+// were injected into change.
+func (r *InstrumentationStatusReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	injected := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetLabels()[instrumentation.InjectedLabel] == "true"
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("instrumentation-status").
+		For(&v1alpha1.Instrumentation{}, ctrlbuilder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(instrumentationsOf), ctrlbuilder.WithPredicates(injected)).
+		Complete(r)
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	instrumentationStatus "github.com/open-telemetry/opentelemetry-operator/internal/status/instrumentation"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
+)
+
+func TestInstrumentationStatusReconcile(t *testing.T) {
+	inst := &v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-inst", Namespace: "observability", UID: "inst-uid"},
+		Spec:       v1alpha1.InstrumentationSpec{Sampler: v1alpha1.Sampler{Type: "foo"}},
+	}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:        "my-app",
+		Namespace:   "app",
+		Labels:      map[string]string{instrumentation.InjectedLabel: "true"},
+		Annotations: map[string]string{instrumentation.InjectedLanguagesAnnotation: "nodejs=observability/my-inst"},
+	}}
+	cli := fake.NewClientBuilder().WithScheme(rolloutScheme(t)).WithObjects(inst, pod).
+		WithStatusSubresource(&v1alpha1.Instrumentation{}).
+		WithIndex(&corev1.Event{}, instrumentationStatus.EventInvolvedObjectField, func(obj client.Object) []string {
+			return []string{string(obj.(*corev1.Event).InvolvedObject.UID)}
+		}).Build()
+
+	r := NewInstrumentationStatusReconciler(InstrumentationStatusReconcilerParams{
+		Client: cli,
+		Events: cli,
+		Scheme: rolloutScheme(t),
+		Log:    logf.Log.WithName("instrumentation-status-unit-tests"),
+		Config: config.New(),
+	})
+
+	// test
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "observability", Name: "my-inst"}}
+	result, err := r.Reconcile(context.Background(), req)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, instrumentationStatusResyncInterval, result.RequeueAfter)
+
+	updated := &v1alpha1.Instrumentation{}
+	require.NoError(t, cli.Get(context.Background(), req.NamespacedName, updated))
+	assert.Equal(t, int32(1), updated.Status.InstrumentedPods)
+	require.Len(t, updated.Status.Languages, 1)
+	assert.Equal(t, "nodejs", updated.Status.Languages[0].Language)
+	valid := meta.FindStatusCondition(updated.Status.Conditions, v1alpha1.InstrumentationConditionValid)
+	require.NotNil(t, valid)
+	assert.Equal(t, metav1.ConditionFalse, valid.Status)
+	assert.Equal(t, "spec.sampler.type is not valid: foo", valid.Message)
+}
+
+func TestInstrumentationsOf(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+		instrumentation.InjectedLanguagesAnnotation: "java=app/my-inst,python=app/my-inst,nodejs=observability/shared",
+	}}}
+
+	requests := instrumentationsOf(context.Background(), pod)
+
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "app", Name: "my-inst"}},
+		{NamespacedName: types.NamespacedName{Namespace: "observability", Name: "shared"}},
+	}, requests)
+}
@@ -23,18 +23,34 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	policyV1 "k8s.io/api/policy/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/internal/rbac"
 	collectorStatus "github.com/open-telemetry/opentelemetry-operator/internal/status/collector"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/sidecar"
 )
 
 // OpenTelemetryCollectorReconciler reconciles a OpenTelemetryCollector object.
@@ -44,6 +60,7 @@ type OpenTelemetryCollectorReconciler struct {
 	scheme   *runtime.Scheme
 	log      logr.Logger
 	config   config.Config
+	reviewer *rbac.Reviewer
 }
 
 // Params is the set of options to build a new OpenTelemetryCollectorReconciler.
@@ -74,6 +91,7 @@ func NewReconciler(p Params) *OpenTelemetryCollectorReconciler {
 		scheme:   p.Scheme,
 		config:   p.Config,
 		recorder: p.Recorder,
+		reviewer: rbac.NewReviewer(p.Client),
 	}
 	return r
 }
@@ -85,8 +103,12 @@ func NewReconciler(p Params) *OpenTelemetryCollectorReconciler {
 // +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;create;update
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;podmonitors,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses;networkpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes;grpcroutes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes;routes/custom-host,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles;clusterrolebindings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=selfsubjectaccessreviews,verbs=create
 // +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors/finalizers,verbs=get;update;patch
@@ -106,9 +128,9 @@ func (r *OpenTelemetryCollectorReconciler) Reconcile(ctx context.Context, req ct
 		// on deleted requests.
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
-	// We have a deletion, short circuit and let the deletion happen
+	// We have a deletion, short circuit and let the deletion happen once the cluster-scoped objects are deleted
 	if deletionTimestamp := instance.GetDeletionTimestamp(); deletionTimestamp != nil {
-		return ctrl.Result{}, nil
+		return ctrl.Result{}, r.finalize(ctx, &instance)
 	}
 
 	if instance.Spec.ManagementState == v1alpha1.ManagementStateUnmanaged {
@@ -117,13 +139,22 @@ func (r *OpenTelemetryCollectorReconciler) Reconcile(ctx context.Context, req ct
 		return ctrl.Result{}, nil
 	}
 
-	params := r.getParams(instance)
+	if err := r.reconcileFinalizer(ctx, &instance); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// a promoted canary configuration replaces the instance's configuration until its spec is updated
+	params := r.getParams(collector.PromotedInstance(instance))
 
 	desiredObjects, buildErr := BuildCollector(params)
 	if buildErr != nil {
-		return ctrl.Result{}, buildErr
+		return collectorStatus.HandleReconcileStatus(ctx, log, params, buildErr)
 	}
-	err := reconcileDesiredObjects(ctx, r.Client, log, &params.OtelCol, params.Scheme, desiredObjects...)
+	desiredObjects, err := r.reconcileRBAC(ctx, log, &params.OtelCol, desiredObjects)
+	if err != nil {
+		return collectorStatus.HandleReconcileStatus(ctx, log, params, err)
+	}
+	err = reconcileDesiredObjects(ctx, r.Client, log, &params.OtelCol, params.Scheme, desiredObjects...)
 	return collectorStatus.HandleReconcileStatus(ctx, log, params, err)
 }
 
@@ -138,12 +169,130 @@ func (r *OpenTelemetryCollectorReconciler) SetupWithManager(mgr ctrl.Manager) er
 		Owns(&appsv1.DaemonSet{}).
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
-		Owns(&policyV1.PodDisruptionBudget{})
+		Owns(&policyV1.PodDisruptionBudget{}).
+		Owns(&networkingv1.NetworkPolicy{}).
+		Watches(&corev1.Pod{},
+			handler.EnqueueRequestsFromMapFunc(r.collectorForPod),
+			ctrlbuilder.WithPredicates(collectorPodPredicate()))
 
 	if featuregate.PrometheusOperatorIsAvailable.IsEnabled() {
 		builder.Owns(&monitoringv1.ServiceMonitor{})
 		builder.Owns(&monitoringv1.PodMonitor{})
 	}
 
+	// the GRPCRoutes are only part of the experimental channel of the Gateway API
+	if r.config.GatewayAPIHTTPRoutesAvailability() == gatewayapi.RoutesAvailable {
+		builder.Owns(&gatewayv1beta1.HTTPRoute{})
+	}
+	if r.config.GatewayAPIGRPCRoutesAvailability() == gatewayapi.RoutesAvailable {
+		builder.Owns(&gatewayv1alpha2.GRPCRoute{})
+	}
+
 	return builder.Complete(r)
 }
+
+// isCollectorPod returns whether the given object is a pod managed by the operator for a collector,
+// or a pod a sidecar collector was injected into.
+func isCollectorPod(obj client.Object) bool {
+	labels := obj.GetLabels()
+	if _, injected := labels[sidecar.InjectedLabel]; injected {
+		return true
+	}
+	return labels["app.kubernetes.io/managed-by"] == "opentelemetry-operator" &&
+		labels["app.kubernetes.io/component"] == collector.ComponentOpenTelemetryCollector
+}
+
+// collectorPodPredicate filters the pod events down to the collector pods, and their updates down to the changes
+// reported in the collector's status, leaving out the status churn such as the probe-driven condition updates.
+func collectorPodPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return isCollectorPod(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return isCollectorPod(e.ObjectNew) && podStatusChanged(e.ObjectOld, e.ObjectNew)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return isCollectorPod(e.Object)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return isCollectorPod(e.Object)
+		},
+	}
+}
+
+// podStatusChanged returns whether a pod update changes what the collector's status reports: the pod's phase,
+// readiness, address, deletion and sidecar configuration, and the restarts, image and last termination of the
+// collector container.
+func podStatusChanged(oldObj, newObj client.Object) bool {
+	oldPod, okOld := oldObj.(*corev1.Pod)
+	newPod, okNew := newObj.(*corev1.Pod)
+	if !okOld || !okNew {
+		return true
+	}
+	if oldPod.Status.Phase != newPod.Status.Phase ||
+		oldPod.Status.PodIP != newPod.Status.PodIP ||
+		isPodReady(oldPod) != isPodReady(newPod) ||
+		(oldPod.DeletionTimestamp == nil) != (newPod.DeletionTimestamp == nil) ||
+		oldPod.Annotations[sidecar.ConfigHashAnnotation] != newPod.Annotations[sidecar.ConfigHashAnnotation] {
+		return true
+	}
+
+	oldStatus, newStatus := collectorContainerStatus(oldPod), collectorContainerStatus(newPod)
+	if oldStatus == nil || newStatus == nil {
+		return (oldStatus == nil) != (newStatus == nil)
+	}
+	return oldStatus.RestartCount != newStatus.RestartCount ||
+		oldStatus.Image != newStatus.Image ||
+		!apiequality.Semantic.DeepEqual(oldStatus.LastTerminationState.Terminated, newStatus.LastTerminationState.Terminated)
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func collectorContainerStatus(pod *corev1.Pod) *corev1.ContainerStatus {
+	for i := range pod.Status.ContainerStatuses {
+		if pod.Status.ContainerStatuses[i].Name == naming.Container() {
+			return &pod.Status.ContainerStatuses[i]
+		}
+	}
+	return nil
+}
+
+// collectorForPod maps a collector pod to the collector owning it, or an injected pod to its sidecar collector,
+// so that pod changes end up in the status.
+func (r *OpenTelemetryCollectorReconciler) collectorForPod(ctx context.Context, obj client.Object) []reconcile.Request {
+	if injected, ok := obj.GetLabels()[sidecar.InjectedLabel]; ok {
+		// sidecar collectors can be injected into pods of any namespace
+		list := &v1alpha1.OpenTelemetryCollectorList{}
+		if err := r.List(ctx, list); err != nil {
+			r.log.Error(err, "failed to list the collectors for an injected pod", "pod", obj.GetName(), "namespace", obj.GetNamespace())
+			return nil
+		}
+		for _, otelcol := range list.Items {
+			if otelcol.Spec.Mode == v1alpha1.ModeSidecar && naming.Truncate("%s.%s", 63, otelcol.Namespace, otelcol.Name) == injected {
+				return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: otelcol.Namespace, Name: otelcol.Name}}}
+			}
+		}
+		return nil
+	}
+
+	instance := obj.GetLabels()["app.kubernetes.io/instance"]
+	list := &v1alpha1.OpenTelemetryCollectorList{}
+	if err := r.List(ctx, list, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.log.Error(err, "failed to list the collectors for a pod", "pod", obj.GetName(), "namespace", obj.GetNamespace())
+		return nil
+	}
+	for _, otelcol := range list.Items {
+		if manifestutils.SelectorLabels(otelcol.ObjectMeta, collector.ComponentOpenTelemetryCollector)["app.kubernetes.io/instance"] == instance {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: otelcol.Namespace, Name: otelcol.Name}}}
+		}
+	}
+	return nil
+}
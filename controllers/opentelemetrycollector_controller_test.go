@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/sidecar"
+)
+
+func TestCollectorPodPredicate(t *testing.T) {
+	newPod := func() *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-instance-collector-a",
+				Namespace: "default",
+				Labels: map[string]string{
+					"app.kubernetes.io/managed-by": "opentelemetry-operator",
+					"app.kubernetes.io/component":  "opentelemetry-collector",
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				PodIP: "10.0.0.1",
+				Conditions: []corev1.PodCondition{
+					{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+					{Type: corev1.ContainersReady, Status: corev1.ConditionTrue},
+				},
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "otc-container", Image: "collector:0.1", Ready: true},
+					{Name: "other", Image: "other:0.1", Ready: true},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		update   func(pod *corev1.Pod)
+		expected bool
+	}{
+		{
+			name:     "unchanged",
+			update:   func(*corev1.Pod) {},
+			expected: false,
+		},
+		{
+			name: "probe-driven condition update",
+			update: func(pod *corev1.Pod) {
+				pod.Status.Conditions[1].LastProbeTime = metav1.Now()
+				pod.Status.Conditions[1].Message = "probed"
+			},
+			expected: false,
+		},
+		{
+			name: "other container restarted",
+			update: func(pod *corev1.Pod) {
+				pod.Status.ContainerStatuses[1].RestartCount = 1
+				pod.Status.ContainerStatuses[1].Ready = false
+			},
+			expected: false,
+		},
+		{
+			name: "unrelated label",
+			update: func(pod *corev1.Pod) {
+				pod.Labels["team"] = "observability"
+			},
+			expected: false,
+		},
+		{
+			name: "not ready",
+			update: func(pod *corev1.Pod) {
+				pod.Status.Conditions[0].Status = corev1.ConditionFalse
+			},
+			expected: true,
+		},
+		{
+			name: "phase",
+			update: func(pod *corev1.Pod) {
+				pod.Status.Phase = corev1.PodFailed
+			},
+			expected: true,
+		},
+		{
+			name: "address",
+			update: func(pod *corev1.Pod) {
+				pod.Status.PodIP = "10.0.0.2"
+			},
+			expected: true,
+		},
+		{
+			name: "deleted",
+			update: func(pod *corev1.Pod) {
+				now := metav1.Now()
+				pod.DeletionTimestamp = &now
+			},
+			expected: true,
+		},
+		{
+			name: "sidecar configuration",
+			update: func(pod *corev1.Pod) {
+				pod.Annotations = map[string]string{sidecar.ConfigHashAnnotation: "abc"}
+			},
+			expected: true,
+		},
+		{
+			name: "collector restarted",
+			update: func(pod *corev1.Pod) {
+				pod.Status.ContainerStatuses[0].RestartCount = 1
+				pod.Status.ContainerStatuses[0].LastTerminationState.Terminated = &corev1.ContainerStateTerminated{Reason: "OOMKilled"}
+			},
+			expected: true,
+		},
+		{
+			name: "collector image",
+			update: func(pod *corev1.Pod) {
+				pod.Status.ContainerStatuses[0].Image = "collector:0.2"
+			},
+			expected: true,
+		},
+		{
+			name: "not a collector pod",
+			update: func(pod *corev1.Pod) {
+				pod.Labels = nil
+				pod.Status.Phase = corev1.PodFailed
+			},
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldPod, updated := newPod(), newPod()
+			tt.update(updated)
+			assert.Equal(t, tt.expected, collectorPodPredicate().Update(event.UpdateEvent{ObjectOld: oldPod, ObjectNew: updated}))
+		})
+	}
+
+	assert.True(t, collectorPodPredicate().Create(event.CreateEvent{Object: newPod()}))
+	assert.True(t, collectorPodPredicate().Delete(event.DeleteEvent{Object: newPod()}))
+	assert.False(t, collectorPodPredicate().Create(event.CreateEvent{Object: &corev1.Pod{}}))
+}
@@ -48,6 +48,8 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/nativesidecar"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
@@ -85,7 +87,10 @@ const (
 var _ autodetect.AutoDetect = (*mockAutoDetect)(nil)
 
 type mockAutoDetect struct {
-	OpenShiftRoutesAvailabilityFunc func() (openshift.RoutesAvailability, error)
+	OpenShiftRoutesAvailabilityFunc      func() (openshift.RoutesAvailability, error)
+	GatewayAPIHTTPRoutesAvailabilityFunc func() (gatewayapi.RoutesAvailability, error)
+	GatewayAPIGRPCRoutesAvailabilityFunc func() (gatewayapi.RoutesAvailability, error)
+	NativeSidecarAvailabilityFunc        func() (nativesidecar.Availability, error)
 }
 
 func (m *mockAutoDetect) OpenShiftRoutesAvailability() (openshift.RoutesAvailability, error) {
@@ -95,6 +100,27 @@ func (m *mockAutoDetect) OpenShiftRoutesAvailability() (openshift.RoutesAvailabi
 	return openshift.RoutesNotAvailable, nil
 }
 
+func (m *mockAutoDetect) GatewayAPIHTTPRoutesAvailability() (gatewayapi.RoutesAvailability, error) {
+	if m.GatewayAPIHTTPRoutesAvailabilityFunc != nil {
+		return m.GatewayAPIHTTPRoutesAvailabilityFunc()
+	}
+	return gatewayapi.RoutesNotAvailable, nil
+}
+
+func (m *mockAutoDetect) GatewayAPIGRPCRoutesAvailability() (gatewayapi.RoutesAvailability, error) {
+	if m.GatewayAPIGRPCRoutesAvailabilityFunc != nil {
+		return m.GatewayAPIGRPCRoutesAvailabilityFunc()
+	}
+	return gatewayapi.RoutesNotAvailable, nil
+}
+
+func (m *mockAutoDetect) NativeSidecarAvailability() (nativesidecar.Availability, error) {
+	if m.NativeSidecarAvailabilityFunc != nil {
+		return m.NativeSidecarAvailabilityFunc()
+	}
+	return nativesidecar.NotAvailable, nil
+}
+
 func TestMain(m *testing.M) {
 	ctx, cancel = context.WithCancel(context.TODO())
 	defer cancel()
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gatewayapi
+
+const (
+	// HTTPRouteGroupVersion is the version of the Gateway API serving the HTTPRoutes the operator manages.
+	HTTPRouteGroupVersion = "gateway.networking.k8s.io/v1beta1"
+	// HTTPRouteKind is the kind of the Gateway API HTTPRoutes.
+	HTTPRouteKind = "HTTPRoute"
+	// GRPCRouteGroupVersion is the version of the Gateway API serving the GRPCRoutes the operator manages.
+	GRPCRouteGroupVersion = "gateway.networking.k8s.io/v1alpha2"
+	// GRPCRouteKind is the kind of the Gateway API GRPCRoutes.
+	GRPCRouteKind = "GRPCRoute"
+)
+
+// RoutesAvailability holds the auto-detected Gateway API routes availability API.
+type RoutesAvailability int
+
+const (
+	// RoutesAvailable represents the kind of gateway.networking.k8s.io route is available.
+	RoutesAvailable RoutesAvailability = iota
+
+	// RoutesNotAvailable represents the kind of gateway.networking.k8s.io route is not available.
+	RoutesNotAvailable
+)
+
+func (p RoutesAvailability) String() string {
+	return [...]string{"Available", "NotAvailable"}[p]
+}
@@ -16,17 +16,29 @@
 package autodetect
 
 import (
+	"strconv"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
 
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/nativesidecar"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 )
 
 var _ AutoDetect = (*autoDetect)(nil)
 
+// nativeSidecarMinorVersion is the first Kubernetes 1.x minor version enabling the SidecarContainers feature by default.
+const nativeSidecarMinorVersion = 29
+
 // AutoDetect provides an assortment of routines that auto-detect traits based on the runtime.
 type AutoDetect interface {
 	OpenShiftRoutesAvailability() (openshift.RoutesAvailability, error)
+	GatewayAPIHTTPRoutesAvailability() (gatewayapi.RoutesAvailability, error)
+	GatewayAPIGRPCRoutesAvailability() (gatewayapi.RoutesAvailability, error)
+	NativeSidecarAvailability() (nativesidecar.Availability, error)
 }
 
 type autoDetect struct {
@@ -64,3 +76,56 @@ func (a *autoDetect) OpenShiftRoutesAvailability() (openshift.RoutesAvailability
 
 	return openshift.RoutesNotAvailable, nil
 }
+
+// GatewayAPIHTTPRoutesAvailability checks if the Gateway API HTTPRoutes are available.
+func (a *autoDetect) GatewayAPIHTTPRoutesAvailability() (gatewayapi.RoutesAvailability, error) {
+	return a.gatewayAPIRoutesAvailability(gatewayapi.HTTPRouteGroupVersion, gatewayapi.HTTPRouteKind)
+}
+
+// GatewayAPIGRPCRoutesAvailability checks if the Gateway API GRPCRoutes are available. They're only part of the
+// experimental channel of the Gateway API.
+func (a *autoDetect) GatewayAPIGRPCRoutesAvailability() (gatewayapi.RoutesAvailability, error) {
+	return a.gatewayAPIRoutesAvailability(gatewayapi.GRPCRouteGroupVersion, gatewayapi.GRPCRouteKind)
+}
+
+// gatewayAPIRoutesAvailability checks if the given kind of the Gateway API is served in the given version.
+func (a *autoDetect) gatewayAPIRoutesAvailability(groupVersion, kind string) (gatewayapi.RoutesAvailability, error) {
+	resources, err := a.dcl.ServerResourcesForGroupVersion(groupVersion)
+	if apierrors.IsNotFound(err) {
+		return gatewayapi.RoutesNotAvailable, nil
+	}
+	if err != nil {
+		return gatewayapi.RoutesNotAvailable, err
+	}
+
+	for _, resource := range resources.APIResources {
+		if resource.Kind == kind {
+			return gatewayapi.RoutesAvailable, nil
+		}
+	}
+
+	return gatewayapi.RoutesNotAvailable, nil
+}
+
+// NativeSidecarAvailability checks if native sidecar containers are enabled by default, based on the server version.
+func (a *autoDetect) NativeSidecarAvailability() (nativesidecar.Availability, error) {
+	info, err := a.dcl.ServerVersion()
+	if err != nil {
+		return nativesidecar.NotAvailable, err
+	}
+
+	major, err := strconv.Atoi(info.Major)
+	if err != nil {
+		return nativesidecar.NotAvailable, err
+	}
+	// some distributions suffix the minor version, e.g. "29+"
+	minor, err := strconv.Atoi(strings.TrimRight(info.Minor, "+"))
+	if err != nil {
+		return nativesidecar.NotAvailable, err
+	}
+
+	if major > 1 || (major == 1 && minor >= nativeSidecarMinorVersion) {
+		return nativesidecar.Available, nil
+	}
+	return nativesidecar.NotAvailable, nil
+}
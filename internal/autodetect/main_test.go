@@ -23,9 +23,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/rest"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/nativesidecar"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 )
 
@@ -71,3 +74,116 @@ func TestDetectPlatformBasedOnAvailableAPIGroups(t *testing.T) {
 		assert.Equal(t, tt.expected, ora)
 	}
 }
+
+func TestDetectGatewayAPIRoutesBasedOnAvailableResources(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		served   map[string]*metav1.APIResourceList
+		expected [2]gatewayapi.RoutesAvailability
+	}{
+		{
+			desc:     "no gateway api",
+			served:   map[string]*metav1.APIResourceList{},
+			expected: [2]gatewayapi.RoutesAvailability{gatewayapi.RoutesNotAvailable, gatewayapi.RoutesNotAvailable},
+		},
+		{
+			desc: "standard channel",
+			served: map[string]*metav1.APIResourceList{
+				"/apis/gateway.networking.k8s.io/v1beta1": {
+					GroupVersion: "gateway.networking.k8s.io/v1beta1",
+					APIResources: []metav1.APIResource{{Name: "gateways", Kind: "Gateway"}, {Name: "httproutes", Kind: "HTTPRoute"}},
+				},
+			},
+			expected: [2]gatewayapi.RoutesAvailability{gatewayapi.RoutesAvailable, gatewayapi.RoutesNotAvailable},
+		},
+		{
+			desc: "experimental channel",
+			served: map[string]*metav1.APIResourceList{
+				"/apis/gateway.networking.k8s.io/v1beta1": {
+					GroupVersion: "gateway.networking.k8s.io/v1beta1",
+					APIResources: []metav1.APIResource{{Name: "httproutes", Kind: "HTTPRoute"}},
+				},
+				"/apis/gateway.networking.k8s.io/v1alpha2": {
+					GroupVersion: "gateway.networking.k8s.io/v1alpha2",
+					APIResources: []metav1.APIResource{{Name: "grpcroutes", Kind: "GRPCRoute"}, {Name: "tcproutes", Kind: "TCPRoute"}},
+				},
+			},
+			expected: [2]gatewayapi.RoutesAvailability{gatewayapi.RoutesAvailable, gatewayapi.RoutesAvailable},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				resources, ok := tt.served[req.URL.Path]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				output, err := json.Marshal(resources)
+				require.NoError(t, err)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				_, err = w.Write(output)
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			autoDetect, err := autodetect.New(&rest.Config{Host: server.URL})
+			require.NoError(t, err)
+
+			// test
+			httpRoutes, err := autoDetect.GatewayAPIHTTPRoutesAvailability()
+			require.NoError(t, err)
+			grpcRoutes, err := autoDetect.GatewayAPIGRPCRoutesAvailability()
+			require.NoError(t, err)
+
+			// verify
+			assert.Equal(t, tt.expected, [2]gatewayapi.RoutesAvailability{httpRoutes, grpcRoutes})
+		})
+	}
+}
+
+func TestDetectNativeSidecarBasedOnServerVersion(t *testing.T) {
+	for _, tt := range []struct {
+		version  version.Info
+		expected nativesidecar.Availability
+	}{
+		{
+			version.Info{Major: "1", Minor: "27"},
+			nativesidecar.NotAvailable,
+		},
+		{
+			version.Info{Major: "1", Minor: "28"},
+			nativesidecar.NotAvailable,
+		},
+		{
+			version.Info{Major: "1", Minor: "29"},
+			nativesidecar.Available,
+		},
+		{
+			version.Info{Major: "1", Minor: "30+"},
+			nativesidecar.Available,
+		},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			output, err := json.Marshal(tt.version)
+			require.NoError(t, err)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, err = w.Write(output)
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		autoDetect, err := autodetect.New(&rest.Config{Host: server.URL})
+		require.NoError(t, err)
+
+		// test
+		nsa, err := autoDetect.NativeSidecarAvailability()
+
+		// verify
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, nsa)
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nativesidecar
+
+// Availability holds the auto-detected availability of native sidecar containers, which are init containers
+// with an "Always" restart policy.
+type Availability int
+
+const (
+	// Available represents the SidecarContainers feature is enabled by default in the cluster (Kubernetes 1.29+).
+	Available Availability = iota
+
+	// NotAvailable represents the SidecarContainers feature isn't enabled by default in the cluster.
+	NotAvailable
+)
+
+func (p Availability) String() string {
+	return [...]string{"Available", "NotAvailable"}[p]
+}
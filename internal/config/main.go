@@ -22,6 +22,8 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/nativesidecar"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 )
@@ -42,16 +44,24 @@ type Config struct {
 	autoInstrumentationPythonImage      string
 	collectorImage                      string
 	collectorConfigMapEntry             string
+	sidecarConfigReloaderImage          string
+	persistenceInitImage                string
 	autoInstrumentationDotNetImage      string
 	autoInstrumentationGoImage          string
 	autoInstrumentationApacheHttpdImage string
 	autoInstrumentationNginxImage       string
+	autoInstrumentationRubyImage        string
+	autoInstrumentationPHPImage         string
 	targetAllocatorConfigMapEntry       string
 	operatorOpAMPBridgeConfigMapEntry   string
 	autoInstrumentationNodeJSImage      string
 	autoInstrumentationJavaImage        string
 	openshiftRoutesAvailability         openshift.RoutesAvailability
+	gatewayAPIHTTPRoutesAvailability    gatewayapi.RoutesAvailability
+	gatewayAPIGRPCRoutesAvailability    gatewayapi.RoutesAvailability
+	nativeSidecarAvailability           nativesidecar.Availability
 	labelsFilter                        []string
+	crossNamespaceSelectorNamespaces    []string
 }
 
 // New constructs a new configuration based on the given options.
@@ -59,6 +69,9 @@ func New(opts ...Option) Config {
 	// initialize with the default values
 	o := options{
 		openshiftRoutesAvailability:       openshift.RoutesNotAvailable,
+		gatewayAPIHTTPRoutesAvailability:  gatewayapi.RoutesNotAvailable,
+		gatewayAPIGRPCRoutesAvailability:  gatewayapi.RoutesNotAvailable,
+		nativeSidecarAvailability:         nativesidecar.NotAvailable,
 		collectorConfigMapEntry:           defaultCollectorConfigMapEntry,
 		targetAllocatorConfigMapEntry:     defaultTargetAllocatorConfigMapEntry,
 		operatorOpAMPBridgeConfigMapEntry: defaultOperatorOpAMPBridgeConfigMapEntry,
@@ -73,12 +86,17 @@ func New(opts ...Option) Config {
 		autoDetect:                          o.autoDetect,
 		collectorImage:                      o.collectorImage,
 		collectorConfigMapEntry:             o.collectorConfigMapEntry,
+		sidecarConfigReloaderImage:          o.sidecarConfigReloaderImage,
+		persistenceInitImage:                o.persistenceInitImage,
 		targetAllocatorImage:                o.targetAllocatorImage,
 		operatorOpAMPBridgeImage:            o.operatorOpAMPBridgeImage,
 		targetAllocatorConfigMapEntry:       o.targetAllocatorConfigMapEntry,
 		operatorOpAMPBridgeConfigMapEntry:   o.operatorOpAMPBridgeConfigMapEntry,
 		logger:                              o.logger,
 		openshiftRoutesAvailability:         o.openshiftRoutesAvailability,
+		gatewayAPIHTTPRoutesAvailability:    o.gatewayAPIHTTPRoutesAvailability,
+		gatewayAPIGRPCRoutesAvailability:    o.gatewayAPIGRPCRoutesAvailability,
+		nativeSidecarAvailability:           o.nativeSidecarAvailability,
 		autoInstrumentationJavaImage:        o.autoInstrumentationJavaImage,
 		autoInstrumentationNodeJSImage:      o.autoInstrumentationNodeJSImage,
 		autoInstrumentationPythonImage:      o.autoInstrumentationPythonImage,
@@ -86,7 +104,10 @@ func New(opts ...Option) Config {
 		autoInstrumentationGoImage:          o.autoInstrumentationGoImage,
 		autoInstrumentationApacheHttpdImage: o.autoInstrumentationApacheHttpdImage,
 		autoInstrumentationNginxImage:       o.autoInstrumentationNginxImage,
+		autoInstrumentationRubyImage:        o.autoInstrumentationRubyImage,
+		autoInstrumentationPHPImage:         o.autoInstrumentationPHPImage,
 		labelsFilter:                        o.labelsFilter,
+		crossNamespaceSelectorNamespaces:    o.crossNamespaceSelectorNamespaces,
 	}
 }
 
@@ -99,6 +120,24 @@ func (c *Config) AutoDetect() error {
 		return err
 	}
 	c.openshiftRoutesAvailability = ora
+
+	ghra, err := c.autoDetect.GatewayAPIHTTPRoutesAvailability()
+	if err != nil {
+		return err
+	}
+	c.gatewayAPIHTTPRoutesAvailability = ghra
+
+	ggra, err := c.autoDetect.GatewayAPIGRPCRoutesAvailability()
+	if err != nil {
+		return err
+	}
+	c.gatewayAPIGRPCRoutesAvailability = ggra
+
+	nsa, err := c.autoDetect.NativeSidecarAvailability()
+	if err != nil {
+		return err
+	}
+	c.nativeSidecarAvailability = nsa
 	return nil
 }
 
@@ -112,6 +151,16 @@ func (c *Config) CollectorConfigMapEntry() string {
 	return c.collectorConfigMapEntry
 }
 
+// SidecarConfigReloaderImage represents the image of the container reloading the configuration of sidecar collectors.
+func (c *Config) SidecarConfigReloaderImage() string {
+	return c.sidecarConfigReloaderImage
+}
+
+// PersistenceInitImage represents the image of the init container giving the collector the ownership of its host path storage.
+func (c *Config) PersistenceInitImage() string {
+	return c.persistenceInitImage
+}
+
 // TargetAllocatorImage represents the flag to override the OpenTelemetry TargetAllocator container image.
 func (c *Config) TargetAllocatorImage() string {
 	return c.targetAllocatorImage
@@ -137,6 +186,21 @@ func (c *Config) OpenShiftRoutesAvailability() openshift.RoutesAvailability {
 	return c.openshiftRoutesAvailability
 }
 
+// GatewayAPIHTTPRoutesAvailability represents the availability of the Gateway API HTTPRoutes.
+func (c *Config) GatewayAPIHTTPRoutesAvailability() gatewayapi.RoutesAvailability {
+	return c.gatewayAPIHTTPRoutesAvailability
+}
+
+// GatewayAPIGRPCRoutesAvailability represents the availability of the Gateway API GRPCRoutes.
+func (c *Config) GatewayAPIGRPCRoutesAvailability() gatewayapi.RoutesAvailability {
+	return c.gatewayAPIGRPCRoutesAvailability
+}
+
+// NativeSidecarAvailability represents the availability of native sidecar containers.
+func (c *Config) NativeSidecarAvailability() nativesidecar.Availability {
+	return c.nativeSidecarAvailability
+}
+
 // AutoInstrumentationJavaImage returns OpenTelemetry Java auto-instrumentation container image.
 func (c *Config) AutoInstrumentationJavaImage() string {
 	return c.autoInstrumentationJavaImage
@@ -172,7 +236,24 @@ func (c *Config) AutoInstrumentationNginxImage() string {
 	return c.autoInstrumentationNginxImage
 }
 
+// AutoInstrumentationRubyImage returns OpenTelemetry Ruby auto-instrumentation container image.
+func (c *Config) AutoInstrumentationRubyImage() string {
+	return c.autoInstrumentationRubyImage
+}
+
+// AutoInstrumentationPHPImage returns OpenTelemetry PHP auto-instrumentation container image.
+func (c *Config) AutoInstrumentationPHPImage() string {
+	return c.autoInstrumentationPHPImage
+}
+
 // LabelsFilter Returns the filters converted to regex strings used to filter out unwanted labels from propagations.
 func (c *Config) LabelsFilter() []string {
 	return c.labelsFilter
 }
+
+// CrossNamespaceSelectorNamespaces returns the namespaces whose OpenTelemetryCollectors and Instrumentations may
+// select the pods of other namespaces with their namespace selectors. The resources of the other namespaces only
+// select the pods of their own namespace.
+func (c *Config) CrossNamespaceSelectorNamespaces() []string {
+	return c.crossNamespaceSelectorNamespaces
+}
@@ -21,6 +21,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/nativesidecar"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 )
@@ -45,6 +47,12 @@ func TestConfigChangesOnAutoDetect(t *testing.T) {
 		OpenShiftRoutesAvailabilityFunc: func() (openshift.RoutesAvailability, error) {
 			return openshift.RoutesAvailable, nil
 		},
+		GatewayAPIHTTPRoutesAvailabilityFunc: func() (gatewayapi.RoutesAvailability, error) {
+			return gatewayapi.RoutesAvailable, nil
+		},
+		NativeSidecarAvailabilityFunc: func() (nativesidecar.Availability, error) {
+			return nativesidecar.Available, nil
+		},
 	}
 	cfg := config.New(
 		config.WithAutoDetect(mock),
@@ -52,6 +60,9 @@ func TestConfigChangesOnAutoDetect(t *testing.T) {
 
 	// sanity check
 	require.Equal(t, openshift.RoutesNotAvailable, cfg.OpenShiftRoutesAvailability())
+	require.Equal(t, gatewayapi.RoutesNotAvailable, cfg.GatewayAPIHTTPRoutesAvailability())
+	require.Equal(t, gatewayapi.RoutesNotAvailable, cfg.GatewayAPIGRPCRoutesAvailability())
+	require.Equal(t, nativesidecar.NotAvailable, cfg.NativeSidecarAvailability())
 
 	// test
 	err := cfg.AutoDetect()
@@ -59,12 +70,18 @@ func TestConfigChangesOnAutoDetect(t *testing.T) {
 
 	// verify
 	assert.Equal(t, openshift.RoutesAvailable, cfg.OpenShiftRoutesAvailability())
+	assert.Equal(t, gatewayapi.RoutesAvailable, cfg.GatewayAPIHTTPRoutesAvailability())
+	assert.Equal(t, gatewayapi.RoutesNotAvailable, cfg.GatewayAPIGRPCRoutesAvailability())
+	assert.Equal(t, nativesidecar.Available, cfg.NativeSidecarAvailability())
 }
 
 var _ autodetect.AutoDetect = (*mockAutoDetect)(nil)
 
 type mockAutoDetect struct {
-	OpenShiftRoutesAvailabilityFunc func() (openshift.RoutesAvailability, error)
+	OpenShiftRoutesAvailabilityFunc      func() (openshift.RoutesAvailability, error)
+	GatewayAPIHTTPRoutesAvailabilityFunc func() (gatewayapi.RoutesAvailability, error)
+	GatewayAPIGRPCRoutesAvailabilityFunc func() (gatewayapi.RoutesAvailability, error)
+	NativeSidecarAvailabilityFunc        func() (nativesidecar.Availability, error)
 }
 
 func (m *mockAutoDetect) OpenShiftRoutesAvailability() (openshift.RoutesAvailability, error) {
@@ -73,3 +90,24 @@ func (m *mockAutoDetect) OpenShiftRoutesAvailability() (openshift.RoutesAvailabi
 	}
 	return openshift.RoutesNotAvailable, nil
 }
+
+func (m *mockAutoDetect) GatewayAPIHTTPRoutesAvailability() (gatewayapi.RoutesAvailability, error) {
+	if m.GatewayAPIHTTPRoutesAvailabilityFunc != nil {
+		return m.GatewayAPIHTTPRoutesAvailabilityFunc()
+	}
+	return gatewayapi.RoutesNotAvailable, nil
+}
+
+func (m *mockAutoDetect) GatewayAPIGRPCRoutesAvailability() (gatewayapi.RoutesAvailability, error) {
+	if m.GatewayAPIGRPCRoutesAvailabilityFunc != nil {
+		return m.GatewayAPIGRPCRoutesAvailabilityFunc()
+	}
+	return gatewayapi.RoutesNotAvailable, nil
+}
+
+func (m *mockAutoDetect) NativeSidecarAvailability() (nativesidecar.Availability, error) {
+	if m.NativeSidecarAvailabilityFunc != nil {
+		return m.NativeSidecarAvailabilityFunc()
+	}
+	return nativesidecar.NotAvailable, nil
+}
@@ -21,6 +21,8 @@ import (
 	"github.com/go-logr/logr"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/nativesidecar"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 )
@@ -39,14 +41,22 @@ type options struct {
 	autoInstrumentationPythonImage      string
 	autoInstrumentationApacheHttpdImage string
 	autoInstrumentationNginxImage       string
+	autoInstrumentationRubyImage        string
+	autoInstrumentationPHPImage         string
 	collectorImage                      string
 	collectorConfigMapEntry             string
+	sidecarConfigReloaderImage          string
+	persistenceInitImage                string
 	targetAllocatorConfigMapEntry       string
 	operatorOpAMPBridgeConfigMapEntry   string
 	targetAllocatorImage                string
 	operatorOpAMPBridgeImage            string
 	openshiftRoutesAvailability         openshift.RoutesAvailability
+	gatewayAPIHTTPRoutesAvailability    gatewayapi.RoutesAvailability
+	gatewayAPIGRPCRoutesAvailability    gatewayapi.RoutesAvailability
+	nativeSidecarAvailability           nativesidecar.Availability
 	labelsFilter                        []string
+	crossNamespaceSelectorNamespaces    []string
 }
 
 func WithAutoDetect(a autodetect.AutoDetect) Option {
@@ -69,6 +79,16 @@ func WithCollectorImage(s string) Option {
 		o.collectorImage = s
 	}
 }
+func WithSidecarConfigReloaderImage(s string) Option {
+	return func(o *options) {
+		o.sidecarConfigReloaderImage = s
+	}
+}
+func WithPersistenceInitImage(s string) Option {
+	return func(o *options) {
+		o.persistenceInitImage = s
+	}
+}
 func WithCollectorConfigMapEntry(s string) Option {
 	return func(o *options) {
 		o.collectorConfigMapEntry = s
@@ -137,12 +157,50 @@ func WithAutoInstrumentationNginxImage(s string) Option {
 	}
 }
 
+func WithAutoInstrumentationRubyImage(s string) Option {
+	return func(o *options) {
+		o.autoInstrumentationRubyImage = s
+	}
+}
+
+func WithAutoInstrumentationPHPImage(s string) Option {
+	return func(o *options) {
+		o.autoInstrumentationPHPImage = s
+	}
+}
+
 func WithOpenShiftRoutesAvailability(os openshift.RoutesAvailability) Option {
 	return func(o *options) {
 		o.openshiftRoutesAvailability = os
 	}
 }
 
+func WithGatewayAPIHTTPRoutesAvailability(ga gatewayapi.RoutesAvailability) Option {
+	return func(o *options) {
+		o.gatewayAPIHTTPRoutesAvailability = ga
+	}
+}
+
+func WithGatewayAPIGRPCRoutesAvailability(ga gatewayapi.RoutesAvailability) Option {
+	return func(o *options) {
+		o.gatewayAPIGRPCRoutesAvailability = ga
+	}
+}
+
+func WithNativeSidecarAvailability(ns nativesidecar.Availability) Option {
+	return func(o *options) {
+		o.nativeSidecarAvailability = ns
+	}
+}
+
+// WithCrossNamespaceSelectorNamespaces sets the namespaces whose resources may select the pods of other namespaces
+// to inject them, with their namespace selectors.
+func WithCrossNamespaceSelectorNamespaces(namespaces []string) Option {
+	return func(o *options) {
+		o.crossNamespaceSelectorNamespaces = namespaces
+	}
+}
+
 func WithLabelFilters(labelFilters []string) Option {
 	return func(o *options) {
 
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ConfigToComponents returns the settings of the enabled components of the given kind, by component name.
+// Components without settings have empty ones.
+func ConfigToComponents(cType ComponentType, config map[interface{}]interface{}) map[string]map[interface{}]interface{} {
+	components, _ := config[fmt.Sprintf("%ss", cType)].(map[interface{}]interface{})
+
+	settings := map[string]map[interface{}]interface{}{}
+	for key, enabled := range getEnabledComponents(config, cType) {
+		name, ok := key.(string)
+		if !ok || !enabled {
+			continue
+		}
+		cmpt, ok := components[key].(map[interface{}]interface{})
+		if !ok {
+			cmpt = map[interface{}]interface{}{}
+		}
+		settings[name] = cmpt
+	}
+	return settings
+}
+
+// ConfigToComponentTypes returns the sorted types of the enabled components of the given kind, e.g. "filelog" for
+// the "filelog" and "filelog/pods" receivers.
+func ConfigToComponentTypes(cType ComponentType, config map[interface{}]interface{}) []string {
+	found := map[string]bool{}
+	for name := range ConfigToComponents(cType, config) {
+		found[ComponentTypeOf(name)] = true
+	}
+
+	types := make([]string, 0, len(found))
+	for cmptType := range found {
+		types = append(types, cmptType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// ComponentTypeOf returns the type of the component with the given name, e.g. "filelog" for "filelog/pods".
+func ComponentTypeOf(name string) string {
+	cmptType, _, _ := strings.Cut(name, "/")
+	return cmptType
+}
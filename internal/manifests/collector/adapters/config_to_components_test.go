@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
+)
+
+func TestConfigToComponentTypes(t *testing.T) {
+	configStr := `receivers:
+  filelog:
+  filelog/pods:
+  kubeletstats:
+  hostmetrics:
+processors:
+  k8sattributes/pods:
+    passthrough: true
+exporters:
+  otlp:
+  debug:
+service:
+  pipelines:
+    logs:
+      receivers: [filelog, filelog/pods]
+      processors: [k8sattributes/pods]
+      exporters: [otlp]
+    metrics:
+      receivers: [kubeletstats]
+      exporters: [otlp]
+`
+	config, err := adapters.ConfigFromString(configStr)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"filelog", "kubeletstats"}, adapters.ConfigToComponentTypes(adapters.ComponentTypeReceiver, config))
+	assert.Equal(t, []string{"otlp"}, adapters.ConfigToComponentTypes(adapters.ComponentTypeExporter, config))
+	assert.Empty(t, adapters.ConfigToComponentTypes(adapters.ComponentTypeReceiver, map[interface{}]interface{}{}))
+
+	assert.Equal(t, map[string]map[interface{}]interface{}{
+		"k8sattributes/pods": {"passthrough": true},
+	}, adapters.ConfigToComponents(adapters.ComponentTypeProcessor, config))
+	assert.Equal(t, map[string]map[interface{}]interface{}{
+		"filelog":      {},
+		"filelog/pods": {},
+		"kubeletstats": {},
+	}, adapters.ConfigToComponents(adapters.ComponentTypeReceiver, config))
+}
@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+)
+
+var defaultSchemePorts = map[string]int32{
+	"http":  80,
+	"https": 443,
+}
+
+// defaultKubeletPort is the port the kubeletstats receiver connects to when its endpoint doesn't set one.
+const defaultKubeletPort int32 = 10250
+
+// ConfigToReceiverTargetPorts returns the ports of the targets the enabled receivers pull their data from. It
+// also returns whether some targets are only known at runtime, through a service discovery or the target
+// allocator, in which case their ports can't be determined from the configuration.
+func ConfigToReceiverTargetPorts(logger logr.Logger, config map[interface{}]interface{}) ([]int32, bool) {
+	found := map[int32]bool{}
+	dynamic := false
+	for name, receiver := range ConfigToComponents(ComponentTypeReceiver, config) {
+		switch ComponentTypeOf(name) {
+		case "kubeletstats":
+			port := defaultKubeletPort
+			if endpoint, ok := receiver["endpoint"].(string); ok && endpoint != "" {
+				if p, err := endpointPort(endpoint); err == nil {
+					port = p
+				} else {
+					logger.V(2).Info("couldn't determine the port of the kubelet endpoint, using the default one", "receiver", name, "endpoint", endpoint, "error", err.Error())
+				}
+			}
+			found[port] = true
+		case "prometheus_simple":
+			if endpoint, ok := receiver["endpoint"].(string); ok && endpoint != "" {
+				if port, err := endpointPort(endpoint); err == nil {
+					found[port] = true
+				} else {
+					logger.V(2).Info("couldn't determine the port of the scrape endpoint", "receiver", name, "endpoint", endpoint, "error", err.Error())
+				}
+			}
+		case "prometheus":
+			if prometheusTargetPorts(logger, name, receiver, found) {
+				dynamic = true
+			}
+		}
+	}
+	return sortedPorts(found), dynamic
+}
+
+// prometheusTargetPorts adds the ports of the static targets of a prometheus receiver to found, and returns
+// whether the receiver also discovers targets at runtime.
+func prometheusTargetPorts(logger logr.Logger, name string, receiver map[interface{}]interface{}, found map[int32]bool) bool {
+	if _, ok := receiver["target_allocator"]; ok {
+		return true
+	}
+	promCfg, _ := receiver["config"].(map[interface{}]interface{})
+	if _, ok := promCfg["scrape_config_files"]; ok {
+		return true
+	}
+	scrapeConfigs, _ := promCfg["scrape_configs"].([]interface{})
+	dynamic := false
+	for _, sc := range scrapeConfigs {
+		scrapeConfig, ok := sc.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		for key, val := range scrapeConfig {
+			if k, ok := key.(string); ok && strings.HasSuffix(k, "_sd_configs") {
+				dynamic = true
+				continue
+			}
+			if key != "static_configs" {
+				continue
+			}
+			staticConfigs, _ := val.([]interface{})
+			for _, stc := range staticConfigs {
+				staticConfig, _ := stc.(map[interface{}]interface{})
+				targets, _ := staticConfig["targets"].([]interface{})
+				for _, t := range targets {
+					target, ok := t.(string)
+					if !ok {
+						continue
+					}
+					port, err := endpointPort(target)
+					if err != nil {
+						logger.V(2).Info("couldn't determine the port of the scrape target", "receiver", name, "target", target, "error", err.Error())
+						continue
+					}
+					found[port] = true
+				}
+			}
+		}
+	}
+	return dynamic
+}
+
+// ConfigToExporterEndpointPorts returns the ports of the endpoints the enabled exporters send their data to.
+func ConfigToExporterEndpointPorts(logger logr.Logger, config map[interface{}]interface{}) ([]int32, error) {
+	exporters, ok := config["exporters"].(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("no exporters available as part of the configuration")
+	}
+	enabled := getEnabledComponents(config, ComponentTypeExporter)
+
+	found := map[int32]bool{}
+	for key, val := range exporters {
+		if !enabled[key] {
+			continue
+		}
+		exporter, ok := val.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		endpoint, ok := exporter["endpoint"].(string)
+		if !ok || endpoint == "" {
+			continue
+		}
+		port, err := endpointPort(endpoint)
+		if err != nil {
+			logger.V(2).Info("couldn't determine the port of the exporter endpoint", "exporter", key, "endpoint", endpoint, "error", err.Error())
+			continue
+		}
+		found[port] = true
+	}
+	return sortedPorts(found), nil
+}
+
+func sortedPorts(found map[int32]bool) []int32 {
+	ports := make([]int32, 0, len(found))
+	for port := range found {
+		ports = append(ports, port)
+	}
+	sort.Slice(ports, func(i, j int) bool {
+		return ports[i] < ports[j]
+	})
+	return ports
+}
+
+func endpointPort(endpoint string) (int32, error) {
+	host := endpoint
+	if strings.Contains(endpoint, "://") {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			// the host may be an environment variable reference, such as ${env:K8S_NODE_IP}
+			_, rest, _ := strings.Cut(endpoint, "://")
+			host, _, _ = strings.Cut(rest, "/")
+		} else {
+			if u.Port() == "" {
+				if port, ok := defaultSchemePorts[u.Scheme]; ok {
+					return port, nil
+				}
+				return 0, fmt.Errorf("no port in endpoint and no default port for the scheme %q", u.Scheme)
+			}
+			host = u.Host
+		}
+	}
+	_, portStr, err := net.SplitHostPort(host)
+	if err != nil {
+		i := strings.LastIndex(host, ":")
+		if !strings.HasPrefix(host, "${") || i < strings.Index(host, "}") {
+			return 0, err
+		}
+		portStr = host[i+1:]
+	}
+	port, err := strconv.ParseInt(portStr, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return int32(port), nil
+}
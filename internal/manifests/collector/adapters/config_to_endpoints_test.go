@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
+)
+
+func TestConfigToExporterEndpointPorts(t *testing.T) {
+	configStr := `receivers:
+  otlp:
+exporters:
+  otlp:
+    endpoint: backend:4317
+  otlphttp:
+    endpoint: https://otlp.example.com/v1
+  otlphttp/plain:
+    endpoint: http://otlp.example.com:4318
+  otlp/duplicate:
+    endpoint: other-backend:4317
+  otlp/unused:
+    endpoint: unused:5317
+  otlp/noport:
+    endpoint: noport
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp, otlphttp, otlphttp/plain, otlp/duplicate, otlp/noport, debug]
+`
+	config, err := adapters.ConfigFromString(configStr)
+	require.NoError(t, err)
+
+	ports, err := adapters.ConfigToExporterEndpointPorts(logger, config)
+	require.NoError(t, err)
+	assert.Equal(t, []int32{443, 4317, 4318}, ports)
+}
+
+func TestConfigToReceiverTargetPorts(t *testing.T) {
+	configStr := `receivers:
+  kubeletstats:
+  kubeletstats/custom:
+    endpoint: https://${env:K8S_NODE_IP}:10255
+  prometheus_simple:
+    endpoint: app:9090
+  prometheus:
+    config:
+      scrape_configs:
+        - job_name: static
+          static_configs:
+            - targets: ["app:9100", "other-app:9100", "noport"]
+  prometheus/unused:
+    config:
+      scrape_configs:
+        - job_name: discovered
+          kubernetes_sd_configs:
+            - role: pod
+exporters:
+  debug:
+service:
+  pipelines:
+    metrics:
+      receivers: [kubeletstats, kubeletstats/custom, prometheus_simple, prometheus]
+      exporters: [debug]
+`
+	config, err := adapters.ConfigFromString(configStr)
+	require.NoError(t, err)
+
+	ports, dynamic := adapters.ConfigToReceiverTargetPorts(logger, config)
+	assert.Equal(t, []int32{9090, 9100, 10250, 10255}, ports)
+	assert.False(t, dynamic)
+
+	for _, receiver := range []string{`
+  prometheus:
+    config:
+      scrape_configs:
+        - job_name: discovered
+          kubernetes_sd_configs:
+            - role: pod
+`, `
+  prometheus:
+    config:
+      scrape_configs: []
+    target_allocator:
+      endpoint: http://my-targetallocator
+`} {
+		config, err = adapters.ConfigFromString("receivers:" + receiver + `exporters:
+  debug:
+service:
+  pipelines:
+    metrics:
+      receivers: [prometheus]
+      exporters: [debug]
+`)
+		require.NoError(t, err)
+		_, dynamic = adapters.ConfigToReceiverTargetPorts(logger, config)
+		assert.True(t, dynamic)
+	}
+}
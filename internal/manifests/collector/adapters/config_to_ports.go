@@ -35,10 +35,11 @@ type ComponentType int
 const (
 	ComponentTypeReceiver ComponentType = iota
 	ComponentTypeExporter
+	ComponentTypeProcessor
 )
 
 func (c ComponentType) String() string {
-	return [...]string{"receiver", "exporter"}[c]
+	return [...]string{"receiver", "exporter", "processor"}[c]
 }
 
 // ConfigToComponentPorts converts the incoming configuration object into a set of service ports required by the exporters.
@@ -141,6 +142,29 @@ func ConfigToPorts(logger logr.Logger, config map[interface{}]interface{}) ([]co
 	return ports, nil
 }
 
+// ConfigToOTLPPorts converts the incoming configuration object into the ports of its enabled OTLP receivers.
+// The application protocol of the ports is either "grpc" or "http".
+func ConfigToOTLPPorts(logger logr.Logger, config map[interface{}]interface{}) ([]corev1.ServicePort, error) {
+	receivers, ok := config["receivers"].(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("no receivers available as part of the configuration")
+	}
+
+	otlpReceivers := map[interface{}]interface{}{}
+	for key, val := range receivers {
+		if name, ok := key.(string); ok && strings.Split(name, "/")[0] == "otlp" {
+			otlpReceivers[key] = val
+		}
+	}
+	otlpConfig := map[interface{}]interface{}{}
+	for key, val := range config {
+		otlpConfig[key] = val
+	}
+	otlpConfig["receivers"] = otlpReceivers
+
+	return ConfigToComponentPorts(logger, ComponentTypeReceiver, otlpConfig)
+}
+
 // ConfigToMetricsPort gets the port number for the metrics endpoint from the collector config if it has been set.
 func ConfigToMetricsPort(logger logr.Logger, config map[interface{}]interface{}) (int32, error) {
 	// we don't need to unmarshal the whole config, just follow the keys down to
@@ -110,6 +110,25 @@ func TestExtractPortsFromConfig(t *testing.T) {
 	assert.ElementsMatch(t, expectedPorts, ports)
 }
 
+func TestExtractOTLPPortsFromConfig(t *testing.T) {
+	// prepare
+	config, err := adapters.ConfigFromString(portConfigStr)
+	require.NoError(t, err)
+	require.NotEmpty(t, config)
+
+	// test
+	ports, err := adapters.ConfigToOTLPPorts(logger, config)
+	assert.NoError(t, err)
+
+	// verify
+	grpc, http := "grpc", "http"
+	assert.Equal(t, []corev1.ServicePort{
+		{Name: "otlp-2-grpc", Protocol: "TCP", AppProtocol: &grpc, Port: 55555},
+		{Name: "otlp-grpc", AppProtocol: &grpc, Port: 4317, TargetPort: intstr.FromInt(4317)},
+		{Name: "otlp-http", AppProtocol: &http, Port: 4318, TargetPort: intstr.FromInt(4318)},
+	}, ports)
+}
+
 func TestNoPortsParsed(t *testing.T) {
 	for _, tt := range []struct {
 		expected  error
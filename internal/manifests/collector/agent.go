@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
+)
+
+// agentHostPath is a host path mounted read-only into a node agent collector.
+type agentHostPath struct {
+	name      string
+	path      string
+	mountPath string
+}
+
+// agentHostPaths are the host paths needed by the receivers of a node agent collector.
+var agentHostPaths = map[string][]agentHostPath{
+	"filelog": {
+		{name: "otc-agent-varlogpods", path: "/var/log/pods", mountPath: "/var/log/pods"},
+		{name: "otc-agent-varlibdockercontainers", path: "/var/lib/docker/containers", mountPath: "/var/lib/docker/containers"},
+	},
+	"hostmetrics": {
+		{name: "otc-agent-hostfs", path: "/", mountPath: "/hostfs"},
+	},
+}
+
+// isAgent returns whether the collector gets the defaults of a node agent.
+func isAgent(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.Mode == v1alpha1.ModeDaemonSet && otelcol.Spec.Agent.Enabled
+}
+
+// agentHostPathsFor returns the host paths needed by the receivers of the collector's configuration.
+func agentHostPathsFor(otelcol v1alpha1.OpenTelemetryCollector) []agentHostPath {
+	if !isAgent(otelcol) {
+		return nil
+	}
+	c, err := adapters.ConfigFromString(otelcol.Spec.Config)
+	if err != nil {
+		return nil
+	}
+
+	var hostPaths []agentHostPath
+	for _, receiver := range adapters.ConfigToComponentTypes(adapters.ComponentTypeReceiver, c) {
+		hostPaths = append(hostPaths, agentHostPaths[receiver]...)
+	}
+	return hostPaths
+}
+
+// agentVolumes returns the host path volumes of a node agent collector.
+func agentVolumes(otelcol v1alpha1.OpenTelemetryCollector) []corev1.Volume {
+	var volumes []corev1.Volume
+	for _, hostPath := range agentHostPathsFor(otelcol) {
+		volumes = append(volumes, corev1.Volume{
+			Name: hostPath.name,
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{Path: hostPath.path},
+			},
+		})
+	}
+	return volumes
+}
+
+// agentVolumeMounts returns the read-only mounts of the host path volumes of a node agent collector.
+func agentVolumeMounts(otelcol v1alpha1.OpenTelemetryCollector) []corev1.VolumeMount {
+	var volumeMounts []corev1.VolumeMount
+	for _, hostPath := range agentHostPathsFor(otelcol) {
+		volumeMount := corev1.VolumeMount{
+			Name:      hostPath.name,
+			MountPath: hostPath.mountPath,
+			ReadOnly:  true,
+		}
+		if hostPath.path == "/" {
+			// the host's filesystems mounted after the collector started are visible too
+			propagation := corev1.MountPropagationHostToContainer
+			volumeMount.MountPropagation = &propagation
+		}
+		volumeMounts = append(volumeMounts, volumeMount)
+	}
+	return volumeMounts
+}
+
+// agentEnvVars returns the environment variables of a node agent collector that aren't defined yet.
+func agentEnvVars(otelcol v1alpha1.OpenTelemetryCollector, envVars []corev1.EnvVar) []corev1.EnvVar {
+	if !isAgent(otelcol) {
+		return nil
+	}
+
+	defined := map[string]bool{}
+	for _, envVar := range envVars {
+		defined[envVar.Name] = true
+	}
+
+	var agentEnvVars []corev1.EnvVar
+	for _, envVar := range []struct{ name, fieldPath string }{
+		{name: "K8S_NODE_NAME", fieldPath: "spec.nodeName"},
+		{name: "K8S_NODE_IP", fieldPath: "status.hostIP"},
+	} {
+		if defined[envVar.name] {
+			continue
+		}
+		agentEnvVars = append(agentEnvVars, corev1.EnvVar{
+			Name: envVar.name,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: envVar.fieldPath},
+			},
+		})
+	}
+	return agentEnvVars
+}
+
+// setAgentHostPorts binds the ports of the receivers of a node agent collector on the node, when requested and the
+// collector doesn't already use the host network.
+func setAgentHostPorts(otelcol v1alpha1.OpenTelemetryCollector, ports map[string]corev1.ContainerPort) {
+	if !isAgent(otelcol) || !otelcol.Spec.Agent.HostPorts || otelcol.Spec.HostNetwork {
+		return
+	}
+	for name, port := range ports {
+		if name == "metrics" {
+			continue
+		}
+		port.HostPort = port.ContainerPort
+		ports[name] = port
+	}
+}
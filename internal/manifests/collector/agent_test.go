@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	. "github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+)
+
+const agentConfig = `receivers:
+  filelog:
+    include: [/var/log/pods/*/*/*.log]
+  kubeletstats:
+    endpoint: https://${env:K8S_NODE_NAME}:10250
+  hostmetrics:
+    root_path: /hostfs
+exporters:
+  debug:
+service:
+  pipelines:
+    logs:
+      receivers: [filelog]
+      exporters: [debug]
+    metrics:
+      receivers: [kubeletstats]
+      exporters: [debug]
+`
+
+func TestDaemonSetAgent(t *testing.T) {
+	params := manifests.Params{
+		Config: config.New(),
+		OtelCol: v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-instance",
+				Namespace: "my-namespace",
+			},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Mode:   v1alpha1.ModeDaemonSet,
+				Config: agentConfig,
+				Agent:  v1alpha1.AgentSpec{Enabled: true},
+				Env:    []corev1.EnvVar{{Name: "K8S_NODE_IP", Value: "overridden"}},
+			},
+		},
+		Log: logger,
+	}
+
+	d := DaemonSet(params)
+
+	// the hostmetrics receiver isn't part of any pipeline
+	assert.Equal(t, []corev1.Volume{
+		{Name: "otc-internal", VolumeSource: d.Spec.Template.Spec.Volumes[0].VolumeSource},
+		{Name: "otc-agent-varlogpods", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/log/pods"}}},
+		{Name: "otc-agent-varlibdockercontainers", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/lib/docker/containers"}}},
+	}, d.Spec.Template.Spec.Volumes)
+
+	require.Len(t, d.Spec.Template.Spec.Containers, 1)
+	container := d.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "otc-internal", MountPath: "/conf"},
+		{Name: "otc-agent-varlogpods", MountPath: "/var/log/pods", ReadOnly: true},
+		{Name: "otc-agent-varlibdockercontainers", MountPath: "/var/lib/docker/containers", ReadOnly: true},
+	}, container.VolumeMounts)
+	assert.Contains(t, container.Env, corev1.EnvVar{
+		Name:      "K8S_NODE_NAME",
+		ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"}},
+	})
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "K8S_NODE_IP", Value: "overridden"})
+	assert.NotContains(t, container.Env, corev1.EnvVar{
+		Name:      "K8S_NODE_IP",
+		ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.hostIP"}},
+	})
+}
+
+func TestDaemonSetAgentHostMetrics(t *testing.T) {
+	params := manifests.Params{
+		Config: config.New(),
+		OtelCol: v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-instance"},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Mode: v1alpha1.ModeDaemonSet,
+				Config: `receivers:
+  hostmetrics/cpu:
+exporters:
+  debug:
+service:
+  pipelines:
+    metrics:
+      receivers: [hostmetrics/cpu]
+      exporters: [debug]
+`,
+				Agent: v1alpha1.AgentSpec{Enabled: true},
+			},
+		},
+		Log: logger,
+	}
+
+	d := DaemonSet(params)
+
+	propagation := corev1.MountPropagationHostToContainer
+	assert.Contains(t, d.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name:         "otc-agent-hostfs",
+		VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/"}},
+	})
+	assert.Contains(t, d.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:             "otc-agent-hostfs",
+		MountPath:        "/hostfs",
+		ReadOnly:         true,
+		MountPropagation: &propagation,
+	})
+	assert.Contains(t, d.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+		Name:      "K8S_NODE_IP",
+		ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.hostIP"}},
+	})
+}
+
+func TestDaemonSetAgentHostPorts(t *testing.T) {
+	params := manifests.Params{
+		Config: config.New(),
+		OtelCol: v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-instance"},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Mode: v1alpha1.ModeDaemonSet,
+				Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`,
+				Agent: v1alpha1.AgentSpec{Enabled: true, HostPorts: true},
+			},
+		},
+		Log: logger,
+	}
+
+	d := DaemonSet(params)
+	assert.ElementsMatch(t, []corev1.ContainerPort{
+		{Name: "metrics", ContainerPort: 8888, Protocol: corev1.ProtocolTCP},
+		{Name: "otlp-grpc", ContainerPort: 4317, HostPort: 4317},
+	}, d.Spec.Template.Spec.Containers[0].Ports)
+
+	// the host network already exposes the ports on the node
+	params.OtelCol.Spec.HostNetwork = true
+	d = DaemonSet(params)
+	for _, port := range d.Spec.Template.Spec.Containers[0].Ports {
+		assert.Zero(t, port.HostPort, port.Name)
+	}
+}
+
+func TestDaemonSetWithoutAgent(t *testing.T) {
+	params := manifests.Params{
+		Config: config.New(),
+		OtelCol: v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-instance"},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Mode:   v1alpha1.ModeDaemonSet,
+				Config: agentConfig,
+			},
+		},
+		Log: logger,
+	}
+
+	d := DaemonSet(params)
+
+	assert.Len(t, d.Spec.Template.Spec.Volumes, 1)
+	assert.Len(t, d.Spec.Template.Spec.Containers[0].VolumeMounts, 1)
+	for _, envVar := range d.Spec.Template.Spec.Containers[0].Env {
+		assert.NotEqual(t, "K8S_NODE_NAME", envVar.Name)
+	}
+}
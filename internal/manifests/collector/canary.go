@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+const (
+	// CanaryLabel is set on the canary workload and its pods, so they can be told apart from the main collector pods.
+	CanaryLabel = "operator.opentelemetry.io/collector-canary"
+	canaryValue = "true"
+)
+
+// CanaryConfigHash returns the hash of the canary configuration of the given instance.
+func CanaryConfigHash(otelcol v1alpha1.OpenTelemetryCollector) string {
+	if otelcol.Spec.Canary == nil {
+		return ""
+	}
+	return getConfigMapSHA(otelcol.Spec.Canary.Config)
+}
+
+// CanaryIsActive returns whether the canary workload should be running for the given instance, that is
+// a canary is configured and its configuration hasn't been promoted or aborted yet.
+func CanaryIsActive(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	if otelcol.Spec.Mode != v1alpha1.ModeDeployment || otelcol.Spec.Canary == nil {
+		return false
+	}
+	status := otelcol.Status.Canary
+	return status == nil || status.ConfigHash != CanaryConfigHash(otelcol) || status.Phase == v1alpha1.CanaryPhaseProgressing
+}
+
+// CanaryIsPromoted returns whether the canary configuration of the given instance passed its analysis.
+func CanaryIsPromoted(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	status := otelcol.Status.Canary
+	return otelcol.Spec.Mode == v1alpha1.ModeDeployment && otelcol.Spec.Canary != nil && status != nil &&
+		status.Phase == v1alpha1.CanaryPhasePromoted && status.ConfigHash == CanaryConfigHash(otelcol)
+}
+
+// PromotedInstance returns a copy of the given instance running its canary configuration once it has been
+// promoted. The promotion is only recorded in the status, the instance's spec is left to its owner.
+func PromotedInstance(otelcol v1alpha1.OpenTelemetryCollector) v1alpha1.OpenTelemetryCollector {
+	if !CanaryIsPromoted(otelcol) {
+		return otelcol
+	}
+	return canaryInstance(otelcol)
+}
+
+// CanaryConfigMap builds the config map holding the canary configuration.
+func CanaryConfigMap(params manifests.Params) (*corev1.ConfigMap, error) {
+	if !CanaryIsActive(params.OtelCol) {
+		return nil, nil
+	}
+	params.OtelCol = canaryInstance(params.OtelCol)
+
+	cm, err := ConfigMap(params)
+	if err != nil {
+		return nil, err
+	}
+	cm.Name = naming.CanaryConfigMap(params.OtelCol.Name)
+	cm.Labels["app.kubernetes.io/name"] = cm.Name
+	cm.Labels[CanaryLabel] = canaryValue
+	return cm, nil
+}
+
+// CanaryDeployment builds the deployment running the canary configuration. Its pods carry the collector's
+// selector labels, so they receive a share of the traffic sent to the collector's Service, and the canary label
+// excluded by the selectors of the collector's Deployment and PodDisruptionBudget.
+func CanaryDeployment(params manifests.Params) *appsv1.Deployment {
+	if !CanaryIsActive(params.OtelCol) {
+		return nil
+	}
+	replicas := int32(1)
+	if params.OtelCol.Spec.Canary.Replicas != nil {
+		replicas = *params.OtelCol.Spec.Canary.Replicas
+	}
+	params.OtelCol = canaryInstance(params.OtelCol)
+
+	d := Deployment(params)
+	d.Name = naming.CanaryCollector(params.OtelCol.Name)
+	d.Spec.Replicas = &replicas
+
+	// the deployment and its pod template share the same labels map
+	labels := map[string]string{}
+	for k, v := range d.Labels {
+		labels[k] = v
+	}
+	labels["app.kubernetes.io/name"] = d.Name
+	labels[CanaryLabel] = canaryValue
+	d.Labels = labels
+	d.Spec.Template.Labels = labels
+	d.Spec.Selector.MatchLabels[CanaryLabel] = canaryValue
+	d.Spec.Selector.MatchExpressions = nil
+
+	for i := range d.Spec.Template.Spec.Volumes {
+		volume := &d.Spec.Template.Spec.Volumes[i]
+		if volume.Name == naming.ConfigMapVolume() && volume.ConfigMap != nil {
+			volume.ConfigMap.Name = naming.CanaryConfigMap(params.OtelCol.Name)
+		}
+	}
+	return d
+}
+
+// excludeCanaryPods returns the selector requirement keeping the canary pods out of the collector's workload, which
+// would otherwise adopt them, as they carry its selector labels. It's only set while a canary is configured, as
+// changing the selector of a Deployment recreates it.
+func excludeCanaryPods(otelcol v1alpha1.OpenTelemetryCollector) []metav1.LabelSelectorRequirement {
+	if otelcol.Spec.Mode != v1alpha1.ModeDeployment || otelcol.Spec.Canary == nil {
+		return nil
+	}
+	return []metav1.LabelSelectorRequirement{{
+		Key:      CanaryLabel,
+		Operator: metav1.LabelSelectorOpDoesNotExist,
+	}}
+}
+
+// canaryInstance returns a copy of the given instance running the canary configuration.
+func canaryInstance(otelcol v1alpha1.OpenTelemetryCollector) v1alpha1.OpenTelemetryCollector {
+	canary := otelcol.DeepCopy()
+	canary.Spec.Config = otelcol.Spec.Canary.Config
+	return *canary
+}
@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	policyV1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	. "github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+)
+
+func canaryInstance(status *v1alpha1.CanaryStatus) v1alpha1.OpenTelemetryCollector {
+	two := int32(2)
+	return v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "my-namespace",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:   v1alpha1.ModeDeployment,
+			Config: "receivers: {}",
+			Canary: &v1alpha1.CanarySpec{
+				Config:   "receivers: {otlp: {}}",
+				Replicas: &two,
+			},
+		},
+		Status: v1alpha1.OpenTelemetryCollectorStatus{
+			Canary: status,
+		},
+	}
+}
+
+func TestCanaryDeployment(t *testing.T) {
+	params := manifests.Params{
+		Config:  config.New(),
+		OtelCol: canaryInstance(nil),
+		Log:     logger,
+	}
+
+	d := CanaryDeployment(params)
+	require.NotNil(t, d)
+
+	main := Deployment(params)
+	assert.Equal(t, "my-instance-collector-canary", d.Name)
+	assert.Equal(t, int32(2), *d.Spec.Replicas)
+	assert.Equal(t, "true", d.Spec.Selector.MatchLabels[CanaryLabel])
+	assert.Equal(t, "true", d.Spec.Template.Labels[CanaryLabel])
+	assert.NotContains(t, main.Spec.Template.Labels, CanaryLabel)
+
+	// the canary pods must be selected by the collector's service
+	for k, v := range main.Spec.Selector.MatchLabels {
+		assert.Equal(t, v, d.Spec.Template.Labels[k])
+	}
+
+	// but not by the collector's deployment and pod disruption budget, nor the main pods by the canary deployment
+	mainSelector, err := metav1.LabelSelectorAsSelector(main.Spec.Selector)
+	require.NoError(t, err)
+	assert.True(t, mainSelector.Matches(labels.Set(main.Spec.Template.Labels)))
+	assert.False(t, mainSelector.Matches(labels.Set(d.Spec.Template.Labels)))
+	canarySelector, err := metav1.LabelSelectorAsSelector(d.Spec.Selector)
+	require.NoError(t, err)
+	assert.True(t, canarySelector.Matches(labels.Set(d.Spec.Template.Labels)))
+	assert.False(t, canarySelector.Matches(labels.Set(main.Spec.Template.Labels)))
+	params.OtelCol.Spec.PodDisruptionBudget = &v1alpha1.PodDisruptionBudgetSpec{}
+	pdb, ok := PodDisruptionBudget(params).(*policyV1.PodDisruptionBudget)
+	require.True(t, ok)
+	pdbSelector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+	require.NoError(t, err)
+	assert.True(t, pdbSelector.Matches(labels.Set(main.Spec.Template.Labels)))
+	assert.False(t, pdbSelector.Matches(labels.Set(d.Spec.Template.Labels)))
+
+	assert.Equal(t, "my-instance-collector-canary", d.Spec.Template.Spec.Volumes[0].ConfigMap.Name)
+	assert.NotEqual(t, main.Spec.Template.Annotations["opentelemetry-operator-config/sha256"], d.Spec.Template.Annotations["opentelemetry-operator-config/sha256"])
+}
+
+func TestCanaryConfigMap(t *testing.T) {
+	params := manifests.Params{
+		Config:  config.New(),
+		OtelCol: canaryInstance(nil),
+		Log:     logger,
+	}
+
+	cm, err := CanaryConfigMap(params)
+	require.NoError(t, err)
+	require.NotNil(t, cm)
+
+	assert.Equal(t, "my-instance-collector-canary", cm.Name)
+	assert.Equal(t, "true", cm.Labels[CanaryLabel])
+	assert.Equal(t, "receivers: {otlp: {}}", cm.Data["collector.yaml"])
+}
+
+func TestCanaryIsActive(t *testing.T) {
+	hash := CanaryConfigHash(canaryInstance(nil))
+	tests := []struct {
+		name     string
+		otelcol  v1alpha1.OpenTelemetryCollector
+		expected bool
+	}{
+		{
+			name:     "no canary",
+			otelcol:  v1alpha1.OpenTelemetryCollector{Spec: v1alpha1.OpenTelemetryCollectorSpec{Mode: v1alpha1.ModeDeployment}},
+			expected: false,
+		},
+		{
+			name:     "not analyzed yet",
+			otelcol:  canaryInstance(nil),
+			expected: true,
+		},
+		{
+			name:     "progressing",
+			otelcol:  canaryInstance(&v1alpha1.CanaryStatus{Phase: v1alpha1.CanaryPhaseProgressing, ConfigHash: hash}),
+			expected: true,
+		},
+		{
+			name:     "aborted",
+			otelcol:  canaryInstance(&v1alpha1.CanaryStatus{Phase: v1alpha1.CanaryPhaseAborted, ConfigHash: hash}),
+			expected: false,
+		},
+		{
+			name:     "previous canary aborted",
+			otelcol:  canaryInstance(&v1alpha1.CanaryStatus{Phase: v1alpha1.CanaryPhaseAborted, ConfigHash: "previous"}),
+			expected: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CanaryIsActive(tt.otelcol))
+			params := manifests.Params{Config: config.New(), OtelCol: tt.otelcol, Log: logger}
+			assert.Equal(t, tt.expected, CanaryDeployment(params) != nil)
+		})
+	}
+}
+
+func TestCanarySelectorExclusion(t *testing.T) {
+	params := manifests.Params{
+		Config:  config.New(),
+		OtelCol: canaryInstance(nil),
+		Log:     logger,
+	}
+	params.OtelCol.Spec.Canary = nil
+	params.OtelCol.Spec.PodDisruptionBudget = &v1alpha1.PodDisruptionBudgetSpec{}
+
+	// without a canary, the selectors are left untouched so that the existing deployments aren't recreated
+	assert.Empty(t, Deployment(params).Spec.Selector.MatchExpressions)
+	pdb, ok := PodDisruptionBudget(params).(*policyV1.PodDisruptionBudget)
+	require.True(t, ok)
+	assert.Empty(t, pdb.Spec.Selector.MatchExpressions)
+}
+
+func TestPromotedInstance(t *testing.T) {
+	hash := CanaryConfigHash(canaryInstance(nil))
+
+	promoted := PromotedInstance(canaryInstance(&v1alpha1.CanaryStatus{Phase: v1alpha1.CanaryPhasePromoted, ConfigHash: hash}))
+	assert.Equal(t, "receivers: {otlp: {}}", promoted.Spec.Config)
+
+	// the promotion only applies to the configuration it analyzed
+	previous := PromotedInstance(canaryInstance(&v1alpha1.CanaryStatus{Phase: v1alpha1.CanaryPhasePromoted, ConfigHash: "previous"}))
+	assert.Equal(t, "receivers: {}", previous.Spec.Config)
+	progressing := PromotedInstance(canaryInstance(&v1alpha1.CanaryStatus{Phase: v1alpha1.CanaryPhaseProgressing, ConfigHash: hash}))
+	assert.Equal(t, "receivers: {}", progressing.Spec.Config)
+
+	// the given instance is left untouched
+	otelcol := canaryInstance(&v1alpha1.CanaryStatus{Phase: v1alpha1.CanaryPhasePromoted, ConfigHash: hash})
+	PromotedInstance(otelcol)
+	assert.Equal(t, "receivers: {}", otelcol.Spec.Config)
+}
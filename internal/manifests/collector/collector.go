@@ -34,6 +34,8 @@ func Build(params manifests.Params) ([]client.Object, error) {
 	case v1alpha1.ModeDeployment:
 		manifestFactories = append(manifestFactories, manifests.FactoryWithoutError(Deployment))
 		manifestFactories = append(manifestFactories, manifests.FactoryWithoutError(PodDisruptionBudget))
+		manifestFactories = append(manifestFactories, manifests.Factory(CanaryConfigMap))
+		manifestFactories = append(manifestFactories, manifests.FactoryWithoutError(CanaryDeployment))
 	case v1alpha1.ModeStatefulSet:
 		manifestFactories = append(manifestFactories, manifests.FactoryWithoutError(StatefulSet))
 		manifestFactories = append(manifestFactories, manifests.FactoryWithoutError(PodDisruptionBudget))
@@ -46,10 +48,15 @@ func Build(params manifests.Params) ([]client.Object, error) {
 		manifests.Factory(ConfigMap),
 		manifests.FactoryWithoutError(HorizontalPodAutoscaler),
 		manifests.FactoryWithoutError(ServiceAccount),
+		manifests.FactoryWithoutError(ClusterRole),
+		manifests.FactoryWithoutError(ClusterRoleBinding),
+		manifests.FactoryWithoutError(Role),
+		manifests.FactoryWithoutError(RoleBinding),
 		manifests.Factory(Service),
 		manifests.Factory(HeadlessService),
 		manifests.Factory(MonitoringService),
 		manifests.Factory(Ingress),
+		manifests.Factory(NetworkPolicy),
 	}...)
 	if params.OtelCol.Spec.Observability.Metrics.EnableMetrics && featuregate.PrometheusOperatorIsAvailable.IsEnabled() {
 		if params.OtelCol.Spec.Mode == v1alpha1.ModeSidecar {
@@ -74,5 +81,19 @@ func Build(params manifests.Params) ([]client.Object, error) {
 	for _, route := range routes {
 		resourceManifests = append(resourceManifests, route)
 	}
+	httpRoutes, err := HTTPRoutes(params)
+	if err != nil {
+		return nil, err
+	}
+	for _, route := range httpRoutes {
+		resourceManifests = append(resourceManifests, route)
+	}
+	grpcRoutes, err := GRPCRoutes(params)
+	if err != nil {
+		return nil, err
+	}
+	for _, route := range grpcRoutes {
+		resourceManifests = append(resourceManifests, route)
+	}
 	return resourceManifests, nil
 }
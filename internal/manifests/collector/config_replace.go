@@ -43,8 +43,8 @@ type Config struct {
 }
 
 func ReplaceConfig(instance v1alpha1.OpenTelemetryCollector) (string, error) {
-	// Check if TargetAllocator is enabled, if not, return the original config
-	if !instance.Spec.TargetAllocator.Enabled {
+	// Check if the configuration needs to be changed, if not, return the original config
+	if !instance.Spec.TargetAllocator.Enabled && !isPersistent(instance) {
 		return instance.Spec.Config, nil
 	}
 
@@ -53,14 +53,36 @@ func ReplaceConfig(instance v1alpha1.OpenTelemetryCollector) (string, error) {
 		return "", err
 	}
 
+	if instance.Spec.TargetAllocator.Enabled {
+		if err := replaceTargetAllocatorConfig(instance, config); err != nil {
+			return "", err
+		}
+	}
+
+	if isPersistent(instance) {
+		if err := addPersistenceToConfig(config); err != nil {
+			return "", err
+		}
+	}
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+// replaceTargetAllocatorConfig points the prometheus receiver of the given configuration to the target allocator.
+func replaceTargetAllocatorConfig(instance v1alpha1.OpenTelemetryCollector, config map[interface{}]interface{}) error {
 	promCfgMap, getCfgPromErr := ta.ConfigToPromConfig(instance.Spec.Config)
 	if getCfgPromErr != nil {
-		return "", getCfgPromErr
+		return getCfgPromErr
 	}
 
 	validateCfgPromErr := ta.ValidatePromConfig(promCfgMap, instance.Spec.TargetAllocator.Enabled, featuregate.EnableTargetAllocatorRewrite.IsEnabled())
 	if validateCfgPromErr != nil {
-		return "", validateCfgPromErr
+		return validateCfgPromErr
 	}
 
 	if featuregate.EnableTargetAllocatorRewrite.IsEnabled() {
@@ -68,34 +90,22 @@ func ReplaceConfig(instance v1alpha1.OpenTelemetryCollector) (string, error) {
 		// $$ in the prom config, we update the YAML file directly without marshaling and unmarshalling.
 		updPromCfgMap, getCfgPromErr := ta.AddTAConfigToPromConfig(promCfgMap, naming.TAService(instance.Name))
 		if getCfgPromErr != nil {
-			return "", getCfgPromErr
+			return getCfgPromErr
 		}
 
 		// type coercion checks are handled in the AddTAConfigToPromConfig method above
 		config["receivers"].(map[interface{}]interface{})["prometheus"] = updPromCfgMap
-
-		out, updCfgMarshalErr := yaml.Marshal(config)
-		if updCfgMarshalErr != nil {
-			return "", updCfgMarshalErr
-		}
-
-		return string(out), nil
+		return nil
 	}
 
 	// To avoid issues caused by Prometheus validation logic, which fails regex validation when it encounters
 	// $$ in the prom config, we update the YAML file directly without marshaling and unmarshalling.
 	updPromCfgMap, err := ta.AddHTTPSDConfigToPromConfig(promCfgMap, naming.TAService(instance.Name))
 	if err != nil {
-		return "", err
+		return err
 	}
 
 	// type coercion checks are handled in the ConfigToPromConfig method above
 	config["receivers"].(map[interface{}]interface{})["prometheus"] = updPromCfgMap
-
-	out, err := yaml.Marshal(config)
-	if err != nil {
-		return "", err
-	}
-
-	return string(out), nil
+	return nil
 }
@@ -55,6 +55,7 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelem
 			Protocol:      p.Protocol,
 		}
 	}
+	setAgentHostPorts(otelcol, ports)
 
 	var volumeMounts []corev1.VolumeMount
 	argsMap := otelcol.Spec.Args
@@ -96,12 +97,16 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelem
 	if len(otelcol.Spec.VolumeMounts) > 0 {
 		volumeMounts = append(volumeMounts, otelcol.Spec.VolumeMounts...)
 	}
+	volumeMounts = append(volumeMounts, agentVolumeMounts(otelcol)...)
+	volumeMounts = append(volumeMounts, persistenceVolumeMounts(otelcol)...)
+	volumeMounts = append(volumeMounts, drainVolumeMounts(otelcol)...)
 
 	var envVars = otelcol.Spec.Env
 	if otelcol.Spec.Env == nil {
 		envVars = []corev1.EnvVar{}
 	}
 
+	envVars = append(envVars, agentEnvVars(otelcol, envVars)...)
 	envVars = append(envVars, corev1.EnvVar{
 		Name: "POD_NAME",
 		ValueFrom: &corev1.EnvVarSource{
@@ -158,10 +163,19 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelem
 		Resources:       otelcol.Spec.Resources,
 		SecurityContext: otelcol.Spec.SecurityContext,
 		LivenessProbe:   livenessProbe,
-		Lifecycle:       otelcol.Spec.Lifecycle,
+		Lifecycle:       lifecycle(otelcol),
 	}
 }
 
+// initContainers returns the init containers of the collector's pods, the ones of the operator before the
+// user's.
+func initContainers(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) []corev1.Container {
+	var containers []corev1.Container
+	containers = append(containers, persistenceInitContainers(cfg, otelcol)...)
+	containers = append(containers, drainInitContainers(otelcol)...)
+	return append(containers, otelcol.Spec.InitContainers...)
+}
+
 func getConfigContainerPorts(logger logr.Logger, cfg string) (map[string]corev1.ContainerPort, error) {
 	ports := map[string]corev1.ContainerPort{}
 	c, err := adapters.ConfigFromString(cfg)
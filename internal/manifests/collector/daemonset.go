@@ -48,20 +48,21 @@ func DaemonSet(params manifests.Params) *appsv1.DaemonSet {
 					Annotations: podAnnotations,
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: ServiceAccountName(params.OtelCol),
-					InitContainers:     params.OtelCol.Spec.InitContainers,
-					Containers:         append(params.OtelCol.Spec.AdditionalContainers, Container(params.Config, params.Log, params.OtelCol, true)),
-					Volumes:            Volumes(params.Config, params.OtelCol),
-					Tolerations:        params.OtelCol.Spec.Tolerations,
-					NodeSelector:       params.OtelCol.Spec.NodeSelector,
-					HostNetwork:        params.OtelCol.Spec.HostNetwork,
-					DNSPolicy:          getDNSPolicy(params.OtelCol),
-					SecurityContext:    params.OtelCol.Spec.PodSecurityContext,
-					PriorityClassName:  params.OtelCol.Spec.PriorityClassName,
-					Affinity:           params.OtelCol.Spec.Affinity,
+					ServiceAccountName:            ServiceAccountName(params.OtelCol),
+					InitContainers:                initContainers(params.Config, params.OtelCol),
+					Containers:                    append(params.OtelCol.Spec.AdditionalContainers, Container(params.Config, params.Log, params.OtelCol, true)),
+					Volumes:                       Volumes(params.Config, params.OtelCol),
+					Tolerations:                   params.OtelCol.Spec.Tolerations,
+					NodeSelector:                  params.OtelCol.Spec.NodeSelector,
+					HostNetwork:                   params.OtelCol.Spec.HostNetwork,
+					DNSPolicy:                     getDNSPolicy(params.OtelCol),
+					SecurityContext:               podSecurityContext(params.OtelCol),
+					PriorityClassName:             params.OtelCol.Spec.PriorityClassName,
+					Affinity:                      params.OtelCol.Spec.Affinity,
+					TerminationGracePeriodSeconds: terminationGracePeriodSeconds(params.OtelCol),
 				},
 			},
-			UpdateStrategy: params.OtelCol.Spec.UpdateStrategy,
+			UpdateStrategy: daemonSetUpdateStrategy(params.OtelCol),
 		},
 	}
 }
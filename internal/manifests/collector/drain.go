@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"math"
+	"path"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+const (
+	// defaultExporterTimeout is the timeout of the exporters' requests when none is configured.
+	defaultExporterTimeout = 5 * time.Second
+	// drainMargin is the time for a terminating pod to be removed from the endpoints of its services, and for the
+	// target allocator to reassign its targets.
+	drainMargin = 5 * time.Second
+	// defaultRetryMaxElapsedTime is the time the exporters retry a request for when none is configured.
+	defaultRetryMaxElapsedTime = 5 * time.Minute
+	// shutdownGracePeriod is the minimum time left to the collector to shut down once the drain is over, the same
+	// as the default termination grace period of the pods.
+	shutdownGracePeriod = 30 * time.Second
+	// drainMountPath is the directory of the collector's container holding the sleep command of the preStop hook.
+	drainMountPath = "/otc-drain"
+	// drainUser is the user copying the sleep command, the same as the collector's image runs as.
+	drainUser int64 = 10001
+)
+
+// isDraining returns whether the collector's pods drain before stopping. The collector images are built from
+// scratch, without the sleep command of the preStop hook: the pods only drain with a drain image or preStop hook.
+func isDraining(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	if !otelcol.Spec.Drain.Enabled || otelcol.Spec.Mode == v1alpha1.ModeSidecar {
+		return false
+	}
+	return otelcol.Spec.Drain.Image != "" || hasPreStop(otelcol)
+}
+
+// hasPreStop returns whether the collector's container defines its own preStop hook.
+func hasPreStop(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.Lifecycle != nil && otelcol.Spec.Lifecycle.PreStop != nil
+}
+
+// isCopyingSleep returns whether the sleep command of the preStop hook is copied from the drain image.
+func isCopyingSleep(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return isDraining(otelcol) && !hasPreStop(otelcol)
+}
+
+// drainDuration returns the wait of the preStop hook of the collector's container, sized to the longest timeout
+// of the exporters with a sending queue unless it is configured.
+func drainDuration(otelcol v1alpha1.OpenTelemetryCollector) time.Duration {
+	if otelcol.Spec.Drain.Duration != nil {
+		return otelcol.Spec.Drain.Duration.Duration
+	}
+
+	timeout := defaultExporterTimeout
+	c, err := adapters.ConfigFromString(otelcol.Spec.Config)
+	if err != nil {
+		return timeout + drainMargin
+	}
+	for name, exporter := range adapters.ConfigToComponents(adapters.ComponentTypeExporter, c) {
+		if !queuedExporters[adapters.ComponentTypeOf(name)] {
+			continue
+		}
+		if queue, ok := exporter["sending_queue"].(map[interface{}]interface{}); ok {
+			if enabled, ok := queue["enabled"].(bool); ok && !enabled {
+				continue
+			}
+		}
+		value, ok := exporter["timeout"].(string)
+		if !ok {
+			continue
+		}
+		if d, err := time.ParseDuration(value); err == nil && d > timeout {
+			timeout = d
+		}
+	}
+	return timeout + drainMargin
+}
+
+// shutdownDuration returns the time left to the collector to shut down once the drain is over, sized to the longest
+// timeout and retries of the exporters with a sending queue, which are flushed on shutdown.
+func shutdownDuration(otelcol v1alpha1.OpenTelemetryCollector) time.Duration {
+	longest := shutdownGracePeriod
+	c, err := adapters.ConfigFromString(otelcol.Spec.Config)
+	if err != nil {
+		return longest
+	}
+	for name, exporter := range adapters.ConfigToComponents(adapters.ComponentTypeExporter, c) {
+		if !queuedExporters[adapters.ComponentTypeOf(name)] {
+			continue
+		}
+		if queue, ok := exporter["sending_queue"].(map[interface{}]interface{}); ok {
+			if enabled, ok := queue["enabled"].(bool); ok && !enabled {
+				continue
+			}
+		}
+		d := durationSetting(exporter, "timeout", defaultExporterTimeout)
+		retry, _ := exporter["retry_on_failure"].(map[interface{}]interface{})
+		if enabled, ok := retry["enabled"].(bool); !ok || enabled {
+			d += durationSetting(retry, "max_elapsed_time", defaultRetryMaxElapsedTime)
+		}
+		if d > longest {
+			longest = d
+		}
+	}
+	return longest
+}
+
+// durationSetting returns the given duration of a component's settings, or the default when it's not set, invalid
+// or unlimited.
+func durationSetting(settings map[interface{}]interface{}, name string, defaultValue time.Duration) time.Duration {
+	value, ok := settings[name].(string)
+	if !ok {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return defaultValue
+	}
+	return d
+}
+
+// drainSeconds returns the wait of the preStop hook in whole seconds.
+func drainSeconds(otelcol v1alpha1.OpenTelemetryCollector) int64 {
+	return int64(math.Ceil(drainDuration(otelcol).Seconds()))
+}
+
+// lifecycle returns the lifecycle of the collector's container, with a preStop hook waiting for the drain unless
+// another one is defined.
+func lifecycle(otelcol v1alpha1.OpenTelemetryCollector) *corev1.Lifecycle {
+	if !isCopyingSleep(otelcol) {
+		return otelcol.Spec.Lifecycle
+	}
+
+	l := &corev1.Lifecycle{}
+	if otelcol.Spec.Lifecycle != nil {
+		l = otelcol.Spec.Lifecycle.DeepCopy()
+	}
+	l.PreStop = &corev1.LifecycleHandler{
+		Exec: &corev1.ExecAction{
+			Command: []string{path.Join(drainMountPath, "sleep"), strconv.FormatInt(drainSeconds(otelcol), 10)},
+		},
+	}
+	return l
+}
+
+// drainInitContainers returns the init container copying the sleep command of the preStop hook from the drain image.
+func drainInitContainers(otelcol v1alpha1.OpenTelemetryCollector) []corev1.Container {
+	if !isCopyingSleep(otelcol) {
+		return nil
+	}
+
+	user := drainUser
+	runAsNonRoot := true
+	allowPrivilegeEscalation := false
+	return []corev1.Container{{
+		Name:    naming.DrainInitContainer(),
+		Image:   otelcol.Spec.Drain.Image,
+		Command: []string{"cp", "/bin/sleep", path.Join(drainMountPath, "sleep")},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      naming.DrainVolume(),
+			MountPath: drainMountPath,
+		}},
+		SecurityContext: &corev1.SecurityContext{
+			RunAsUser:                &user,
+			RunAsNonRoot:             &runAsNonRoot,
+			AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+			SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+		},
+	}}
+}
+
+// drainVolumes returns the volume holding the sleep command of the preStop hook.
+func drainVolumes(otelcol v1alpha1.OpenTelemetryCollector) []corev1.Volume {
+	if !isCopyingSleep(otelcol) {
+		return nil
+	}
+	return []corev1.Volume{{
+		Name: naming.DrainVolume(),
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}}
+}
+
+// drainVolumeMounts returns the mount of the volume holding the sleep command of the preStop hook.
+func drainVolumeMounts(otelcol v1alpha1.OpenTelemetryCollector) []corev1.VolumeMount {
+	if !isCopyingSleep(otelcol) {
+		return nil
+	}
+	return []corev1.VolumeMount{{
+		Name:      naming.DrainVolume(),
+		MountPath: drainMountPath,
+		ReadOnly:  true,
+	}}
+}
+
+// terminationGracePeriodSeconds returns the termination grace period of the collector's pods, leaving the collector
+// time to flush its sending queues after the drain unless it is set.
+func terminationGracePeriodSeconds(otelcol v1alpha1.OpenTelemetryCollector) *int64 {
+	if !isDraining(otelcol) || otelcol.Spec.TerminationGracePeriodSeconds != nil {
+		return otelcol.Spec.TerminationGracePeriodSeconds
+	}
+	seconds := drainSeconds(otelcol) + int64(math.Ceil(shutdownDuration(otelcol).Seconds()))
+	return &seconds
+}
@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	. "github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+)
+
+const drainConfig = `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  otlp:
+    endpoint: backend:4317
+    timeout: 20s
+  otlphttp:
+    endpoint: http://backend:4318
+    timeout: 1m
+    sending_queue:
+      enabled: false
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp, otlphttp, debug]
+`
+
+func drainParams(mode v1alpha1.Mode, drain v1alpha1.DrainSpec) manifests.Params {
+	return manifests.Params{
+		Config: config.New(),
+		OtelCol: v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-instance",
+				Namespace: "my-namespace",
+			},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Mode:   mode,
+				Config: drainConfig,
+				Drain:  drain,
+			},
+		},
+		Log: logger,
+	}
+}
+
+func TestDrainSizedToExporters(t *testing.T) {
+	params := drainParams(v1alpha1.ModeStatefulSet, v1alpha1.DrainSpec{Enabled: true, Image: "busybox:1.36"})
+
+	s := StatefulSet(params)
+
+	// the otlphttp exporter doesn't queue its requests, the otlp exporter retries them for 5 minutes by default
+	assert.Equal(t, &corev1.Lifecycle{
+		PreStop: &corev1.LifecycleHandler{
+			Exec: &corev1.ExecAction{Command: []string{"/otc-drain/sleep", "25"}},
+		},
+	}, s.Spec.Template.Spec.Containers[0].Lifecycle)
+	expected := int64(345)
+	assert.Equal(t, &expected, s.Spec.Template.Spec.TerminationGracePeriodSeconds)
+}
+
+func TestDrainDuration(t *testing.T) {
+	params := drainParams(v1alpha1.ModeDaemonSet, v1alpha1.DrainSpec{Enabled: true, Image: "busybox:1.36", Duration: &metav1.Duration{Duration: 10 * time.Second}})
+	params.OtelCol.Spec.Lifecycle = &corev1.Lifecycle{
+		PostStart: &corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"true"}}},
+	}
+
+	d := DaemonSet(params)
+
+	assert.Equal(t, &corev1.Lifecycle{
+		PostStart: &corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"true"}}},
+		PreStop: &corev1.LifecycleHandler{
+			Exec: &corev1.ExecAction{Command: []string{"/otc-drain/sleep", "10"}},
+		},
+	}, d.Spec.Template.Spec.Containers[0].Lifecycle)
+	expected := int64(330)
+	assert.Equal(t, &expected, d.Spec.Template.Spec.TerminationGracePeriodSeconds)
+}
+
+func TestDrainCopiesSleep(t *testing.T) {
+	params := drainParams(v1alpha1.ModeDeployment, v1alpha1.DrainSpec{Enabled: true, Image: "busybox:1.36"})
+	params.OtelCol.Spec.InitContainers = []corev1.Container{{Name: "my-init"}}
+
+	d := Deployment(params)
+
+	initContainers := d.Spec.Template.Spec.InitContainers
+	assert.Len(t, initContainers, 2)
+	assert.Equal(t, "otc-drain-init", initContainers[0].Name)
+	assert.Equal(t, "busybox:1.36", initContainers[0].Image)
+	assert.Equal(t, []string{"cp", "/bin/sleep", "/otc-drain/sleep"}, initContainers[0].Command)
+	assert.Equal(t, []corev1.VolumeMount{{Name: "otc-drain", MountPath: "/otc-drain"}}, initContainers[0].VolumeMounts)
+	assert.True(t, *initContainers[0].SecurityContext.RunAsNonRoot)
+	assert.False(t, *initContainers[0].SecurityContext.AllowPrivilegeEscalation)
+	assert.Equal(t, []corev1.Capability{"ALL"}, initContainers[0].SecurityContext.Capabilities.Drop)
+	assert.Equal(t, "my-init", initContainers[1].Name)
+	assert.Contains(t, d.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name:         "otc-drain",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	})
+	assert.Contains(t, d.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "otc-drain",
+		MountPath: "/otc-drain",
+		ReadOnly:  true,
+	})
+}
+
+func TestDrainSizedToRetries(t *testing.T) {
+	params := drainParams(v1alpha1.ModeDeployment, v1alpha1.DrainSpec{Enabled: true, Image: "busybox:1.36"})
+	params.OtelCol.Spec.Config = `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  otlp:
+    endpoint: backend:4317
+    retry_on_failure:
+      max_elapsed_time: 1m
+  otlp/noretry:
+    endpoint: backend:4317
+    timeout: 10s
+    retry_on_failure:
+      enabled: false
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp, otlp/noretry]
+`
+
+	d := Deployment(params)
+
+	// the preStop hook waits for the longest timeout, the collector then flushes the queues for the longest retries
+	assert.Equal(t, []string{"/otc-drain/sleep", "15"}, d.Spec.Template.Spec.Containers[0].Lifecycle.PreStop.Exec.Command)
+	expected := int64(80)
+	assert.Equal(t, &expected, d.Spec.Template.Spec.TerminationGracePeriodSeconds)
+}
+
+func TestDrainKeepsUserSettings(t *testing.T) {
+	params := drainParams(v1alpha1.ModeDeployment, v1alpha1.DrainSpec{Enabled: true})
+	lifecycle := &corev1.Lifecycle{
+		PreStop: &corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"/bin/drain"}}},
+	}
+	gracePeriod := int64(120)
+	params.OtelCol.Spec.Lifecycle = lifecycle
+	params.OtelCol.Spec.TerminationGracePeriodSeconds = &gracePeriod
+
+	d := Deployment(params)
+
+	assert.Equal(t, lifecycle, d.Spec.Template.Spec.Containers[0].Lifecycle)
+	assert.Empty(t, d.Spec.Template.Spec.InitContainers)
+	assert.Equal(t, &gracePeriod, d.Spec.Template.Spec.TerminationGracePeriodSeconds)
+}
+
+func TestDrainDisabled(t *testing.T) {
+	params := drainParams(v1alpha1.ModeDeployment, v1alpha1.DrainSpec{})
+
+	d := Deployment(params)
+
+	assert.Nil(t, d.Spec.Template.Spec.Containers[0].Lifecycle)
+	assert.Nil(t, d.Spec.Template.Spec.TerminationGracePeriodSeconds)
+}
+
+func TestDrainWithoutImage(t *testing.T) {
+	params := drainParams(v1alpha1.ModeDeployment, v1alpha1.DrainSpec{Enabled: true})
+	params.OtelCol.Spec.Image = "otel/opentelemetry-collector-contrib:0.88.0"
+
+	d := Deployment(params)
+
+	// the collector images have no sleep command to run in a preStop hook
+	assert.Nil(t, d.Spec.Template.Spec.Containers[0].Lifecycle)
+	assert.Empty(t, d.Spec.Template.Spec.InitContainers)
+	assert.Nil(t, d.Spec.Template.Spec.TerminationGracePeriodSeconds)
+}
@@ -0,0 +1,172 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// HTTPRoutes builds a Gateway API HTTPRoute for each receiver port which isn't served over gRPC.
+func HTTPRoutes(params manifests.Params) ([]*gatewayv1beta1.HTTPRoute, error) {
+	ports, err := gatewayRoutePorts(params, params.Config.GatewayAPIHTTPRoutesAvailability())
+	if err != nil || len(ports) == 0 {
+		return nil, err
+	}
+
+	var routes []*gatewayv1beta1.HTTPRoute
+	for _, p := range ports {
+		if isGRPCPort(p) {
+			continue
+		}
+		routes = append(routes, &gatewayv1beta1.HTTPRoute{
+			ObjectMeta: gatewayRouteObjectMeta(params.OtelCol, p),
+			Spec: gatewayv1beta1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayRouteCommonSpec(params.OtelCol),
+				Hostnames:       gatewayRouteHostnames(params.OtelCol, p),
+				Rules: []gatewayv1beta1.HTTPRouteRule{{
+					BackendRefs: []gatewayv1beta1.HTTPBackendRef{{
+						BackendRef: gatewayRouteBackendRef(params.OtelCol, p),
+					}},
+				}},
+			},
+		})
+	}
+	return routes, nil
+}
+
+// GRPCRoutes builds a Gateway API GRPCRoute for each receiver port served over gRPC.
+func GRPCRoutes(params manifests.Params) ([]*gatewayv1alpha2.GRPCRoute, error) {
+	ports, err := gatewayRoutePorts(params, params.Config.GatewayAPIGRPCRoutesAvailability())
+	if err != nil || len(ports) == 0 {
+		return nil, err
+	}
+
+	var routes []*gatewayv1alpha2.GRPCRoute
+	for _, p := range ports {
+		if !isGRPCPort(p) {
+			continue
+		}
+		routes = append(routes, &gatewayv1alpha2.GRPCRoute{
+			ObjectMeta: gatewayRouteObjectMeta(params.OtelCol, p),
+			Spec: gatewayv1alpha2.GRPCRouteSpec{
+				CommonRouteSpec: gatewayRouteCommonSpec(params.OtelCol),
+				Hostnames:       gatewayRouteHostnames(params.OtelCol, p),
+				Rules: []gatewayv1alpha2.GRPCRouteRule{{
+					BackendRefs: []gatewayv1alpha2.GRPCBackendRef{{
+						BackendRef: gatewayRouteBackendRef(params.OtelCol, p),
+					}},
+				}},
+			},
+		})
+	}
+	return routes, nil
+}
+
+// gatewayRoutePorts returns the ports to build routes for, or nothing when the Gateway API routes shouldn't be built,
+// given the availability of their kind.
+func gatewayRoutePorts(params manifests.Params, availability gatewayapi.RoutesAvailability) ([]corev1.ServicePort, error) {
+	if params.OtelCol.Spec.Ingress.Type != v1alpha1.IngressTypeGateway || availability != gatewayapi.RoutesAvailable {
+		return nil, nil
+	}
+
+	if params.OtelCol.Spec.Mode == v1alpha1.ModeSidecar {
+		params.Log.V(3).Info("ingress settings are not supported in sidecar mode")
+		return nil, nil
+	}
+
+	ports, err := servicePortsFromCfg(params.Log, params.OtelCol)
+
+	// if we have no ports, we don't need a route entry
+	if len(ports) == 0 || err != nil {
+		params.Log.V(1).Info(
+			"the instance's configuration didn't yield any ports to open, skipping gateway routes",
+			"instance.name", params.OtelCol.Name,
+			"instance.namespace", params.OtelCol.Namespace,
+		)
+		return nil, err
+	}
+
+	var tcpPorts []corev1.ServicePort
+	for _, p := range ports {
+		// the Gateway API HTTPRoute and GRPCRoute only carry TCP traffic.
+		if p.Protocol == "" || p.Protocol == corev1.ProtocolTCP {
+			tcpPorts = append(tcpPorts, p)
+		}
+	}
+	return tcpPorts, nil
+}
+
+func isGRPCPort(p corev1.ServicePort) bool {
+	return p.AppProtocol != nil && strings.EqualFold(*p.AppProtocol, "grpc")
+}
+
+func gatewayRouteObjectMeta(otelcol v1alpha1.OpenTelemetryCollector, p corev1.ServicePort) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        naming.Route(otelcol.Name, p.Name),
+		Namespace:   otelcol.Namespace,
+		Annotations: otelcol.Spec.Ingress.Annotations,
+		Labels: map[string]string{
+			"app.kubernetes.io/name":       naming.Route(otelcol.Name, p.Name),
+			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", otelcol.Namespace, otelcol.Name),
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+			"app.kubernetes.io/component":  "opentelemetry-collector",
+		},
+	}
+}
+
+func gatewayRouteCommonSpec(otelcol v1alpha1.OpenTelemetryCollector) gatewayv1beta1.CommonRouteSpec {
+	gateway := otelcol.Spec.Ingress.Gateway
+	parentRef := gatewayv1beta1.ParentReference{
+		Name: gatewayv1beta1.ObjectName(gateway.Name),
+	}
+	if gateway.Namespace != "" {
+		namespace := gatewayv1beta1.Namespace(gateway.Namespace)
+		parentRef.Namespace = &namespace
+	}
+	if gateway.SectionName != "" {
+		sectionName := gatewayv1beta1.SectionName(gateway.SectionName)
+		parentRef.SectionName = &sectionName
+	}
+	return gatewayv1beta1.CommonRouteSpec{ParentRefs: []gatewayv1beta1.ParentReference{parentRef}}
+}
+
+func gatewayRouteHostnames(otelcol v1alpha1.OpenTelemetryCollector, p corev1.ServicePort) []gatewayv1beta1.Hostname {
+	if otelcol.Spec.Ingress.Hostname == "" {
+		return nil
+	}
+	portName := naming.PortName(p.Name, p.Port)
+	return []gatewayv1beta1.Hostname{gatewayv1beta1.Hostname(fmt.Sprintf("%s.%s", portName, otelcol.Spec.Ingress.Hostname))}
+}
+
+func gatewayRouteBackendRef(otelcol v1alpha1.OpenTelemetryCollector, p corev1.ServicePort) gatewayv1beta1.BackendRef {
+	port := gatewayv1beta1.PortNumber(p.Port)
+	return gatewayv1beta1.BackendRef{
+		BackendObjectReference: gatewayv1beta1.BackendObjectReference{
+			Name: gatewayv1beta1.ObjectName(naming.Service(otelcol.Name)),
+			Port: &port,
+		},
+	}
+}
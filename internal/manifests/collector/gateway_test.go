@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+)
+
+const gatewayRoutesConfig = `receivers:
+  otlp:
+    protocols:
+      grpc:
+      http:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`
+
+func gatewayParams(availability gatewayapi.RoutesAvailability) manifests.Params {
+	return manifests.Params{
+		Config: config.New(
+			config.WithGatewayAPIHTTPRoutesAvailability(availability),
+			config.WithGatewayAPIGRPCRoutesAvailability(availability),
+		),
+		Log: logger,
+		OtelCol: v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Config: gatewayRoutesConfig,
+				Ingress: v1alpha1.Ingress{
+					Type:     v1alpha1.IngressTypeGateway,
+					Hostname: "example.com",
+					Gateway: v1alpha1.GatewayRoute{
+						Name:        "public",
+						Namespace:   "gateways",
+						SectionName: "https",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGatewayRoutes(t *testing.T) {
+	t.Run("should return nil when the Gateway API isn't available", func(t *testing.T) {
+		params := gatewayParams(gatewayapi.RoutesNotAvailable)
+
+		httpRoutes, err := HTTPRoutes(params)
+		require.NoError(t, err)
+		assert.Nil(t, httpRoutes)
+
+		grpcRoutes, err := GRPCRoutes(params)
+		require.NoError(t, err)
+		assert.Nil(t, grpcRoutes)
+	})
+
+	t.Run("should return nil for another ingress type", func(t *testing.T) {
+		params := gatewayParams(gatewayapi.RoutesAvailable)
+		params.OtelCol.Spec.Ingress.Type = v1alpha1.IngressTypeNginx
+
+		httpRoutes, err := HTTPRoutes(params)
+		require.NoError(t, err)
+		assert.Nil(t, httpRoutes)
+	})
+
+	t.Run("should return nil unable to parse config", func(t *testing.T) {
+		params := gatewayParams(gatewayapi.RoutesAvailable)
+		params.OtelCol.Spec.Config = "!!!"
+
+		httpRoutes, err := HTTPRoutes(params)
+		assert.Nil(t, httpRoutes)
+		assert.ErrorContains(t, err, "couldn't parse the opentelemetry-collector configuration")
+	})
+
+	t.Run("should split the receiver ports into http and grpc routes", func(t *testing.T) {
+		params := gatewayParams(gatewayapi.RoutesAvailable)
+
+		httpRoutes, err := HTTPRoutes(params)
+		require.NoError(t, err)
+		require.Len(t, httpRoutes, 1)
+		grpcRoutes, err := GRPCRoutes(params)
+		require.NoError(t, err)
+		require.Len(t, grpcRoutes, 1)
+
+		httpRoute := httpRoutes[0]
+		assert.Equal(t, "otlp-http-test-route", httpRoute.Name)
+		assert.Equal(t, []gatewayv1beta1.Hostname{"otlp-http.example.com"}, httpRoute.Spec.Hostnames)
+		require.Len(t, httpRoute.Spec.ParentRefs, 1)
+		assert.Equal(t, gatewayv1beta1.ObjectName("public"), httpRoute.Spec.ParentRefs[0].Name)
+		assert.Equal(t, gatewayv1beta1.Namespace("gateways"), *httpRoute.Spec.ParentRefs[0].Namespace)
+		assert.Equal(t, gatewayv1beta1.SectionName("https"), *httpRoute.Spec.ParentRefs[0].SectionName)
+		require.Len(t, httpRoute.Spec.Rules, 1)
+		backend := httpRoute.Spec.Rules[0].BackendRefs[0].BackendObjectReference
+		assert.Equal(t, gatewayv1beta1.ObjectName("test-collector"), backend.Name)
+		assert.Equal(t, gatewayv1beta1.PortNumber(4318), *backend.Port)
+
+		grpcRoute := grpcRoutes[0]
+		assert.Equal(t, "otlp-grpc-test-route", grpcRoute.Name)
+		assert.Equal(t, []gatewayv1beta1.Hostname{"otlp-grpc.example.com"}, grpcRoute.Spec.Hostnames)
+		require.Len(t, grpcRoute.Spec.Rules, 1)
+		assert.Equal(t, gatewayv1beta1.PortNumber(4317), *grpcRoute.Spec.Rules[0].BackendRefs[0].Port)
+	})
+
+	t.Run("should only build the http routes without the experimental channel", func(t *testing.T) {
+		params := gatewayParams(gatewayapi.RoutesAvailable)
+		params.Config = config.New(
+			config.WithGatewayAPIHTTPRoutesAvailability(gatewayapi.RoutesAvailable),
+			config.WithGatewayAPIGRPCRoutesAvailability(gatewayapi.RoutesNotAvailable),
+		)
+
+		httpRoutes, err := HTTPRoutes(params)
+		require.NoError(t, err)
+		assert.Len(t, httpRoutes, 1)
+
+		grpcRoutes, err := GRPCRoutes(params)
+		require.NoError(t, err)
+		assert.Nil(t, grpcRoutes)
+	})
+
+	t.Run("should not build routes in sidecar mode", func(t *testing.T) {
+		params := gatewayParams(gatewayapi.RoutesAvailable)
+		params.OtelCol.Spec.Mode = v1alpha1.ModeSidecar
+
+		grpcRoutes, err := GRPCRoutes(params)
+		require.NoError(t, err)
+		assert.Nil(t, grpcRoutes)
+	})
+}
@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+const (
+	dnsPort             = 53
+	targetAllocatorPort = 8080
+)
+
+// apiServerPorts are the ports the Kubernetes API server usually listens on, the NetworkPolicies matching
+// the traffic once the address of its Service has been translated to the address of its endpoints.
+var apiServerPorts = []int{443, 6443}
+
+// apiServerComponents are the components watching the Kubernetes API, by component kind and type.
+var apiServerComponents = map[adapters.ComponentType]map[string]func(settings map[interface{}]interface{}) bool{
+	adapters.ComponentTypeReceiver: {
+		"k8s_cluster": always,
+		"k8s_events":  always,
+		"k8sobjects":  always,
+	},
+	adapters.ComponentTypeProcessor: {
+		"k8sattributes": func(settings map[interface{}]interface{}) bool {
+			passthrough, _ := settings["passthrough"].(bool)
+			return !passthrough
+		},
+	},
+}
+
+func always(map[interface{}]interface{}) bool {
+	return true
+}
+
+// NetworkPolicy builds the NetworkPolicy restricting the traffic of the collector pods to its known ports.
+func NetworkPolicy(params manifests.Params) (*networkingv1.NetworkPolicy, error) {
+	spec := params.OtelCol.Spec.NetworkPolicy
+	if !spec.Enabled || params.OtelCol.Spec.Mode == v1alpha1.ModeSidecar {
+		return nil, nil
+	}
+
+	name := naming.NetworkPolicy(params.OtelCol.Name)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, []string{})
+
+	containerPorts, err := getConfigContainerPorts(params.Log, params.OtelCol.Spec.Config)
+	if err != nil {
+		return nil, err
+	}
+	var ports []networkingv1.NetworkPolicyPort
+	for _, p := range portMapToList(containerPorts) {
+		ports = append(ports, networkPolicyPort(p.Protocol, intstr.FromInt(int(p.ContainerPort))))
+	}
+	for _, p := range params.OtelCol.Spec.Ports {
+		target := p.TargetPort
+		if target.IntVal == 0 && target.StrVal == "" {
+			target = intstr.FromInt(int(p.Port))
+		}
+		ports = append(ports, networkPolicyPort(p.Protocol, target))
+	}
+
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   params.OtelCol.Namespace,
+			Labels:      labels,
+			Annotations: params.OtelCol.Annotations,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector),
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress:     []networkingv1.NetworkPolicyIngressRule{{Ports: ports}},
+		},
+	}
+
+	if spec.RestrictEgress {
+		egress, err := egressRules(params)
+		if err != nil {
+			return nil, err
+		}
+		policy.Spec.PolicyTypes = append(policy.Spec.PolicyTypes, networkingv1.PolicyTypeEgress)
+		policy.Spec.Egress = egress
+	}
+	return policy, nil
+}
+
+// egressRules allows DNS, the target allocator, the Kubernetes API server when the configuration watches it,
+// the ports of the receiver targets and the ports of the exporter endpoints. When the receivers discover their
+// targets at runtime, their ports can't be known and all the egress traffic is allowed.
+func egressRules(params manifests.Params) ([]networkingv1.NetworkPolicyEgressRule, error) {
+	rules := []networkingv1.NetworkPolicyEgressRule{{
+		Ports: []networkingv1.NetworkPolicyPort{
+			networkPolicyPort(corev1.ProtocolUDP, intstr.FromInt(dnsPort)),
+			networkPolicyPort(corev1.ProtocolTCP, intstr.FromInt(dnsPort)),
+		},
+	}}
+
+	if params.OtelCol.Spec.TargetAllocator.Enabled {
+		rules = append(rules, networkingv1.NetworkPolicyEgressRule{
+			To: []networkingv1.NetworkPolicyPeer{{
+				PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{
+					"app.kubernetes.io/managed-by": "opentelemetry-operator",
+					"app.kubernetes.io/instance":   naming.Truncate("%s.%s", 63, params.OtelCol.Namespace, params.OtelCol.Name),
+					"app.kubernetes.io/component":  "opentelemetry-targetallocator",
+				}},
+			}},
+			Ports: []networkingv1.NetworkPolicyPort{networkPolicyPort(corev1.ProtocolTCP, intstr.FromInt(targetAllocatorPort))},
+		})
+	}
+
+	cfg, err := adapters.ConfigFromString(params.OtelCol.Spec.Config)
+	if err != nil {
+		return nil, err
+	}
+	if watchesAPIServer(cfg) {
+		var ports []networkingv1.NetworkPolicyPort
+		for _, port := range apiServerPorts {
+			ports = append(ports, networkPolicyPort(corev1.ProtocolTCP, intstr.FromInt(port)))
+		}
+		rules = append(rules, networkingv1.NetworkPolicyEgressRule{Ports: ports})
+	}
+
+	targetPorts, dynamic := adapters.ConfigToReceiverTargetPorts(params.Log, cfg)
+	if dynamic || (params.OtelCol.Spec.TargetAllocator.Enabled && hasPrometheusReceiver(cfg)) {
+		params.Log.V(2).Info("the receivers discover their targets at runtime, allowing all the egress traffic")
+		return []networkingv1.NetworkPolicyEgressRule{{}}, nil
+	}
+	if len(targetPorts) > 0 {
+		var ports []networkingv1.NetworkPolicyPort
+		for _, port := range targetPorts {
+			ports = append(ports, networkPolicyPort(corev1.ProtocolTCP, intstr.FromInt(int(port))))
+		}
+		rules = append(rules, networkingv1.NetworkPolicyEgressRule{Ports: ports})
+	}
+
+	exporterPorts, err := adapters.ConfigToExporterEndpointPorts(params.Log, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(exporterPorts) > 0 {
+		var ports []networkingv1.NetworkPolicyPort
+		for _, port := range exporterPorts {
+			ports = append(ports, networkPolicyPort(corev1.ProtocolTCP, intstr.FromInt(int(port))))
+		}
+		rules = append(rules, networkingv1.NetworkPolicyEgressRule{Ports: ports})
+	}
+	return rules, nil
+}
+
+// watchesAPIServer returns whether a component of the given configuration connects to the Kubernetes API server.
+func watchesAPIServer(cfg map[interface{}]interface{}) bool {
+	for cType, components := range apiServerComponents {
+		for name, settings := range adapters.ConfigToComponents(cType, cfg) {
+			if watches, ok := components[adapters.ComponentTypeOf(name)]; ok && watches(settings) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasPrometheusReceiver returns whether the given configuration has a prometheus receiver, whose targets are
+// assigned by the target allocator when it's enabled.
+func hasPrometheusReceiver(cfg map[interface{}]interface{}) bool {
+	for name := range adapters.ConfigToComponents(adapters.ComponentTypeReceiver, cfg) {
+		if adapters.ComponentTypeOf(name) == "prometheus" {
+			return true
+		}
+	}
+	return false
+}
+
+func networkPolicyPort(protocol corev1.Protocol, port intstr.IntOrString) networkingv1.NetworkPolicyPort {
+	if protocol == "" {
+		protocol = corev1.ProtocolTCP
+	}
+	return networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &port}
+}
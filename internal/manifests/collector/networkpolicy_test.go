@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	. "github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+)
+
+const networkPolicyConfig = `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  otlp:
+    endpoint: backend.observability:4317
+  otlphttp:
+    endpoint: https://otlp.example.com
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp, otlphttp]
+`
+
+func policyPorts(ports []networkingv1.NetworkPolicyPort) []string {
+	var result []string
+	for _, p := range ports {
+		result = append(result, string(*p.Protocol)+"/"+p.Port.String())
+	}
+	return result
+}
+
+func TestNetworkPolicy(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-instance", Namespace: "my-ns"},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:   v1alpha1.ModeStatefulSet,
+			Config: networkPolicyConfig,
+			Ports: []corev1.ServicePort{
+				{Name: "custom", Port: 9090, TargetPort: intstr.FromInt(9091), Protocol: corev1.ProtocolUDP},
+			},
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{Enabled: true},
+		},
+	}
+	params := manifests.Params{Config: config.New(), OtelCol: otelcol, Log: logger}
+
+	t.Run("disabled", func(t *testing.T) {
+		np, err := NetworkPolicy(params)
+		require.NoError(t, err)
+		assert.Nil(t, np)
+	})
+
+	t.Run("ingress only", func(t *testing.T) {
+		params.OtelCol.Spec.NetworkPolicy = v1alpha1.CollectorNetworkPolicySpec{Enabled: true}
+		np, err := NetworkPolicy(params)
+		require.NoError(t, err)
+		require.NotNil(t, np)
+
+		assert.Equal(t, "my-instance-collector", np.Name)
+		assert.Equal(t, "my-ns.my-instance", np.Spec.PodSelector.MatchLabels["app.kubernetes.io/instance"])
+		assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, np.Spec.PolicyTypes)
+		require.Len(t, np.Spec.Ingress, 1)
+		assert.Empty(t, np.Spec.Ingress[0].From)
+		assert.Equal(t, []string{"TCP/8888", "TCP/4317", "UDP/9091"}, policyPorts(np.Spec.Ingress[0].Ports))
+		assert.Empty(t, np.Spec.Egress)
+	})
+
+	t.Run("restrict egress", func(t *testing.T) {
+		params.OtelCol.Spec.NetworkPolicy = v1alpha1.CollectorNetworkPolicySpec{Enabled: true, RestrictEgress: true}
+		np, err := NetworkPolicy(params)
+		require.NoError(t, err)
+		require.NotNil(t, np)
+
+		assert.Contains(t, np.Spec.PolicyTypes, networkingv1.PolicyTypeEgress)
+		require.Len(t, np.Spec.Egress, 3)
+		assert.Equal(t, []string{"UDP/53", "TCP/53"}, policyPorts(np.Spec.Egress[0].Ports))
+		assert.Equal(t, "opentelemetry-targetallocator", np.Spec.Egress[1].To[0].PodSelector.MatchLabels["app.kubernetes.io/component"])
+		assert.Equal(t, []string{"TCP/8080"}, policyPorts(np.Spec.Egress[1].Ports))
+		assert.Equal(t, []string{"TCP/443", "TCP/4317"}, policyPorts(np.Spec.Egress[2].Ports))
+	})
+
+	t.Run("restrict egress with the API server", func(t *testing.T) {
+		params.OtelCol.Spec.NetworkPolicy = v1alpha1.CollectorNetworkPolicySpec{Enabled: true, RestrictEgress: true}
+		params.OtelCol.Spec.TargetAllocator.Enabled = false
+		params.OtelCol.Spec.Config = `receivers:
+  k8s_events:
+processors:
+  k8sattributes/passthrough:
+    passthrough: true
+exporters:
+  otlp:
+    endpoint: backend.observability:4317
+service:
+  pipelines:
+    logs:
+      receivers: [k8s_events]
+      processors: [k8sattributes/passthrough]
+      exporters: [otlp]
+`
+		np, err := NetworkPolicy(params)
+		require.NoError(t, err)
+		require.NotNil(t, np)
+
+		require.Len(t, np.Spec.Egress, 3)
+		assert.Equal(t, []string{"UDP/53", "TCP/53"}, policyPorts(np.Spec.Egress[0].Ports))
+		assert.Equal(t, []string{"TCP/443", "TCP/6443"}, policyPorts(np.Spec.Egress[1].Ports))
+		assert.Empty(t, np.Spec.Egress[1].To)
+		assert.Equal(t, []string{"TCP/4317"}, policyPorts(np.Spec.Egress[2].Ports))
+
+		// a k8sattributes processor in passthrough mode doesn't connect to the API server
+		params.OtelCol.Spec.Config = `receivers:
+  otlp:
+    protocols:
+      grpc:
+processors:
+  k8sattributes:
+    passthrough: true
+exporters:
+  otlp:
+    endpoint: backend.observability:4317
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [k8sattributes]
+      exporters: [otlp]
+`
+		np, err = NetworkPolicy(params)
+		require.NoError(t, err)
+		require.Len(t, np.Spec.Egress, 2)
+		assert.Equal(t, []string{"TCP/4317"}, policyPorts(np.Spec.Egress[1].Ports))
+	})
+
+	t.Run("restrict egress with the receiver targets", func(t *testing.T) {
+		params.OtelCol.Spec.NetworkPolicy = v1alpha1.CollectorNetworkPolicySpec{Enabled: true, RestrictEgress: true}
+		params.OtelCol.Spec.TargetAllocator.Enabled = false
+		params.OtelCol.Spec.Config = `receivers:
+  kubeletstats:
+    auth_type: serviceAccount
+    endpoint: ${env:K8S_NODE_NAME}:10250
+  prometheus:
+    config:
+      scrape_configs:
+        - job_name: static
+          static_configs:
+            - targets: ["app.my-ns:9100"]
+exporters:
+  otlp:
+    endpoint: backend.observability:4317
+service:
+  pipelines:
+    metrics:
+      receivers: [kubeletstats, prometheus]
+      exporters: [otlp]
+`
+		np, err := NetworkPolicy(params)
+		require.NoError(t, err)
+		require.NotNil(t, np)
+
+		require.Len(t, np.Spec.Egress, 3)
+		assert.Equal(t, []string{"TCP/9100", "TCP/10250"}, policyPorts(np.Spec.Egress[1].Ports))
+		assert.Equal(t, []string{"TCP/4317"}, policyPorts(np.Spec.Egress[2].Ports))
+
+		// the targets assigned by the target allocator can listen on any port
+		params.OtelCol.Spec.TargetAllocator.Enabled = true
+		np, err = NetworkPolicy(params)
+		require.NoError(t, err)
+		assert.Equal(t, []networkingv1.NetworkPolicyEgressRule{{}}, np.Spec.Egress)
+		params.OtelCol.Spec.TargetAllocator.Enabled = false
+	})
+
+	t.Run("sidecar", func(t *testing.T) {
+		params.OtelCol.Spec.Mode = v1alpha1.ModeSidecar
+		np, err := NetworkPolicy(params)
+		require.NoError(t, err)
+		assert.Nil(t, np)
+	})
+}
@@ -0,0 +1,299 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"path"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+const (
+	// persistenceMountPath is the directory of the collector's container storing the sending queues.
+	persistenceMountPath = "/var/lib/otelcol/storage"
+	// persistenceExtension is the name of the storage extension added to the configuration.
+	persistenceExtension = "file_storage/persistence"
+	// persistenceHostPathPrefix is the directory of the node under which the queues are stored in DaemonSet mode.
+	persistenceHostPathPrefix = "/var/lib/otelcol"
+	// persistenceUser is the user and group the collector's image runs as.
+	persistenceUser int64 = 10001
+)
+
+// defaultPersistenceSize is the size of the persistent volume claims when none is configured.
+var defaultPersistenceSize = resource.MustParse("1Gi")
+
+// queuedExporters are the exporters having a sending queue that can be stored by a storage extension.
+var queuedExporters = map[string]bool{
+	"elasticsearch": true,
+	"kafka":         true,
+	"loki":          true,
+	"otlp":          true,
+	"otlphttp":      true,
+	"sapm":          true,
+	"signalfx":      true,
+	"splunk_hec":    true,
+	"zipkin":        true,
+}
+
+// isPersistent returns whether the collector stores its sending queues on disk.
+func isPersistent(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.Persistence.Enabled && otelcol.Spec.Mode != v1alpha1.ModeSidecar
+}
+
+// persistenceHostPath returns the directory of the node storing the queues of a DaemonSet collector.
+func persistenceHostPath(otelcol v1alpha1.OpenTelemetryCollector) string {
+	if otelcol.Spec.Persistence.HostPath != "" {
+		return otelcol.Spec.Persistence.HostPath
+	}
+	return path.Join(persistenceHostPathPrefix, otelcol.Namespace, otelcol.Name)
+}
+
+// persistenceVolumes returns the volume storing the sending queues, unless it's a claim of the StatefulSet.
+func persistenceVolumes(otelcol v1alpha1.OpenTelemetryCollector) []corev1.Volume {
+	if !isPersistent(otelcol) {
+		return nil
+	}
+
+	switch otelcol.Spec.Mode { // nolint:exhaustive
+	case v1alpha1.ModeDaemonSet:
+		hostPathType := corev1.HostPathDirectoryOrCreate
+		return []corev1.Volume{{
+			Name: naming.PersistenceVolume(),
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: persistenceHostPath(otelcol),
+					Type: &hostPathType,
+				},
+			},
+		}}
+	case v1alpha1.ModeStatefulSet:
+		return nil
+	default:
+		return []corev1.Volume{{
+			Name: naming.PersistenceVolume(),
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		}}
+	}
+}
+
+// persistenceVolumeClaimTemplates returns the claim storing the sending queues of each replica of a StatefulSet.
+func persistenceVolumeClaimTemplates(otelcol v1alpha1.OpenTelemetryCollector) []corev1.PersistentVolumeClaim {
+	if !isPersistent(otelcol) || otelcol.Spec.Mode != v1alpha1.ModeStatefulSet {
+		return nil
+	}
+
+	size := defaultPersistenceSize
+	if otelcol.Spec.Persistence.Size != nil {
+		size = *otelcol.Spec.Persistence.Size
+	}
+	return []corev1.PersistentVolumeClaim{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: naming.PersistenceVolume(),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: otelcol.Spec.Persistence.StorageClassName,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+			},
+		},
+	}}
+}
+
+// podSecurityContext returns the security context of the collector's pods, owning the volumes storing the sending queues
+// through their fsGroup unless it's set. Their ownership is only changed when the root of the volume doesn't match.
+func podSecurityContext(otelcol v1alpha1.OpenTelemetryCollector) *corev1.PodSecurityContext {
+	if !isPersistent(otelcol) {
+		return otelcol.Spec.PodSecurityContext
+	}
+	securityContext := &corev1.PodSecurityContext{}
+	if otelcol.Spec.PodSecurityContext != nil {
+		securityContext = otelcol.Spec.PodSecurityContext.DeepCopy()
+	}
+	if securityContext.FSGroup == nil {
+		fsGroup := persistenceUser
+		securityContext.FSGroup = &fsGroup
+	}
+	if securityContext.FSGroupChangePolicy == nil {
+		policy := corev1.FSGroupChangeOnRootMismatch
+		securityContext.FSGroupChangePolicy = &policy
+	}
+	return securityContext
+}
+
+// daemonSetUpdateStrategy returns the update strategy of the DaemonSet. A node never runs two pods of a collector
+// storing its sending queues in a host path at the same time, as they would share the storage directory.
+func daemonSetUpdateStrategy(otelcol v1alpha1.OpenTelemetryCollector) appsv1.DaemonSetUpdateStrategy {
+	strategy := otelcol.Spec.UpdateStrategy
+	if !isPersistent(otelcol) || strategy.RollingUpdate == nil || strategy.RollingUpdate.MaxSurge == nil {
+		return strategy
+	}
+	rollingUpdate := strategy.RollingUpdate.DeepCopy()
+	noSurge := intstr.FromInt(0)
+	rollingUpdate.MaxSurge = &noSurge
+	strategy.RollingUpdate = rollingUpdate
+	return strategy
+}
+
+// persistenceInitContainers returns the init container giving the collector the ownership of the host path storing
+// the sending queues in DaemonSet mode, which unlike the other volumes isn't owned by the fsGroup of the pod. It only
+// holds the capabilities needed to change the ownership, and only changes it when the root of the path doesn't match.
+func persistenceInitContainers(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) []corev1.Container {
+	if !isPersistent(otelcol) || otelcol.Spec.Mode != v1alpha1.ModeDaemonSet {
+		return nil
+	}
+
+	user := persistenceUser
+	if otelcol.Spec.PodSecurityContext != nil && otelcol.Spec.PodSecurityContext.RunAsUser != nil {
+		user = *otelcol.Spec.PodSecurityContext.RunAsUser
+	}
+	if otelcol.Spec.SecurityContext != nil && otelcol.Spec.SecurityContext.RunAsUser != nil {
+		user = *otelcol.Spec.SecurityContext.RunAsUser
+	}
+	if user == 0 {
+		// the collector runs as root, and already owns the host path
+		return nil
+	}
+	owner := fmt.Sprintf("%d:%d", user, *podSecurityContext(otelcol).FSGroup)
+
+	root := int64(0)
+	runAsNonRoot := false
+	allowPrivilegeEscalation := false
+	return []corev1.Container{{
+		Name:  naming.PersistenceInitContainer(),
+		Image: cfg.PersistenceInitImage(),
+		Command: []string{"sh", "-c", fmt.Sprintf(`[ "$(stat -c %%u:%%g %[1]s)" = "%[2]s" ] || chown -R %[2]s %[1]s`,
+			persistenceMountPath, owner)},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      naming.PersistenceVolume(),
+			MountPath: persistenceMountPath,
+		}},
+		SecurityContext: &corev1.SecurityContext{
+			RunAsUser:                &root,
+			RunAsNonRoot:             &runAsNonRoot,
+			AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+				Add:  []corev1.Capability{"CHOWN", "DAC_READ_SEARCH"},
+			},
+			SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+		},
+	}}
+}
+
+// persistenceVolumeMounts returns the mount of the volume storing the sending queues.
+func persistenceVolumeMounts(otelcol v1alpha1.OpenTelemetryCollector) []corev1.VolumeMount {
+	if !isPersistent(otelcol) {
+		return nil
+	}
+	return []corev1.VolumeMount{{
+		Name:      naming.PersistenceVolume(),
+		MountPath: persistenceMountPath,
+	}}
+}
+
+// addPersistenceToConfig adds the file storage extension to the configuration, and stores the sending queues
+// of the exporters in it unless they're already stored elsewhere.
+func addPersistenceToConfig(config map[interface{}]interface{}) error {
+	extensions, err := configSection(config, "extensions")
+	if err != nil {
+		return err
+	}
+	if _, ok := extensions[persistenceExtension]; !ok {
+		extensions[persistenceExtension] = map[interface{}]interface{}{
+			"directory": persistenceMountPath,
+		}
+	}
+
+	service, err := configSection(config, "service")
+	if err != nil {
+		return err
+	}
+	var enabled []interface{}
+	if service["extensions"] != nil {
+		var ok bool
+		if enabled, ok = service["extensions"].([]interface{}); !ok {
+			return fmt.Errorf("service.extensions should be a list, got %T", service["extensions"])
+		}
+	}
+	found := false
+	for _, extension := range enabled {
+		if extension == persistenceExtension {
+			found = true
+		}
+	}
+	if !found {
+		service["extensions"] = append(enabled, persistenceExtension)
+	}
+
+	exporters, ok := config["exporters"].(map[interface{}]interface{})
+	if !ok {
+		return nil
+	}
+	for name, exporter := range exporters {
+		if !queuedExporters[adapters.ComponentTypeOf(fmt.Sprint(name))] {
+			continue
+		}
+		settings, ok := exporter.(map[interface{}]interface{})
+		if !ok {
+			if exporter != nil {
+				continue
+			}
+			settings = map[interface{}]interface{}{}
+			exporters[name] = settings
+		}
+		queue, ok := settings["sending_queue"].(map[interface{}]interface{})
+		if !ok {
+			if settings["sending_queue"] != nil {
+				continue
+			}
+			queue = map[interface{}]interface{}{}
+			settings["sending_queue"] = queue
+		}
+		if enabled, ok := queue["enabled"].(bool); ok && !enabled {
+			continue
+		}
+		if _, ok := queue["storage"]; !ok {
+			queue["storage"] = persistenceExtension
+		}
+	}
+	return nil
+}
+
+// configSection returns the given top-level section of the configuration, creating it when it's missing.
+func configSection(config map[interface{}]interface{}, name string) (map[interface{}]interface{}, error) {
+	if config[name] == nil {
+		section := map[interface{}]interface{}{}
+		config[name] = section
+		return section, nil
+	}
+	section, ok := config[name].(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s should be a map, got %T", name, config[name])
+	}
+	return section, nil
+}
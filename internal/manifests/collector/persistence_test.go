@@ -0,0 +1,259 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	. "github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+)
+
+const persistenceConfig = `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  otlp:
+    endpoint: backend:4317
+  otlphttp/custom:
+    endpoint: http://backend:4318
+    sending_queue:
+      storage: file_storage/custom
+  otlphttp/disabled:
+    endpoint: http://backend:4318
+    sending_queue:
+      enabled: false
+  debug:
+extensions:
+  health_check:
+service:
+  extensions: [health_check]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp, otlphttp/custom, otlphttp/disabled, debug]
+`
+
+func persistenceParams(mode v1alpha1.Mode, persistence v1alpha1.PersistenceSpec) manifests.Params {
+	return manifests.Params{
+		Config: config.New(),
+		OtelCol: v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-instance",
+				Namespace: "my-namespace",
+			},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Mode:        mode,
+				Config:      persistenceConfig,
+				Persistence: persistence,
+			},
+		},
+		Log: logger,
+	}
+}
+
+func TestPersistenceConfig(t *testing.T) {
+	params := persistenceParams(v1alpha1.ModeStatefulSet, v1alpha1.PersistenceSpec{Enabled: true})
+
+	replaced, err := ReplaceConfig(params.OtelCol)
+	require.NoError(t, err)
+
+	var actual map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(replaced), &actual))
+	var expected map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(`receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  otlp:
+    endpoint: backend:4317
+    sending_queue:
+      storage: file_storage/persistence
+  otlphttp/custom:
+    endpoint: http://backend:4318
+    sending_queue:
+      storage: file_storage/custom
+  otlphttp/disabled:
+    endpoint: http://backend:4318
+    sending_queue:
+      enabled: false
+  debug:
+extensions:
+  health_check:
+  file_storage/persistence:
+    directory: /var/lib/otelcol/storage
+service:
+  extensions: [health_check, file_storage/persistence]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp, otlphttp/custom, otlphttp/disabled, debug]
+`), &expected))
+	assert.Equal(t, expected, actual)
+
+	// the configuration is left untouched when the persistence is disabled
+	params.OtelCol.Spec.Persistence.Enabled = false
+	replaced, err = ReplaceConfig(params.OtelCol)
+	require.NoError(t, err)
+	assert.Equal(t, persistenceConfig, replaced)
+}
+
+func TestPersistenceStatefulSet(t *testing.T) {
+	storageClass := "fast"
+	size := resource.MustParse("5Gi")
+	params := persistenceParams(v1alpha1.ModeStatefulSet, v1alpha1.PersistenceSpec{Enabled: true, Size: &size, StorageClassName: &storageClass})
+
+	s := StatefulSet(params)
+
+	assert.Equal(t, []corev1.PersistentVolumeClaim{{
+		ObjectMeta: metav1.ObjectMeta{Name: "otc-persistence"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: &storageClass,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+			},
+		},
+	}}, s.Spec.VolumeClaimTemplates)
+	assert.Len(t, s.Spec.Template.Spec.Volumes, 1)
+	assert.Contains(t, s.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{Name: "otc-persistence", MountPath: "/var/lib/otelcol/storage"})
+	// the claims are owned by the collector's group
+	require.NotNil(t, s.Spec.Template.Spec.SecurityContext)
+	assert.Equal(t, int64(10001), *s.Spec.Template.Spec.SecurityContext.FSGroup)
+}
+
+func TestPersistenceDaemonSet(t *testing.T) {
+	params := persistenceParams(v1alpha1.ModeDaemonSet, v1alpha1.PersistenceSpec{Enabled: true})
+	params.Config = config.New(config.WithPersistenceInitImage("some-init-image"))
+	params.OtelCol.Spec.InitContainers = []corev1.Container{{Name: "my-init"}}
+
+	d := DaemonSet(params)
+
+	hostPathType := corev1.HostPathDirectoryOrCreate
+	assert.Contains(t, d.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: "otc-persistence",
+		VolumeSource: corev1.VolumeSource{
+			HostPath: &corev1.HostPathVolumeSource{Path: "/var/lib/otelcol/my-namespace/my-instance", Type: &hostPathType},
+		},
+	})
+	assert.Contains(t, d.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{Name: "otc-persistence", MountPath: "/var/lib/otelcol/storage"})
+
+	// the host path isn't owned by the fsGroup, an init container gives its ownership to the collector
+	root := int64(0)
+	runAsNonRoot := false
+	allowPrivilegeEscalation := false
+	assert.Equal(t, []corev1.Container{{
+		Name:         "otc-persistence-init",
+		Image:        "some-init-image",
+		Command:      []string{"sh", "-c", `[ "$(stat -c %u:%g /var/lib/otelcol/storage)" = "10001:10001" ] || chown -R 10001:10001 /var/lib/otelcol/storage`},
+		VolumeMounts: []corev1.VolumeMount{{Name: "otc-persistence", MountPath: "/var/lib/otelcol/storage"}},
+		SecurityContext: &corev1.SecurityContext{
+			RunAsUser:                &root,
+			RunAsNonRoot:             &runAsNonRoot,
+			AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+				Add:  []corev1.Capability{"CHOWN", "DAC_READ_SEARCH"},
+			},
+			SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+		},
+	}, {Name: "my-init"}}, d.Spec.Template.Spec.InitContainers)
+}
+
+func TestPersistenceDaemonSetUpdateStrategy(t *testing.T) {
+	params := persistenceParams(v1alpha1.ModeDaemonSet, v1alpha1.PersistenceSpec{Enabled: true})
+	surge, unavailable := intstr.FromInt(1), intstr.FromInt(0)
+	params.OtelCol.Spec.UpdateStrategy = appsv1.DaemonSetUpdateStrategy{
+		Type:          appsv1.RollingUpdateDaemonSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateDaemonSet{MaxSurge: &surge, MaxUnavailable: &unavailable},
+	}
+
+	d := DaemonSet(params)
+
+	// the pods of a node share the storage directory, they never run at the same time
+	require.NotNil(t, d.Spec.UpdateStrategy.RollingUpdate)
+	assert.Equal(t, intstr.FromInt(0), *d.Spec.UpdateStrategy.RollingUpdate.MaxSurge)
+	assert.Equal(t, intstr.FromInt(1), *params.OtelCol.Spec.UpdateStrategy.RollingUpdate.MaxSurge)
+
+	// the pods of a node can surge without persistence
+	params.OtelCol.Spec.Persistence.Enabled = false
+	d = DaemonSet(params)
+	assert.Equal(t, intstr.FromInt(1), *d.Spec.UpdateStrategy.RollingUpdate.MaxSurge)
+}
+
+func TestPersistenceDaemonSetUser(t *testing.T) {
+	params := persistenceParams(v1alpha1.ModeDaemonSet, v1alpha1.PersistenceSpec{Enabled: true})
+	user, group := int64(1000), int64(2000)
+	params.OtelCol.Spec.PodSecurityContext = &corev1.PodSecurityContext{FSGroup: &group}
+	params.OtelCol.Spec.SecurityContext = &corev1.SecurityContext{RunAsUser: &user}
+
+	d := DaemonSet(params)
+
+	require.Len(t, d.Spec.Template.Spec.InitContainers, 1)
+	assert.Contains(t, d.Spec.Template.Spec.InitContainers[0].Command[2], "chown -R 1000:2000 /var/lib/otelcol/storage")
+	// the fsGroup of the user is kept
+	assert.Equal(t, int64(2000), *d.Spec.Template.Spec.SecurityContext.FSGroup)
+
+	// the collector running as root already owns the host path
+	root := int64(0)
+	params.OtelCol.Spec.SecurityContext = &corev1.SecurityContext{RunAsUser: &root}
+	d = DaemonSet(params)
+	assert.Empty(t, d.Spec.Template.Spec.InitContainers)
+}
+
+func TestPersistenceDeployment(t *testing.T) {
+	params := persistenceParams(v1alpha1.ModeDeployment, v1alpha1.PersistenceSpec{Enabled: true})
+
+	d := Deployment(params)
+
+	assert.Contains(t, d.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name:         "otc-persistence",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	})
+	assert.Contains(t, d.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{Name: "otc-persistence", MountPath: "/var/lib/otelcol/storage"})
+	assert.Empty(t, d.Spec.Template.Spec.InitContainers)
+}
+
+func TestPersistenceSecurityContext(t *testing.T) {
+	params := persistenceParams(v1alpha1.ModeDeployment, v1alpha1.PersistenceSpec{Enabled: true})
+	user := int64(1000)
+	params.OtelCol.Spec.PodSecurityContext = &corev1.PodSecurityContext{RunAsUser: &user}
+
+	d := Deployment(params)
+
+	// the emptyDir volume is owned by the collector's group, the rest of the user's security context is kept
+	fsGroup := int64(10001)
+	onRootMismatch := corev1.FSGroupChangeOnRootMismatch
+	assert.Equal(t, &corev1.PodSecurityContext{RunAsUser: &user, FSGroup: &fsGroup, FSGroupChangePolicy: &onRootMismatch}, d.Spec.Template.Spec.SecurityContext)
+	assert.Nil(t, params.OtelCol.Spec.PodSecurityContext.FSGroup)
+
+	// the security context is left untouched when the persistence is disabled
+	params.OtelCol.Spec.Persistence.Enabled = false
+	d = Deployment(params)
+	assert.Equal(t, &corev1.PodSecurityContext{RunAsUser: &user}, d.Spec.Template.Spec.SecurityContext)
+}
@@ -48,7 +48,8 @@ func PodDisruptionBudget(params manifests.Params) client.Object {
 			MinAvailable:   params.OtelCol.Spec.PodDisruptionBudget.MinAvailable,
 			MaxUnavailable: params.OtelCol.Spec.PodDisruptionBudget.MaxUnavailable,
 			Selector: &metav1.LabelSelector{
-				MatchLabels: objectMeta.Labels,
+				MatchLabels:      objectMeta.Labels,
+				MatchExpressions: excludeCanaryPods(params.OtelCol),
 			},
 		},
 	}
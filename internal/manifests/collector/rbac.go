@@ -0,0 +1,268 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/strings/slices"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+var readVerbs = []string{"get", "list", "watch"}
+
+// rulesFunc returns the rules needed by a component with the given settings, in a collector of the given namespace:
+// the ones needed cluster-wide, and the ones only needed in the collector's namespace.
+type rulesFunc func(settings map[interface{}]interface{}, namespace string) (clusterRules, namespaceRules []rbacv1.PolicyRule)
+
+// sensitiveResources are the resources, by API group, that the generated roles never grant: the operator would
+// otherwise hand them over to anyone allowed to edit a collector's configuration.
+var sensitiveResources = map[string][]string{
+	"": {"secrets"},
+}
+
+// receiverRules are the rules needed by the receivers watching the cluster, by receiver type.
+var receiverRules = map[string]rulesFunc{
+	"k8s_cluster": func(map[interface{}]interface{}, string) ([]rbacv1.PolicyRule, []rbacv1.PolicyRule) {
+		return []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{
+					"events", "namespaces", "namespaces/status", "nodes", "nodes/spec", "pods", "pods/status",
+					"replicationcontrollers", "replicationcontrollers/status", "resourcequotas", "services",
+				},
+				Verbs: readVerbs,
+			},
+			{APIGroups: []string{"apps"}, Resources: []string{"daemonsets", "deployments", "replicasets", "statefulsets"}, Verbs: readVerbs},
+			{APIGroups: []string{"extensions"}, Resources: []string{"daemonsets", "deployments", "replicasets"}, Verbs: readVerbs},
+			{APIGroups: []string{"batch"}, Resources: []string{"jobs", "cronjobs"}, Verbs: readVerbs},
+			{APIGroups: []string{"autoscaling"}, Resources: []string{"horizontalpodautoscalers"}, Verbs: readVerbs},
+		}, nil
+	},
+	"k8s_events": func(map[interface{}]interface{}, string) ([]rbacv1.PolicyRule, []rbacv1.PolicyRule) {
+		return []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"events"}, Verbs: readVerbs}}, nil
+	},
+	"k8sobjects": func(settings map[interface{}]interface{}, namespace string) ([]rbacv1.PolicyRule, []rbacv1.PolicyRule) {
+		objects, _ := settings["objects"].([]interface{})
+		var clusterRules, namespaceRules []rbacv1.PolicyRule
+		for _, obj := range objects {
+			object, ok := obj.(map[interface{}]interface{})
+			if !ok {
+				continue
+			}
+			resource, ok := object["name"].(string)
+			if !ok || resource == "" {
+				continue
+			}
+			group, _ := object["group"].(string)
+			rule := rbacv1.PolicyRule{APIGroups: []string{group}, Resources: []string{resource}, Verbs: readVerbs}
+			// the objects of the collector's namespace only need a role, the other ones a cluster role
+			if namespaces, _ := object["namespaces"].([]interface{}); len(namespaces) == 1 && namespaces[0] == namespace {
+				namespaceRules = append(namespaceRules, rule)
+			} else {
+				clusterRules = append(clusterRules, rule)
+			}
+		}
+		return clusterRules, namespaceRules
+	},
+	"kubeletstats": func(settings map[interface{}]interface{}, _ string) ([]rbacv1.PolicyRule, []rbacv1.PolicyRule) {
+		rules := []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"nodes/stats"}, Verbs: []string{"get"}}}
+		if labels, _ := settings["extra_metadata_labels"].([]interface{}); len(labels) > 0 {
+			rules = append(rules, rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"nodes/proxy"}, Verbs: []string{"get"}})
+		}
+		return rules, nil
+	},
+}
+
+// processorRules are the rules needed by the processors watching the cluster, by processor type.
+var processorRules = map[string]rulesFunc{
+	"k8sattributes": func(settings map[interface{}]interface{}, _ string) ([]rbacv1.PolicyRule, []rbacv1.PolicyRule) {
+		if passthrough, _ := settings["passthrough"].(bool); passthrough {
+			return nil, nil
+		}
+		return []rbacv1.PolicyRule{
+			{APIGroups: []string{""}, Resources: []string{"pods", "namespaces"}, Verbs: readVerbs},
+			{APIGroups: []string{"apps"}, Resources: []string{"replicasets"}, Verbs: readVerbs},
+		}, nil
+	},
+}
+
+// ClusterRole builds the cluster role granting the permissions needed cluster-wide by the collector's configuration.
+func ClusterRole(params manifests.Params) *rbacv1.ClusterRole {
+	if !params.OtelCol.Spec.RBAC.Enabled || params.OtelCol.Spec.Mode == v1alpha1.ModeSidecar {
+		return nil
+	}
+	rules, _ := rbacRules(params.Log, params.OtelCol)
+	if len(rules) == 0 {
+		return nil
+	}
+
+	name := naming.ClusterRole(params.OtelCol.Name, params.OtelCol.Namespace)
+	return &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter()),
+			Annotations: params.OtelCol.Annotations,
+		},
+		Rules: rules,
+	}
+}
+
+// ClusterRoleBinding builds the binding of the collector's cluster role to its service account.
+func ClusterRoleBinding(params manifests.Params) *rbacv1.ClusterRoleBinding {
+	if ClusterRole(params) == nil {
+		return nil
+	}
+
+	name := naming.ClusterRoleBinding(params.OtelCol.Name, params.OtelCol.Namespace)
+	return &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter()),
+			Annotations: params.OtelCol.Annotations,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     naming.ClusterRole(params.OtelCol.Name, params.OtelCol.Namespace),
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      ServiceAccountName(params.OtelCol),
+			Namespace: params.OtelCol.Namespace,
+		}},
+	}
+}
+
+// Role builds the role granting the permissions needed by the collector's configuration in its namespace only.
+func Role(params manifests.Params) *rbacv1.Role {
+	if !params.OtelCol.Spec.RBAC.Enabled || params.OtelCol.Spec.Mode == v1alpha1.ModeSidecar {
+		return nil
+	}
+	_, rules := rbacRules(params.Log, params.OtelCol)
+	if len(rules) == 0 {
+		return nil
+	}
+
+	name := naming.Role(params.OtelCol.Name)
+	return &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   params.OtelCol.Namespace,
+			Labels:      manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter()),
+			Annotations: params.OtelCol.Annotations,
+		},
+		Rules: rules,
+	}
+}
+
+// RoleBinding builds the binding of the collector's role to its service account.
+func RoleBinding(params manifests.Params) *rbacv1.RoleBinding {
+	if Role(params) == nil {
+		return nil
+	}
+
+	name := naming.RoleBinding(params.OtelCol.Name)
+	return &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   params.OtelCol.Namespace,
+			Labels:      manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter()),
+			Annotations: params.OtelCol.Annotations,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     naming.Role(params.OtelCol.Name),
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      ServiceAccountName(params.OtelCol),
+			Namespace: params.OtelCol.Namespace,
+		}},
+	}
+}
+
+// rbacRules returns the rules needed by the receivers and processors of the collector's configuration, cluster-wide
+// and in the collector's namespace only. The rules granting sensitive resources are left out.
+func rbacRules(logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) (clusterRules, namespaceRules []rbacv1.PolicyRule) {
+	c, err := adapters.ConfigFromString(otelcol.Spec.Config)
+	if err != nil {
+		logger.Error(err, "couldn't extract the configuration")
+		return nil, nil
+	}
+
+	for cType, rulesFor := range map[adapters.ComponentType]map[string]rulesFunc{
+		adapters.ComponentTypeReceiver:  receiverRules,
+		adapters.ComponentTypeProcessor: processorRules,
+	} {
+		for name, settings := range adapters.ConfigToComponents(cType, c) {
+			ruleFor, ok := rulesFor[adapters.ComponentTypeOf(name)]
+			if !ok {
+				continue
+			}
+			componentClusterRules, componentNamespaceRules := ruleFor(settings, otelcol.Namespace)
+			for _, rule := range append(componentClusterRules, componentNamespaceRules...) {
+				if sensitive(rule) {
+					logger.V(1).Info("the collector's roles don't grant sensitive resources, the permission has to be granted separately", "component", name, "groups", rule.APIGroups, "resources", rule.Resources)
+				}
+			}
+			clusterRules = append(clusterRules, componentClusterRules...)
+			namespaceRules = append(namespaceRules, componentNamespaceRules...)
+		}
+	}
+	return uniqueRules(clusterRules), uniqueRules(namespaceRules)
+}
+
+// uniqueRules removes the duplicated and the sensitive rules, and sorts the other ones.
+func uniqueRules(rules []rbacv1.PolicyRule) []rbacv1.PolicyRule {
+	var unique []rbacv1.PolicyRule
+	found := map[string]bool{}
+	for _, rule := range rules {
+		// the same rule can be needed by several components
+		key := fmt.Sprint(rule.APIGroups, rule.Resources, rule.Verbs)
+		if !found[key] && !sensitive(rule) {
+			found[key] = true
+			unique = append(unique, rule)
+		}
+	}
+
+	// the components are iterated over in no particular order
+	sort.Slice(unique, func(i, j int) bool {
+		return fmt.Sprint(unique[i].APIGroups, unique[i].Resources, unique[i].Verbs) < fmt.Sprint(unique[j].APIGroups, unique[j].Resources, unique[j].Verbs)
+	})
+	return unique
+}
+
+// sensitive returns whether the given rule grants a sensitive resource, or any resource with a wildcard.
+func sensitive(rule rbacv1.PolicyRule) bool {
+	for _, group := range rule.APIGroups {
+		for _, resource := range rule.Resources {
+			if group == rbacv1.APIGroupAll || resource == rbacv1.ResourceAll || slices.Contains(sensitiveResources[group], resource) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	. "github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+)
+
+const rbacConfig = `receivers:
+  kubeletstats:
+    extra_metadata_labels: [container.id]
+  k8sobjects:
+    objects:
+      - name: pods
+      - name: events
+        group: events.k8s.io
+        mode: watch
+      - name: configmaps
+        namespaces: [my-namespace]
+      - name: secrets
+  otlp:
+    protocols:
+      grpc:
+processors:
+  k8sattributes:
+  k8sattributes/passthrough:
+    passthrough: true
+exporters:
+  debug:
+service:
+  pipelines:
+    metrics:
+      receivers: [kubeletstats, k8sobjects]
+      processors: [k8sattributes]
+      exporters: [debug]
+    traces:
+      receivers: [otlp]
+      processors: [k8sattributes/passthrough]
+      exporters: [debug]
+`
+
+func TestClusterRole(t *testing.T) {
+	params := manifests.Params{
+		Config: config.New(),
+		OtelCol: v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-instance", Namespace: "my-namespace"},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Mode:   v1alpha1.ModeDeployment,
+				Config: rbacConfig,
+				RBAC:   v1alpha1.CollectorRBACSpec{Enabled: true},
+			},
+		},
+		Log: logger,
+	}
+
+	cr := ClusterRole(params)
+	require.NotNil(t, cr)
+	assert.Equal(t, "my-instance-my-namespace-collector", cr.Name)
+	readVerbs := []string{"get", "list", "watch"}
+	assert.Equal(t, []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"nodes/proxy"}, Verbs: []string{"get"}},
+		{APIGroups: []string{""}, Resources: []string{"nodes/stats"}, Verbs: []string{"get"}},
+		{APIGroups: []string{""}, Resources: []string{"pods", "namespaces"}, Verbs: readVerbs},
+		{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: readVerbs},
+		{APIGroups: []string{"apps"}, Resources: []string{"replicasets"}, Verbs: readVerbs},
+		{APIGroups: []string{"events.k8s.io"}, Resources: []string{"events"}, Verbs: readVerbs},
+	}, cr.Rules)
+
+	crb := ClusterRoleBinding(params)
+	require.NotNil(t, crb)
+	assert.Equal(t, rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: cr.Name}, crb.RoleRef)
+	assert.Equal(t, []rbacv1.Subject{{Kind: "ServiceAccount", Name: "my-instance-collector", Namespace: "my-namespace"}}, crb.Subjects)
+}
+
+func TestRole(t *testing.T) {
+	params := manifests.Params{
+		Config: config.New(),
+		OtelCol: v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-instance", Namespace: "my-namespace"},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Mode:   v1alpha1.ModeDeployment,
+				Config: rbacConfig,
+				RBAC:   v1alpha1.CollectorRBACSpec{Enabled: true},
+			},
+		},
+		Log: logger,
+	}
+
+	role := Role(params)
+	require.NotNil(t, role)
+	assert.Equal(t, "my-instance-collector", role.Name)
+	assert.Equal(t, "my-namespace", role.Namespace)
+	assert.Equal(t, []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get", "list", "watch"}},
+	}, role.Rules)
+
+	rb := RoleBinding(params)
+	require.NotNil(t, rb)
+	assert.Equal(t, "my-namespace", rb.Namespace)
+	assert.Equal(t, rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "Role", Name: role.Name}, rb.RoleRef)
+	assert.Equal(t, []rbacv1.Subject{{Kind: "ServiceAccount", Name: "my-instance-collector", Namespace: "my-namespace"}}, rb.Subjects)
+}
+
+func TestRoleOnly(t *testing.T) {
+	params := manifests.Params{
+		Config: config.New(),
+		OtelCol: v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-instance", Namespace: "my-namespace"},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Mode: v1alpha1.ModeDeployment,
+				Config: `receivers:
+  k8sobjects:
+    objects:
+      - name: pods
+        namespaces: [my-namespace]
+      - name: secrets
+        namespaces: [my-namespace]
+      - name: "*"
+exporters:
+  debug:
+service:
+  pipelines:
+    logs:
+      receivers: [k8sobjects]
+      exporters: [debug]
+`,
+				RBAC: v1alpha1.CollectorRBACSpec{Enabled: true},
+			},
+		},
+		Log: logger,
+	}
+
+	assert.Nil(t, ClusterRole(params))
+	assert.Nil(t, ClusterRoleBinding(params))
+	role := Role(params)
+	require.NotNil(t, role)
+	assert.Equal(t, []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "list", "watch"}},
+	}, role.Rules)
+}
+
+func TestClusterRoleNotGenerated(t *testing.T) {
+	for _, tt := range []struct {
+		desc string
+		spec v1alpha1.OpenTelemetryCollectorSpec
+	}{
+		{
+			desc: "disabled",
+			spec: v1alpha1.OpenTelemetryCollectorSpec{Mode: v1alpha1.ModeDeployment, Config: rbacConfig},
+		},
+		{
+			desc: "sidecar",
+			spec: v1alpha1.OpenTelemetryCollectorSpec{Mode: v1alpha1.ModeSidecar, Config: rbacConfig, RBAC: v1alpha1.CollectorRBACSpec{Enabled: true}},
+		},
+		{
+			desc: "no permissions needed",
+			spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Mode: v1alpha1.ModeDeployment,
+				Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`,
+				RBAC: v1alpha1.CollectorRBACSpec{Enabled: true},
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			params := manifests.Params{
+				Config: config.New(),
+				OtelCol: v1alpha1.OpenTelemetryCollector{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-instance", Namespace: "my-namespace"},
+					Spec:       tt.spec,
+				},
+				Log: logger,
+			}
+
+			assert.Nil(t, ClusterRole(params))
+			assert.Nil(t, ClusterRoleBinding(params))
+			assert.Nil(t, Role(params))
+			assert.Nil(t, RoleBinding(params))
+		})
+	}
+}
@@ -50,18 +50,19 @@ func StatefulSet(params manifests.Params) *appsv1.StatefulSet {
 					Annotations: podAnnotations,
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName:        ServiceAccountName(params.OtelCol),
-					InitContainers:            params.OtelCol.Spec.InitContainers,
-					Containers:                append(params.OtelCol.Spec.AdditionalContainers, Container(params.Config, params.Log, params.OtelCol, true)),
-					Volumes:                   Volumes(params.Config, params.OtelCol),
-					DNSPolicy:                 getDNSPolicy(params.OtelCol),
-					HostNetwork:               params.OtelCol.Spec.HostNetwork,
-					Tolerations:               params.OtelCol.Spec.Tolerations,
-					NodeSelector:              params.OtelCol.Spec.NodeSelector,
-					SecurityContext:           params.OtelCol.Spec.PodSecurityContext,
-					PriorityClassName:         params.OtelCol.Spec.PriorityClassName,
-					Affinity:                  params.OtelCol.Spec.Affinity,
-					TopologySpreadConstraints: params.OtelCol.Spec.TopologySpreadConstraints,
+					ServiceAccountName:            ServiceAccountName(params.OtelCol),
+					InitContainers:                initContainers(params.Config, params.OtelCol),
+					Containers:                    append(params.OtelCol.Spec.AdditionalContainers, Container(params.Config, params.Log, params.OtelCol, true)),
+					Volumes:                       Volumes(params.Config, params.OtelCol),
+					DNSPolicy:                     getDNSPolicy(params.OtelCol),
+					HostNetwork:                   params.OtelCol.Spec.HostNetwork,
+					Tolerations:                   params.OtelCol.Spec.Tolerations,
+					NodeSelector:                  params.OtelCol.Spec.NodeSelector,
+					SecurityContext:               podSecurityContext(params.OtelCol),
+					PriorityClassName:             params.OtelCol.Spec.PriorityClassName,
+					Affinity:                      params.OtelCol.Spec.Affinity,
+					TopologySpreadConstraints:     params.OtelCol.Spec.TopologySpreadConstraints,
+					TerminationGracePeriodSeconds: terminationGracePeriodSeconds(params.OtelCol),
 				},
 			},
 			Replicas:             params.OtelCol.Spec.Replicas,
@@ -41,6 +41,9 @@ func Volumes(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) []corev
 	if len(otelcol.Spec.Volumes) > 0 {
 		volumes = append(volumes, otelcol.Spec.Volumes...)
 	}
+	volumes = append(volumes, agentVolumes(otelcol)...)
+	volumes = append(volumes, persistenceVolumes(otelcol)...)
+	volumes = append(volumes, drainVolumes(otelcol)...)
 
 	if len(otelcol.Spec.ConfigMaps) > 0 {
 		for keyCfgMap := range otelcol.Spec.ConfigMaps {
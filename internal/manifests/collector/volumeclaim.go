@@ -29,5 +29,9 @@ func VolumeClaimTemplates(otelcol v1alpha1.OpenTelemetryCollector) []corev1.Pers
 	}
 
 	// Add all user specified claims.
-	return otelcol.Spec.VolumeClaimTemplates
+	claims := otelcol.Spec.VolumeClaimTemplates
+	if persistenceClaims := persistenceVolumeClaimTemplates(otelcol); len(persistenceClaims) > 0 {
+		claims = append(append([]corev1.PersistentVolumeClaim{}, claims...), persistenceClaims...)
+	}
+	return claims
 }
@@ -31,6 +31,8 @@ import (
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
 var (
@@ -146,6 +148,11 @@ func MutateFuncFor(existing, desired client.Object) controllerutil.MutateFn {
 			wantIng := desired.(*networkingv1.Ingress)
 			mutateIngress(ing, wantIng)
 
+		case *networkingv1.NetworkPolicy:
+			np := existing.(*networkingv1.NetworkPolicy)
+			wantNp := desired.(*networkingv1.NetworkPolicy)
+			mutateNetworkPolicy(np, wantNp)
+
 		case *autoscalingv2.HorizontalPodAutoscaler:
 			existingHPA := existing.(*autoscalingv2.HorizontalPodAutoscaler)
 			desiredHPA := desired.(*autoscalingv2.HorizontalPodAutoscaler)
@@ -161,6 +168,16 @@ func MutateFuncFor(existing, desired client.Object) controllerutil.MutateFn {
 			wantRt := desired.(*routev1.Route)
 			mutateRoute(rt, wantRt)
 
+		case *gatewayv1beta1.HTTPRoute:
+			rt := existing.(*gatewayv1beta1.HTTPRoute)
+			wantRt := desired.(*gatewayv1beta1.HTTPRoute)
+			mutateHTTPRoute(rt, wantRt)
+
+		case *gatewayv1alpha2.GRPCRoute:
+			rt := existing.(*gatewayv1alpha2.GRPCRoute)
+			wantRt := desired.(*gatewayv1alpha2.GRPCRoute)
+			mutateGRPCRoute(rt, wantRt)
+
 		case *corev1.Secret:
 			pr := existing.(*corev1.Secret)
 			wantPr := desired.(*corev1.Secret)
@@ -238,12 +255,30 @@ func mutateIngress(existing, desired *networkingv1.Ingress) {
 	existing.Spec.TLS = desired.Spec.TLS
 }
 
+func mutateNetworkPolicy(existing, desired *networkingv1.NetworkPolicy) {
+	existing.Labels = desired.Labels
+	existing.Annotations = desired.Annotations
+	existing.Spec = desired.Spec
+}
+
 func mutateRoute(existing, desired *routev1.Route) {
 	existing.Annotations = desired.Annotations
 	existing.Labels = desired.Labels
 	existing.Spec = desired.Spec
 }
 
+func mutateHTTPRoute(existing, desired *gatewayv1beta1.HTTPRoute) {
+	existing.Annotations = desired.Annotations
+	existing.Labels = desired.Labels
+	existing.Spec = desired.Spec
+}
+
+func mutateGRPCRoute(existing, desired *gatewayv1alpha2.GRPCRoute) {
+	existing.Annotations = desired.Annotations
+	existing.Labels = desired.Labels
+	existing.Spec = desired.Spec
+}
+
 func mutateServiceMonitor(existing, desired *monitoringv1.ServiceMonitor) {
 	existing.Annotations = desired.Annotations
 	existing.Labels = desired.Labels
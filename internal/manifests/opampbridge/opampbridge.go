@@ -32,6 +32,7 @@ func Build(params manifests.Params) ([]client.Object, error) {
 		manifests.Factory(ConfigMap),
 		manifests.FactoryWithoutError(ServiceAccount),
 		manifests.FactoryWithoutError(Service),
+		manifests.FactoryWithoutError(NetworkPolicy),
 	}
 	for _, factory := range resourceFactories {
 		res, err := factory(params)
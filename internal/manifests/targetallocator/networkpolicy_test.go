@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+)
+
+func TestNetworkPolicy(t *testing.T) {
+	otelcol := collectorInstance()
+	params := manifests.Params{
+		OtelCol: otelcol,
+		Config:  config.New(),
+		Log:     logger,
+	}
+	assert.Nil(t, NetworkPolicy(params))
+
+	params.OtelCol.Spec.NetworkPolicy.Enabled = true
+	np := NetworkPolicy(params)
+	require.NotNil(t, np)
+
+	assert.Equal(t, "opentelemetry-targetallocator", np.Spec.PodSelector.MatchLabels["app.kubernetes.io/component"])
+	require.Len(t, np.Spec.Ingress, 2)
+	require.Len(t, np.Spec.Ingress[0].From, 1)
+	assert.Equal(t, "opentelemetry-collector", np.Spec.Ingress[0].From[0].PodSelector.MatchLabels["app.kubernetes.io/component"])
+	assert.Equal(t, "http", np.Spec.Ingress[0].Ports[0].Port.String())
+
+	// the metrics are scraped on the same port
+	require.Len(t, np.Spec.Ingress[1].From, 1)
+	assert.NotNil(t, np.Spec.Ingress[1].From[0].NamespaceSelector)
+	assert.Nil(t, np.Spec.Ingress[1].From[0].PodSelector)
+	assert.Equal(t, "http", np.Spec.Ingress[1].Ports[0].Port.String())
+}
@@ -31,6 +31,7 @@ func Build(params manifests.Params) ([]client.Object, error) {
 		manifests.Factory(Deployment),
 		manifests.FactoryWithoutError(ServiceAccount),
 		manifests.FactoryWithoutError(Service),
+		manifests.FactoryWithoutError(NetworkPolicy),
 	}
 	for _, factory := range resourceFactories {
 		res, err := factory(params)
@@ -35,6 +35,26 @@ func ConfigMapVolume() string {
 	return "otc-internal"
 }
 
+// PersistenceVolume returns the name to use for the volume storing the exporters' sending queues in the pod.
+func PersistenceVolume() string {
+	return "otc-persistence"
+}
+
+// PersistenceInitContainer returns the name to use for the init container giving the collector the ownership of its host path.
+func PersistenceInitContainer() string {
+	return "otc-persistence-init"
+}
+
+// DrainVolume returns the name to use for the volume holding the sleep command of the drain in the pod.
+func DrainVolume() string {
+	return "otc-drain"
+}
+
+// DrainInitContainer returns the name to use for the init container copying the sleep command of the drain.
+func DrainInitContainer() string {
+	return "otc-drain-init"
+}
+
 // ConfigMapExtra returns the prefix to use for the extras mounted configmaps in the pod.
 func ConfigMapExtra(extraConfigMapName string) string {
 	return DNSName(Truncate("configmap-%s", 63, extraConfigMapName))
@@ -55,6 +75,11 @@ func Container() string {
 	return "otc-container"
 }
 
+// ConfigReloaderContainer returns the name to use for the container reloading the configuration of a sidecar.
+func ConfigReloaderContainer() string {
+	return "otc-config-reloader"
+}
+
 // TAContainer returns the name to use for the container in the TargetAllocator pod.
 func TAContainer() string {
 	return "ta-container"
@@ -70,6 +95,16 @@ func Collector(otelcol string) string {
 	return DNSName(Truncate("%s-collector", 63, otelcol))
 }
 
+// CanaryCollector builds the canary collector deployment name based on the instance.
+func CanaryCollector(otelcol string) string {
+	return DNSName(Truncate("%s-collector-canary", 63, otelcol))
+}
+
+// CanaryConfigMap builds the name for the config map used in the canary collector containers.
+func CanaryConfigMap(otelcol string) string {
+	return DNSName(Truncate("%s-collector-canary", 63, otelcol))
+}
+
 // HorizontalPodAutoscaler builds the autoscaler name based on the instance.
 func HorizontalPodAutoscaler(otelcol string) string {
 	return DNSName(Truncate("%s-collector", 63, otelcol))
@@ -80,6 +115,41 @@ func PodDisruptionBudget(otelcol string) string {
 	return DNSName(Truncate("%s-collector", 63, otelcol))
 }
 
+// NetworkPolicy builds the collector network policy name based on the instance.
+func NetworkPolicy(otelcol string) string {
+	return DNSName(Truncate("%s-collector", 63, otelcol))
+}
+
+// ClusterRole builds the collector's cluster role name based on the instance and its namespace.
+func ClusterRole(otelcol string, namespace string) string {
+	return DNSName(Truncate("%s-%s-collector", 63, otelcol, namespace))
+}
+
+// ClusterRoleBinding builds the collector's cluster role binding name based on the instance and its namespace.
+func ClusterRoleBinding(otelcol string, namespace string) string {
+	return DNSName(Truncate("%s-%s-collector", 63, otelcol, namespace))
+}
+
+// Role builds the collector's role name based on the instance.
+func Role(otelcol string) string {
+	return DNSName(Truncate("%s-collector", 63, otelcol))
+}
+
+// RoleBinding builds the collector's role binding name based on the instance.
+func RoleBinding(otelcol string) string {
+	return DNSName(Truncate("%s-collector", 63, otelcol))
+}
+
+// TANetworkPolicy returns the name to use for the TargetAllocator network policy.
+func TANetworkPolicy(otelcol string) string {
+	return DNSName(Truncate("%s-targetallocator", 63, otelcol))
+}
+
+// OpAMPBridgeNetworkPolicy returns the name to use for the OpAMPBridge network policy.
+func OpAMPBridgeNetworkPolicy(opampBridge string) string {
+	return DNSName(Truncate("%s-opamp-bridge", 63, opampBridge))
+}
+
 // OpenTelemetryCollector builds the collector (deployment/daemonset) name based on the instance.
 func OpenTelemetryCollector(otelcol string) string {
 	return DNSName(Truncate("%s", 63, otelcol))
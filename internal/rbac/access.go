@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rbac checks the permissions held by the operator.
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reviewTTL is how long the outcome of a permission review is reused for.
+const reviewTTL = 5 * time.Minute
+
+// Reviewer checks the permissions held by the operator.
+type Reviewer struct {
+	client  client.Client
+	mu      sync.Mutex
+	reviews map[authorizationv1.ResourceAttributes]review
+}
+
+type review struct {
+	allowed bool
+	expiry  time.Time
+}
+
+// NewReviewer creates a new Reviewer.
+func NewReviewer(cl client.Client) *Reviewer {
+	return &Reviewer{
+		client:  cl,
+		reviews: map[authorizationv1.ResourceAttributes]review{},
+	}
+}
+
+// MissingPermissions returns the cluster-wide permissions granted by the given rules that the operator doesn't hold,
+// like "list nodes" or "get apps/deployments".
+func (r *Reviewer) MissingPermissions(ctx context.Context, rules ...rbacv1.PolicyRule) ([]string, error) {
+	return r.MissingPermissionsIn(ctx, "", rules...)
+}
+
+// MissingPermissionsIn returns the permissions granted by the given rules in the given namespace that the operator
+// doesn't hold. An empty namespace stands for the cluster-wide permissions.
+func (r *Reviewer) MissingPermissionsIn(ctx context.Context, namespace string, rules ...rbacv1.PolicyRule) ([]string, error) {
+	var missing []string
+	for _, rule := range rules {
+		for _, group := range rule.APIGroups {
+			for _, resource := range rule.Resources {
+				for _, verb := range rule.Verbs {
+					name, subresource, _ := strings.Cut(resource, "/")
+					allowed, err := r.allowed(ctx, authorizationv1.ResourceAttributes{
+						Namespace:   namespace,
+						Verb:        verb,
+						Group:       group,
+						Resource:    name,
+						Subresource: subresource,
+					})
+					if err != nil {
+						return nil, err
+					}
+					if !allowed {
+						missing = append(missing, permission(verb, group, resource))
+					}
+				}
+			}
+		}
+	}
+	return missing, nil
+}
+
+// allowed returns whether the operator holds the given permission.
+func (r *Reviewer) allowed(ctx context.Context, attributes authorizationv1.ResourceAttributes) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if cached, ok := r.reviews[attributes]; ok && time.Now().Before(cached.expiry) {
+		return cached.allowed, nil
+	}
+	ssar := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: attributes.DeepCopy()},
+	}
+	if err := r.client.Create(ctx, ssar); err != nil {
+		return false, fmt.Errorf("failed to review the operator's permissions: %w", err)
+	}
+	r.reviews[attributes] = review{allowed: ssar.Status.Allowed, expiry: time.Now().Add(reviewTTL)}
+	return ssar.Status.Allowed, nil
+}
+
+func permission(verb, group, resource string) string {
+	if group == "" {
+		return fmt.Sprintf("%s %s", verb, resource)
+	}
+	return fmt.Sprintf("%s %s/%s", verb, group, resource)
+}
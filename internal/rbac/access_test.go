@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rbac
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestMissingPermissions(t *testing.T) {
+	reviews := 0
+	cli := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			ssar := obj.(*authorizationv1.SelfSubjectAccessReview)
+			reviews++
+			// the operator can only read pods
+			attributes := ssar.Spec.ResourceAttributes
+			ssar.Status.Allowed = attributes.Group == "" && attributes.Resource == "pods" && attributes.Verb != "delete"
+			return nil
+		},
+	}).Build()
+	reviewer := NewReviewer(cli)
+	rules := []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"pods", "nodes/stats"}, Verbs: []string{"get", "delete"}},
+		{APIGroups: []string{"apps"}, Resources: []string{"replicasets"}, Verbs: []string{"list"}},
+	}
+
+	missing, err := reviewer.MissingPermissions(context.Background(), rules...)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"delete pods", "get nodes/stats", "delete nodes/stats", "list apps/replicasets"}, missing)
+	assert.Equal(t, 5, reviews)
+
+	// the reviews are reused
+	missing, err = reviewer.MissingPermissions(context.Background(), rules...)
+	require.NoError(t, err)
+	assert.Len(t, missing, 4)
+	assert.Equal(t, 5, reviews)
+}
+
+func TestMissingPermissionsIn(t *testing.T) {
+	cli := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			ssar := obj.(*authorizationv1.SelfSubjectAccessReview)
+			// the operator can only read the configmaps of its namespace
+			attributes := ssar.Spec.ResourceAttributes
+			ssar.Status.Allowed = attributes.Namespace == "observability" && attributes.Resource == "configmaps"
+			return nil
+		},
+	}).Build()
+	reviewer := NewReviewer(cli)
+	rule := rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"list"}}
+
+	missing, err := reviewer.MissingPermissionsIn(context.Background(), "observability", rule)
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+
+	missing, err = reviewer.MissingPermissions(context.Background(), rule)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"list configmaps"}, missing)
+}
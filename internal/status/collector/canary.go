@@ -0,0 +1,241 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+const (
+	defaultCanaryAnalysisDuration = 5 * time.Minute
+	canaryAnalysisInterval        = 30 * time.Second
+	canaryMetricsTimeout          = 5 * time.Second
+)
+
+var (
+	exporterSendFailedMetrics = []string{
+		"otelcol_exporter_send_failed_spans",
+		"otelcol_exporter_send_failed_metric_points",
+		"otelcol_exporter_send_failed_log_records",
+	}
+	receiverRefusedMetrics = []string{
+		"otelcol_receiver_refused_spans",
+		"otelcol_receiver_refused_metric_points",
+		"otelcol_receiver_refused_log_records",
+	}
+	canaryHTTPClient = &http.Client{Timeout: canaryMetricsTimeout}
+)
+
+// UpdateCanaryStatus analyzes the health of the canary workload of the given instance and records
+// the outcome in its status. Once the canary is aborted, or the instance no longer asks for it, the
+// canary workload is removed.
+func UpdateCanaryStatus(ctx context.Context, log logr.Logger, cli client.Client, changed *v1alpha1.OpenTelemetryCollector) error {
+	canary := changed.Spec.Canary
+	if canary == nil || changed.Spec.Mode != v1alpha1.ModeDeployment {
+		if changed.Status.Canary == nil || changed.Status.Canary.Phase != v1alpha1.CanaryPhaseProgressing {
+			return nil
+		}
+		changed.Status.Canary.Phase = v1alpha1.CanaryPhaseAborted
+		changed.Status.Canary.Message = "the canary has been removed before its analysis finished"
+		return removeCanary(ctx, cli, changed)
+	}
+
+	hash := collector.CanaryConfigHash(*changed)
+	if changed.Status.Canary == nil || changed.Status.Canary.ConfigHash != hash {
+		now := metav1.Now()
+		changed.Status.Canary = &v1alpha1.CanaryStatus{
+			Phase:      v1alpha1.CanaryPhaseProgressing,
+			ConfigHash: hash,
+			StartTime:  &now,
+			Message:    "the canary analysis has started",
+		}
+		return nil
+	}
+	status := changed.Status.Canary
+	if status.Phase != v1alpha1.CanaryPhaseProgressing {
+		return nil
+	}
+
+	pods := &corev1.PodList{}
+	selector := manifestutils.SelectorLabels(changed.ObjectMeta, collector.ComponentOpenTelemetryCollector)
+	selector[collector.CanaryLabel] = "true"
+	if err := cli.List(ctx, pods, client.InNamespace(changed.Namespace), client.MatchingLabels(selector)); err != nil {
+		return fmt.Errorf("failed to list the canary pods: %w", err)
+	}
+
+	metricsPort := int32(8888)
+	if c, err := adapters.ConfigFromString(canary.Config); err == nil {
+		if port, err := adapters.ConfigToMetricsPort(log, c); err == nil {
+			metricsPort = port
+		}
+	}
+
+	var readyPods, restarts int32
+	var sendFailures, refused int64
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Name == naming.Container() {
+				restarts += cs.RestartCount
+			}
+		}
+		if !isPodReady(pod) {
+			continue
+		}
+		readyPods++
+		metrics, err := scrapeCanaryMetrics(ctx, pod.Status.PodIP, metricsPort)
+		if err != nil {
+			log.V(1).Info("failed to scrape the canary metrics", "pod", pod.Name, "error", err)
+			continue
+		}
+		for _, name := range exporterSendFailedMetrics {
+			sendFailures += int64(metrics[name])
+		}
+		for _, name := range receiverRefusedMetrics {
+			refused += int64(metrics[name])
+		}
+	}
+	status.Restarts = restarts
+	status.ExporterSendFailures = sendFailures
+	status.Refused = refused
+
+	switch {
+	case restarts > valueOrDefault(canary.MaxRestarts, 0):
+		status.Phase = v1alpha1.CanaryPhaseAborted
+		status.Message = fmt.Sprintf("the canary pods restarted %d times", restarts)
+	case sendFailures > valueOrDefault(canary.MaxExporterSendFailures, 0):
+		status.Phase = v1alpha1.CanaryPhaseAborted
+		status.Message = fmt.Sprintf("the canary exporters failed to send %d items", sendFailures)
+	case refused > valueOrDefault(canary.MaxRefused, 0):
+		status.Phase = v1alpha1.CanaryPhaseAborted
+		status.Message = fmt.Sprintf("the canary receivers refused %d items", refused)
+	}
+	if status.Phase == v1alpha1.CanaryPhaseAborted {
+		return removeCanary(ctx, cli, changed)
+	}
+
+	replicas := valueOrDefault(canary.Replicas, 1)
+	duration := defaultCanaryAnalysisDuration
+	if canary.AnalysisDuration != nil {
+		duration = canary.AnalysisDuration.Duration
+	}
+	if readyPods >= replicas && status.StartTime != nil && time.Since(status.StartTime.Time) >= duration {
+		status.Phase = v1alpha1.CanaryPhasePromoted
+		status.Message = fmt.Sprintf("the canary stayed healthy for %s", duration)
+		return removeCanary(ctx, cli, changed)
+	}
+	status.Message = fmt.Sprintf("analyzing the canary, %d/%d pods ready", readyPods, replicas)
+	return nil
+}
+
+// removeCanary deletes the canary workload and its configuration.
+func removeCanary(ctx context.Context, cli client.Client, otelcol *v1alpha1.OpenTelemetryCollector) error {
+	objs := []client.Object{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: naming.CanaryCollector(otelcol.Name), Namespace: otelcol.Namespace}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: naming.CanaryConfigMap(otelcol.Name), Namespace: otelcol.Namespace}},
+	}
+	for _, obj := range objs {
+		if err := cli.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to remove the canary %s: %w", obj.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// scrapeCanaryMetrics returns the sum of each metric exposed by the collector's internal telemetry endpoint.
+func scrapeCanaryMetrics(ctx context.Context, podIP string, port int32) (map[string]float64, error) {
+	url := fmt.Sprintf("http://%s/metrics", net.JoinHostPort(podIP, strconv.Itoa(int(port))))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := canaryHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return parseMetrics(resp.Body)
+}
+
+// parseMetrics sums the samples of each metric in the Prometheus text exposition format.
+func parseMetrics(r io.Reader) (map[string]float64, error) {
+	metrics := map[string]float64{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, rest := line, ""
+		if i := strings.IndexAny(line, "{ "); i >= 0 {
+			name, rest = line[:i], line[i:]
+		}
+		if i := strings.LastIndex(rest, "}"); i >= 0 {
+			rest = rest[i+1:]
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			continue
+		}
+		value, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			continue
+		}
+		metrics[name] += value
+	}
+	return metrics, scanner.Err()
+}
+
+func isPodReady(pod corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func valueOrDefault[T int32 | int64](v *T, def T) T {
+	if v == nil {
+		return def
+	}
+	return *v
+}
@@ -0,0 +1,254 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+)
+
+const canaryMetrics = `# HELP otelcol_exporter_send_failed_spans Number of spans in failed attempts to send to destination.
+# TYPE otelcol_exporter_send_failed_spans counter
+otelcol_exporter_send_failed_spans{exporter="otlp",service_instance_id="a"} 2
+otelcol_exporter_send_failed_spans{exporter="otlphttp",service_instance_id="a"} 3
+otelcol_exporter_send_failed_log_records{exporter="otlp"} 1
+otelcol_receiver_refused_metric_points{receiver="otlp",transport="grpc"} 4
+otelcol_process_uptime 12.5
+`
+
+func TestParseMetrics(t *testing.T) {
+	metrics, err := parseMetrics(strings.NewReader(canaryMetrics + `
+otelcol_receiver_refused_spans{receiver="otlp",note="a } b"} 1 1700000000000
+otelcol_receiver_refused_spans 2
+malformed_metric not-a-number
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]float64{
+		"otelcol_exporter_send_failed_spans":       5,
+		"otelcol_exporter_send_failed_log_records": 1,
+		"otelcol_receiver_refused_metric_points":   4,
+		"otelcol_receiver_refused_spans":           3,
+		"otelcol_process_uptime":                   12.5,
+	}, metrics)
+}
+
+func TestUpdateCanaryStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, canaryMetrics)
+	}))
+	defer server.Close()
+	_, port, err := net.SplitHostPort(strings.TrimPrefix(server.URL, "http://"))
+	require.NoError(t, err)
+
+	canaryConfig := fmt.Sprintf("service:\n  telemetry:\n    metrics:\n      address: 0.0.0.0:%s\n", port)
+	newInstance := func(canary *v1alpha1.CanarySpec, status *v1alpha1.CanaryStatus) v1alpha1.OpenTelemetryCollector {
+		return v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Mode:   v1alpha1.ModeDeployment,
+				Canary: canary,
+			},
+			Status: v1alpha1.OpenTelemetryCollectorStatus{Canary: status},
+		}
+	}
+	newCanaryPod := func(otelcol v1alpha1.OpenTelemetryCollector, name string, ready bool, restarts int32) *corev1.Pod {
+		pod := newCollectorPod(otelcol, name, ready, restarts, "", time.Time{})
+		pod.Labels[collector.CanaryLabel] = "true"
+		pod.Status.PodIP = "127.0.0.1"
+		return pod
+	}
+	limited := func(maxExporterSendFailures, maxRefused int64) *v1alpha1.CanarySpec {
+		return &v1alpha1.CanarySpec{Config: canaryConfig, MaxExporterSendFailures: &maxExporterSendFailures, MaxRefused: &maxRefused}
+	}
+	progressing := func(canary *v1alpha1.CanarySpec, startedAgo time.Duration) *v1alpha1.CanaryStatus {
+		start := metav1.NewTime(time.Now().Add(-startedAgo))
+		return &v1alpha1.CanaryStatus{
+			Phase:      v1alpha1.CanaryPhaseProgressing,
+			ConfigHash: collector.CanaryConfigHash(newInstance(canary, nil)),
+			StartTime:  &start,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		canary   *v1alpha1.CanarySpec
+		status   *v1alpha1.CanaryStatus
+		pods     func(otelcol v1alpha1.OpenTelemetryCollector) []client.Object
+		phase    v1alpha1.CanaryPhase
+		message  string
+		removed  bool
+		restarts int32
+	}{
+		{
+			name:    "analysis started",
+			canary:  limited(100, 100),
+			phase:   v1alpha1.CanaryPhaseProgressing,
+			message: "the canary analysis has started",
+		},
+		{
+			name:    "canary removed",
+			status:  progressing(limited(100, 100), time.Minute),
+			phase:   v1alpha1.CanaryPhaseAborted,
+			message: "the canary has been removed before its analysis finished",
+			removed: true,
+		},
+		{
+			name:   "restarts exceeded",
+			canary: limited(100, 100),
+			status: progressing(limited(100, 100), time.Minute),
+			pods: func(otelcol v1alpha1.OpenTelemetryCollector) []client.Object {
+				return []client.Object{newCanaryPod(otelcol, "test-canary-a", false, 2)}
+			},
+			phase:    v1alpha1.CanaryPhaseAborted,
+			message:  "the canary pods restarted 2 times",
+			removed:  true,
+			restarts: 2,
+		},
+		{
+			name:   "exporter send failures exceeded",
+			canary: limited(0, 100),
+			status: progressing(limited(0, 100), time.Minute),
+			pods: func(otelcol v1alpha1.OpenTelemetryCollector) []client.Object {
+				return []client.Object{newCanaryPod(otelcol, "test-canary-a", true, 0)}
+			},
+			phase:   v1alpha1.CanaryPhaseAborted,
+			message: "the canary exporters failed to send 6 items",
+			removed: true,
+		},
+		{
+			name:   "refused exceeded",
+			canary: limited(100, 0),
+			status: progressing(limited(100, 0), time.Minute),
+			pods: func(otelcol v1alpha1.OpenTelemetryCollector) []client.Object {
+				return []client.Object{newCanaryPod(otelcol, "test-canary-a", true, 0)}
+			},
+			phase:   v1alpha1.CanaryPhaseAborted,
+			message: "the canary receivers refused 4 items",
+			removed: true,
+		},
+		{
+			name:   "analyzing",
+			canary: limited(100, 100),
+			status: progressing(limited(100, 100), time.Minute),
+			pods: func(otelcol v1alpha1.OpenTelemetryCollector) []client.Object {
+				// the main pods aren't part of the analysis
+				return []client.Object{
+					newCanaryPod(otelcol, "test-canary-a", true, 0),
+					newCollectorPod(otelcol, "test-a", false, 5, "OOMKilled", time.Now()),
+				}
+			},
+			phase:   v1alpha1.CanaryPhaseProgressing,
+			message: "analyzing the canary, 1/1 pods ready",
+		},
+		{
+			name:   "pods not ready after the analysis duration",
+			canary: limited(100, 100),
+			status: progressing(limited(100, 100), 10*time.Minute),
+			pods: func(otelcol v1alpha1.OpenTelemetryCollector) []client.Object {
+				return []client.Object{newCanaryPod(otelcol, "test-canary-a", false, 0)}
+			},
+			phase:   v1alpha1.CanaryPhaseProgressing,
+			message: "analyzing the canary, 0/1 pods ready",
+		},
+		{
+			name:   "promoted",
+			canary: limited(100, 100),
+			status: progressing(limited(100, 100), 10*time.Minute),
+			pods: func(otelcol v1alpha1.OpenTelemetryCollector) []client.Object {
+				return []client.Object{newCanaryPod(otelcol, "test-canary-a", true, 0)}
+			},
+			phase:   v1alpha1.CanaryPhasePromoted,
+			message: "the canary stayed healthy for 5m0s",
+			removed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := newInstance(tt.canary, tt.status)
+			objects := []client.Object{
+				&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "test-collector-canary", Namespace: "default"}},
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-collector-canary", Namespace: "default"}},
+			}
+			if tt.pods != nil {
+				objects = append(objects, tt.pods(otelcol)...)
+			}
+			cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+			require.NoError(t, UpdateCanaryStatus(context.Background(), logf.Log, cli, &otelcol))
+
+			require.NotNil(t, otelcol.Status.Canary)
+			assert.Equal(t, tt.phase, otelcol.Status.Canary.Phase)
+			assert.Equal(t, tt.message, otelcol.Status.Canary.Message)
+			assert.Equal(t, tt.restarts, otelcol.Status.Canary.Restarts)
+			for _, obj := range []client.Object{&appsv1.Deployment{}, &corev1.ConfigMap{}} {
+				err := cli.Get(context.Background(), client.ObjectKey{Name: "test-collector-canary", Namespace: "default"}, obj)
+				assert.Equal(t, tt.removed, apierrors.IsNotFound(err), "%T", obj)
+			}
+		})
+	}
+}
+
+func TestUpdateCanaryStatusFinished(t *testing.T) {
+	canary := &v1alpha1.CanarySpec{Config: "receivers: {}"}
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:   v1alpha1.ModeDeployment,
+			Canary: canary,
+		},
+	}
+	status := &v1alpha1.CanaryStatus{
+		Phase:      v1alpha1.CanaryPhasePromoted,
+		ConfigHash: collector.CanaryConfigHash(otelcol),
+		Message:    "the canary stayed healthy for 5m0s",
+	}
+	otelcol.Status.Canary = status.DeepCopy()
+
+	// a finished canary is neither analyzed again nor needs the client
+	require.NoError(t, UpdateCanaryStatus(context.Background(), logf.Log, nil, &otelcol))
+	assert.Equal(t, status, otelcol.Status.Canary)
+
+	// a new canary configuration restarts the analysis
+	otelcol.Spec.Canary = &v1alpha1.CanarySpec{Config: "receivers: {otlp: {}}"}
+	require.NoError(t, UpdateCanaryStatus(context.Background(), logf.Log, nil, &otelcol))
+	assert.Equal(t, v1alpha1.CanaryPhaseProgressing, otelcol.Status.Canary.Phase)
+	assert.Equal(t, collector.CanaryConfigHash(otelcol), otelcol.Status.Canary.ConfigHash)
+	assert.NotNil(t, otelcol.Status.Canary.StartTime)
+}
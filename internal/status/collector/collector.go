@@ -18,40 +18,44 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
+	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 )
 
-func UpdateCollectorStatus(ctx context.Context, cli client.Client, changed *v1alpha1.OpenTelemetryCollector) error {
+// UpdateCollectorStatus updates the status of the given instance. It returns the time after which the status must be
+// updated again, when the recent pod terminations it counts expire, or zero.
+func UpdateCollectorStatus(ctx context.Context, cli client.Client, cfg config.Config, changed *v1alpha1.OpenTelemetryCollector) (time.Duration, error) {
 	if changed.Status.Version == "" {
 		// a version is not set, otherwise let the upgrade mechanism take care of it!
 		changed.Status.Version = version.OpenTelemetryCollector()
 	}
+	changed.Status.ObservedGeneration = changed.Generation
+	setConfigValidCondition(changed)
+	if err := updateTargetAllocatorCondition(ctx, cli, changed); err != nil {
+		return 0, err
+	}
+
 	mode := changed.Spec.Mode
-	if mode != v1alpha1.ModeDeployment && mode != v1alpha1.ModeStatefulSet {
+	if mode == v1alpha1.ModeSidecar {
 		changed.Status.Scale.Replicas = 0
 		changed.Status.Scale.Selector = ""
-		return nil
+		changed.Status.PodHealth = nil
+		setWorkloadConditions(changed, workloadState{sidecar: true})
+		return 0, updateSidecarStatus(ctx, cli, cfg, changed)
 	}
 
-	name := naming.Collector(changed.Name)
-
-	// Set the scale selector
-	labels := manifestutils.Labels(changed.ObjectMeta, name, changed.Spec.Image, collector.ComponentOpenTelemetryCollector, []string{})
-	selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchLabels: labels})
-	if err != nil {
-		return fmt.Errorf("failed to get selector for labelSelector: %w", err)
-	}
-	changed.Status.Scale.Selector = selector.String()
-
 	// Set the scale replicas
 	objKey := client.ObjectKey{
 		Namespace: changed.GetNamespace(),
@@ -62,38 +66,73 @@ func UpdateCollectorStatus(ctx context.Context, cli client.Client, changed *v1al
 	var readyReplicas int32
 	var statusReplicas string
 	var statusImage string
+	var state workloadState
 
 	switch mode { // nolint:exhaustive
 	case v1alpha1.ModeDeployment:
 		obj := &appsv1.Deployment{}
 		if err := cli.Get(ctx, objKey, obj); err != nil {
-			return fmt.Errorf("failed to get deployment status.replicas: %w", err)
+			return 0, fmt.Errorf("failed to get deployment status.replicas: %w", err)
 		}
 		replicas = obj.Status.Replicas
 		readyReplicas = obj.Status.ReadyReplicas
 		statusReplicas = strconv.Itoa(int(readyReplicas)) + "/" + strconv.Itoa(int(replicas))
 		statusImage = obj.Spec.Template.Spec.Containers[0].Image
+		state = deploymentState(obj)
 
 	case v1alpha1.ModeStatefulSet:
 		obj := &appsv1.StatefulSet{}
 		if err := cli.Get(ctx, objKey, obj); err != nil {
-			return fmt.Errorf("failed to get statefulSet status.replicas: %w", err)
+			return 0, fmt.Errorf("failed to get statefulSet status.replicas: %w", err)
 		}
 		replicas = obj.Status.Replicas
 		readyReplicas = obj.Status.ReadyReplicas
 		statusReplicas = strconv.Itoa(int(readyReplicas)) + "/" + strconv.Itoa(int(replicas))
 		statusImage = obj.Spec.Template.Spec.Containers[0].Image
+		state = statefulSetState(obj)
 
 	case v1alpha1.ModeDaemonSet:
 		obj := &appsv1.DaemonSet{}
 		if err := cli.Get(ctx, objKey, obj); err != nil {
-			return fmt.Errorf("failed to get daemonSet status.replicas: %w", err)
+			return 0, fmt.Errorf("failed to get daemonSet status.replicas: %w", err)
 		}
+		statusReplicas = strconv.Itoa(int(obj.Status.NumberReady)) + "/" + strconv.Itoa(int(obj.Status.DesiredNumberScheduled))
 		statusImage = obj.Spec.Template.Spec.Containers[0].Image
+		state = daemonSetState(obj)
+	}
+	setWorkloadConditions(changed, state)
+	changed.Status.Sidecar = nil
+	requeueAfter, err := updatePodHealth(ctx, cli, changed, time.Now())
+	if err != nil {
+		return 0, err
 	}
-	changed.Status.Scale.Replicas = replicas
 	changed.Status.Image = statusImage
 	changed.Status.Scale.StatusReplicas = statusReplicas
 
-	return nil
+	if mode != v1alpha1.ModeDeployment && mode != v1alpha1.ModeStatefulSet {
+		changed.Status.Scale.Replicas = 0
+		changed.Status.Scale.Selector = ""
+		return requeueAfter, nil
+	}
+
+	// Set the scale selector
+	labels := manifestutils.Labels(changed.ObjectMeta, naming.Collector(changed.Name), changed.Spec.Image, collector.ComponentOpenTelemetryCollector, []string{})
+	selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchLabels: labels})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get selector for labelSelector: %w", err)
+	}
+	changed.Status.Scale.Selector = selector.String()
+	changed.Status.Scale.Replicas = replicas
+
+	return requeueAfter, nil
+}
+
+// configIsValid parses the collector's configuration the same way the manifests are built from it.
+func configIsValid(otelcol v1alpha1.OpenTelemetryCollector) error {
+	c, err := adapters.ConfigFromString(otelcol.Spec.Config)
+	if err != nil {
+		return err
+	}
+	_, err = adapters.ConfigToPorts(logr.Discard(), c)
+	return err
 }
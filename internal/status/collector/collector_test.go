@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+const validConfig = `
+receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`
+
+func newDeployment(replicas, ready, updated int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-collector", Namespace: "default", Generation: 2},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "otc-container", Image: "collector:0.1"}}},
+			},
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 2,
+			Replicas:           replicas,
+			ReadyReplicas:      ready,
+			UpdatedReplicas:    updated,
+		},
+	}
+}
+
+func TestUpdateCollectorStatusConditions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	tests := []struct {
+		name     string
+		config   string
+		objects  []*appsv1.Deployment
+		expected map[string]metav1.ConditionStatus
+	}{
+		{
+			name:    "ready",
+			config:  validConfig,
+			objects: []*appsv1.Deployment{newDeployment(2, 2, 2)},
+			expected: map[string]metav1.ConditionStatus{
+				v1alpha1.CollectorConditionConfigValid: metav1.ConditionTrue,
+				v1alpha1.CollectorConditionReady:       metav1.ConditionTrue,
+				v1alpha1.CollectorConditionProgressing: metav1.ConditionFalse,
+				v1alpha1.CollectorConditionDegraded:    metav1.ConditionFalse,
+			},
+		},
+		{
+			name:    "rollout in progress",
+			config:  validConfig,
+			objects: []*appsv1.Deployment{newDeployment(2, 1, 1)},
+			expected: map[string]metav1.ConditionStatus{
+				v1alpha1.CollectorConditionConfigValid: metav1.ConditionTrue,
+				v1alpha1.CollectorConditionReady:       metav1.ConditionFalse,
+				v1alpha1.CollectorConditionProgressing: metav1.ConditionTrue,
+				v1alpha1.CollectorConditionDegraded:    metav1.ConditionFalse,
+			},
+		},
+		{
+			name:    "invalid config",
+			config:  "receivers: [",
+			objects: []*appsv1.Deployment{newDeployment(1, 1, 1)},
+			expected: map[string]metav1.ConditionStatus{
+				v1alpha1.CollectorConditionConfigValid: metav1.ConditionFalse,
+				v1alpha1.CollectorConditionReady:       metav1.ConditionTrue,
+				v1alpha1.CollectorConditionProgressing: metav1.ConditionFalse,
+				v1alpha1.CollectorConditionDegraded:    metav1.ConditionTrue,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			for _, obj := range tt.objects {
+				builder = builder.WithObjects(obj)
+			}
+			otelcol := &v1alpha1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Generation: 3},
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Mode:   v1alpha1.ModeDeployment,
+					Config: tt.config,
+				},
+			}
+
+			_, err := UpdateCollectorStatus(context.Background(), builder.Build(), config.New(), otelcol)
+			require.NoError(t, err)
+
+			assert.Equal(t, int64(3), otelcol.Status.ObservedGeneration)
+			assert.Len(t, otelcol.Status.Conditions, len(tt.expected))
+			for conditionType, status := range tt.expected {
+				condition := meta.FindStatusCondition(otelcol.Status.Conditions, conditionType)
+				require.NotNil(t, condition, conditionType)
+				assert.Equal(t, status, condition.Status, conditionType)
+				assert.Equal(t, int64(3), condition.ObservedGeneration)
+			}
+		})
+	}
+}
+
+func TestUpdateCollectorStatusTargetAllocatorCondition(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	ta := newDeployment(1, 0, 1)
+	ta.Name = "test-targetallocator"
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-collector", Namespace: "default"},
+		Spec: appsv1.StatefulSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "otc-container", Image: "collector:0.1"}}},
+			},
+		},
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ta, sts).Build()
+	otelcol := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:            v1alpha1.ModeStatefulSet,
+			Config:          validConfig,
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{Enabled: true},
+		},
+	}
+
+	_, err := UpdateCollectorStatus(context.Background(), cli, config.New(), otelcol)
+	require.NoError(t, err)
+	assert.True(t, meta.IsStatusConditionFalse(otelcol.Status.Conditions, v1alpha1.CollectorConditionTargetAllocatorReady))
+
+	otelcol.Spec.TargetAllocator.Enabled = false
+	_, err = UpdateCollectorStatus(context.Background(), cli, config.New(), otelcol)
+	require.NoError(t, err)
+	assert.Nil(t, meta.FindStatusCondition(otelcol.Status.Conditions, v1alpha1.CollectorConditionTargetAllocatorReady))
+}
+
+func TestSetReconciledCondition(t *testing.T) {
+	otelcol := &v1alpha1.OpenTelemetryCollector{ObjectMeta: metav1.ObjectMeta{Generation: 5}}
+
+	setReconciledCondition(otelcol, errors.New("failed to create objects"))
+	assert.Equal(t, int64(5), otelcol.Status.ObservedGeneration)
+	assert.True(t, meta.IsStatusConditionFalse(otelcol.Status.Conditions, v1alpha1.CollectorConditionReconciled))
+	assert.True(t, meta.IsStatusConditionTrue(otelcol.Status.Conditions, v1alpha1.CollectorConditionDegraded))
+
+	setReconciledCondition(otelcol, nil)
+	assert.True(t, meta.IsStatusConditionTrue(otelcol.Status.Conditions, v1alpha1.CollectorConditionReconciled))
+}
@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+const (
+	reasonConfigParsed            = "ConfigParsed"
+	reasonConfigInvalid           = "ConfigInvalid"
+	reasonReconcileSucceeded      = "ReconcileSucceeded"
+	reasonReconcileFailed         = "ReconcileFailed"
+	reasonPodsReady               = "PodsReady"
+	reasonPodsNotReady            = "PodsNotReady"
+	reasonRolloutInProgress       = "RolloutInProgress"
+	reasonRolloutComplete         = "RolloutComplete"
+	reasonWorkloadFailure         = "WorkloadFailure"
+	reasonAsExpected              = "AsExpected"
+	reasonSidecar                 = "Sidecar"
+	reasonTargetAllocatorNotFound = "TargetAllocatorNotFound"
+)
+
+// workloadState summarizes the rollout of the workload running the collector pods.
+type workloadState struct {
+	sidecar  bool
+	desired  int32
+	ready    int32
+	updated  int32
+	observed bool
+	failure  string
+}
+
+func deploymentState(obj *appsv1.Deployment) workloadState {
+	state := workloadState{
+		desired:  1,
+		ready:    obj.Status.ReadyReplicas,
+		updated:  obj.Status.UpdatedReplicas,
+		observed: obj.Status.ObservedGeneration >= obj.Generation,
+	}
+	if obj.Spec.Replicas != nil {
+		state.desired = *obj.Spec.Replicas
+	}
+	for _, c := range obj.Status.Conditions {
+		if c.Type == appsv1.DeploymentReplicaFailure && c.Status == corev1.ConditionTrue {
+			state.failure = c.Message
+		}
+		if c.Type == appsv1.DeploymentProgressing && c.Status == corev1.ConditionFalse {
+			state.failure = c.Message
+		}
+	}
+	return state
+}
+
+func statefulSetState(obj *appsv1.StatefulSet) workloadState {
+	state := workloadState{
+		desired:  1,
+		ready:    obj.Status.ReadyReplicas,
+		updated:  obj.Status.UpdatedReplicas,
+		observed: obj.Status.ObservedGeneration >= obj.Generation,
+	}
+	if obj.Spec.Replicas != nil {
+		state.desired = *obj.Spec.Replicas
+	}
+	return state
+}
+
+func daemonSetState(obj *appsv1.DaemonSet) workloadState {
+	return workloadState{
+		desired:  obj.Status.DesiredNumberScheduled,
+		ready:    obj.Status.NumberReady,
+		updated:  obj.Status.UpdatedNumberScheduled,
+		observed: obj.Status.ObservedGeneration >= obj.Generation,
+	}
+}
+
+// setWorkloadConditions sets the Ready, Progressing and Degraded conditions from the state of the collector's workload.
+func setWorkloadConditions(otelcol *v1alpha1.OpenTelemetryCollector, state workloadState) {
+	if state.sidecar {
+		setCondition(otelcol, v1alpha1.CollectorConditionReady, metav1.ConditionTrue, reasonSidecar, "the collector is injected as a sidecar into the selected pods")
+		setCondition(otelcol, v1alpha1.CollectorConditionProgressing, metav1.ConditionFalse, reasonSidecar, "the collector is injected as a sidecar into the selected pods")
+	} else {
+		if state.ready >= state.desired {
+			setCondition(otelcol, v1alpha1.CollectorConditionReady, metav1.ConditionTrue, reasonPodsReady, fmt.Sprintf("%d/%d pods are ready", state.ready, state.desired))
+		} else {
+			setCondition(otelcol, v1alpha1.CollectorConditionReady, metav1.ConditionFalse, reasonPodsNotReady, fmt.Sprintf("%d/%d pods are ready", state.ready, state.desired))
+		}
+		if !state.observed || state.updated < state.desired {
+			setCondition(otelcol, v1alpha1.CollectorConditionProgressing, metav1.ConditionTrue, reasonRolloutInProgress, fmt.Sprintf("%d/%d pods are updated", state.updated, state.desired))
+		} else {
+			setCondition(otelcol, v1alpha1.CollectorConditionProgressing, metav1.ConditionFalse, reasonRolloutComplete, fmt.Sprintf("%d/%d pods are updated", state.updated, state.desired))
+		}
+	}
+
+	switch {
+	case meta.IsStatusConditionFalse(otelcol.Status.Conditions, v1alpha1.CollectorConditionConfigValid):
+		setCondition(otelcol, v1alpha1.CollectorConditionDegraded, metav1.ConditionTrue, reasonConfigInvalid, "the collector configuration is invalid")
+	case state.failure != "":
+		setCondition(otelcol, v1alpha1.CollectorConditionDegraded, metav1.ConditionTrue, reasonWorkloadFailure, state.failure)
+	default:
+		setCondition(otelcol, v1alpha1.CollectorConditionDegraded, metav1.ConditionFalse, reasonAsExpected, "")
+	}
+}
+
+// setConfigValidCondition sets the ConfigValid condition from the collector's configuration.
+func setConfigValidCondition(otelcol *v1alpha1.OpenTelemetryCollector) {
+	if err := configIsValid(*otelcol); err != nil {
+		setCondition(otelcol, v1alpha1.CollectorConditionConfigValid, metav1.ConditionFalse, reasonConfigInvalid, err.Error())
+		return
+	}
+	setCondition(otelcol, v1alpha1.CollectorConditionConfigValid, metav1.ConditionTrue, reasonConfigParsed, "")
+}
+
+// setReconciledCondition sets the Reconciled condition from the outcome of the reconciliation. A failed reconciliation
+// also marks the collector as degraded.
+func setReconciledCondition(otelcol *v1alpha1.OpenTelemetryCollector, err error) {
+	otelcol.Status.ObservedGeneration = otelcol.Generation
+	if err != nil {
+		setCondition(otelcol, v1alpha1.CollectorConditionReconciled, metav1.ConditionFalse, reasonReconcileFailed, err.Error())
+		setCondition(otelcol, v1alpha1.CollectorConditionDegraded, metav1.ConditionTrue, reasonReconcileFailed, err.Error())
+		return
+	}
+	setCondition(otelcol, v1alpha1.CollectorConditionReconciled, metav1.ConditionTrue, reasonReconcileSucceeded, "")
+}
+
+// updateTargetAllocatorCondition sets the TargetAllocatorReady condition when the target allocator is enabled,
+// and removes it otherwise.
+func updateTargetAllocatorCondition(ctx context.Context, cli client.Client, otelcol *v1alpha1.OpenTelemetryCollector) error {
+	if !otelcol.Spec.TargetAllocator.Enabled {
+		meta.RemoveStatusCondition(&otelcol.Status.Conditions, v1alpha1.CollectorConditionTargetAllocatorReady)
+		return nil
+	}
+	obj := &appsv1.Deployment{}
+	objKey := client.ObjectKey{Namespace: otelcol.Namespace, Name: naming.TargetAllocator(otelcol.Name)}
+	if err := cli.Get(ctx, objKey, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			setCondition(otelcol, v1alpha1.CollectorConditionTargetAllocatorReady, metav1.ConditionFalse, reasonTargetAllocatorNotFound, "the target allocator deployment doesn't exist")
+			return nil
+		}
+		return fmt.Errorf("failed to get the target allocator deployment: %w", err)
+	}
+	state := deploymentState(obj)
+	message := fmt.Sprintf("%d/%d target allocator pods are ready", state.ready, state.desired)
+	if state.ready >= state.desired {
+		setCondition(otelcol, v1alpha1.CollectorConditionTargetAllocatorReady, metav1.ConditionTrue, reasonPodsReady, message)
+	} else {
+		setCondition(otelcol, v1alpha1.CollectorConditionTargetAllocatorReady, metav1.ConditionFalse, reasonPodsNotReady, message)
+	}
+	return nil
+}
+
+func setCondition(otelcol *v1alpha1.OpenTelemetryCollector, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&otelcol.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: otelcol.Generation,
+	})
+}
@@ -22,6 +22,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 	collectorupgrade "github.com/open-telemetry/opentelemetry-operator/pkg/collector/upgrade"
@@ -31,17 +32,25 @@ const (
 	eventTypeNormal  = "Normal"
 	eventTypeWarning = "Warning"
 
-	reasonError         = "Error"
-	reasonStatusFailure = "StatusFailure"
-	reasonInfo          = "Info"
+	reasonError          = "Error"
+	reasonStatusFailure  = "StatusFailure"
+	reasonInfo           = "Info"
+	reasonCanaryPromoted = "CanaryPromoted"
+	reasonCanaryAborted  = "CanaryAborted"
 )
 
-// HandleReconcileStatus handles updating the status of the CRDs managed by the operator.
-// TODO: make the status more useful https://github.com/open-telemetry/opentelemetry-operator/issues/1972
+// HandleReconcileStatus handles updating the status of the CRDs managed by the operator, including the
+// conditions describing the outcome of the reconciliation.
 func HandleReconcileStatus(ctx context.Context, log logr.Logger, params manifests.Params, err error) (ctrl.Result, error) {
 	log.V(2).Info("updating collector status")
 	if err != nil {
 		params.Recorder.Event(&params.OtelCol, eventTypeWarning, reasonError, err.Error())
+		changed := params.OtelCol.DeepCopy()
+		setConfigValidCondition(changed)
+		setReconciledCondition(changed, err)
+		if patchErr := params.Client.Status().Patch(ctx, changed, client.MergeFrom(&params.OtelCol)); patchErr != nil {
+			log.Error(patchErr, "failed to apply status changes to the OpenTelemetry CR")
+		}
 		return ctrl.Result{}, err
 	}
 	changed := params.OtelCol.DeepCopy()
@@ -58,15 +67,47 @@ func HandleReconcileStatus(ctx context.Context, log logr.Logger, params manifest
 		params.Log.Error(upgradeErr, "failed to upgrade the OpenTelemetry CR")
 	}
 	changed = &upgraded
-	statusErr := UpdateCollectorStatus(ctx, params.Client, changed)
+	requeueAfter, statusErr := UpdateCollectorStatus(ctx, params.Client, params.Config, changed)
 	if statusErr != nil {
 		params.Recorder.Event(changed, eventTypeWarning, reasonStatusFailure, statusErr.Error())
 		return ctrl.Result{}, statusErr
 	}
+	canaryErr := UpdateCanaryStatus(ctx, log, params.Client, changed)
+	if canaryErr != nil {
+		params.Recorder.Event(changed, eventTypeWarning, reasonStatusFailure, canaryErr.Error())
+		return ctrl.Result{}, canaryErr
+	}
+	setReconciledCondition(changed, nil)
 	statusPatch := client.MergeFrom(&params.OtelCol)
 	if err := params.Client.Status().Patch(ctx, changed, statusPatch); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to apply status changes to the OpenTelemetry CR: %w", err)
 	}
 	params.Recorder.Event(changed, eventTypeNormal, reasonInfo, "applied status changes")
-	return ctrl.Result{}, nil
+	result := handleCanaryTransition(params, changed)
+	if requeueAfter > 0 && (result.RequeueAfter == 0 || requeueAfter < result.RequeueAfter) {
+		result.RequeueAfter = requeueAfter
+	}
+	return result, nil
+}
+
+// handleCanaryTransition records the outcome of a finished canary analysis and, while the analysis is
+// still running, requests the instance to be reconciled again.
+func handleCanaryTransition(params manifests.Params, changed *v1alpha1.OpenTelemetryCollector) ctrl.Result {
+	current := changed.Status.Canary
+	if current == nil {
+		return ctrl.Result{}
+	}
+	if current.Phase == v1alpha1.CanaryPhaseProgressing {
+		return ctrl.Result{RequeueAfter: canaryAnalysisInterval}
+	}
+	previous := params.OtelCol.Status.Canary
+	if previous != nil && previous.ConfigHash == current.ConfigHash && previous.Phase == current.Phase {
+		return ctrl.Result{}
+	}
+	if current.Phase == v1alpha1.CanaryPhasePromoted {
+		params.Recorder.Event(changed, eventTypeNormal, reasonCanaryPromoted, current.Message)
+	} else {
+		params.Recorder.Event(changed, eventTypeWarning, reasonCanaryAborted, current.Message)
+	}
+	return ctrl.Result{}
 }
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+const (
+	// recentTerminationWindow is how far back a container termination counts toward the pods health.
+	recentTerminationWindow = time.Hour
+	// maxReportedPods bounds the size of the status for collectors with many pods.
+	maxReportedPods = 100
+
+	terminationReasonOOMKilled = "OOMKilled"
+	terminationReasonCompleted = "Completed"
+)
+
+// updatePodHealth aggregates the health of the collector pods into the status of the given instance. It returns the
+// time after which the oldest recent termination leaves the window of the counts, or zero.
+func updatePodHealth(ctx context.Context, cli client.Client, changed *v1alpha1.OpenTelemetryCollector, now time.Time) (time.Duration, error) {
+	pods := &corev1.PodList{}
+	selector := manifestutils.SelectorLabels(changed.ObjectMeta, collector.ComponentOpenTelemetryCollector)
+	if err := cli.List(ctx, pods, client.InNamespace(changed.Namespace), client.MatchingLabels(selector)); err != nil {
+		return 0, fmt.Errorf("failed to list the collector pods: %w", err)
+	}
+
+	health := &v1alpha1.CollectorPodHealth{}
+	var expiry time.Duration
+	for _, pod := range pods.Items {
+		if _, canary := pod.Labels[collector.CanaryLabel]; canary || pod.DeletionTimestamp != nil {
+			continue
+		}
+		podStatus := v1alpha1.CollectorPodStatus{
+			Name:  pod.Name,
+			Ready: isPodReady(pod),
+		}
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Name != naming.Container() {
+				continue
+			}
+			podStatus.Restarts = cs.RestartCount
+			podStatus.Image = cs.Image
+			if terminated := cs.LastTerminationState.Terminated; terminated != nil {
+				podStatus.LastTerminationReason = terminated.Reason
+				finishedAt := terminated.FinishedAt
+				podStatus.LastTerminationTime = &finishedAt
+			}
+		}
+
+		health.Total++
+		health.Restarts += podStatus.Restarts
+		if podStatus.Ready {
+			health.Ready++
+		}
+		if podStatus.LastTerminationTime != nil && now.Sub(podStatus.LastTerminationTime.Time) <= recentTerminationWindow &&
+			podStatus.LastTerminationReason != terminationReasonCompleted {
+			if podStatus.LastTerminationReason == terminationReasonOOMKilled {
+				health.RecentlyOOMKilled++
+			} else {
+				health.RecentlyCrashed++
+			}
+			// the counts decrease once the termination leaves the window
+			if remaining := recentTerminationWindow - now.Sub(podStatus.LastTerminationTime.Time) + time.Second; expiry == 0 || remaining < expiry {
+				expiry = remaining
+			}
+		}
+		health.Pods = append(health.Pods, podStatus)
+	}
+
+	sort.Slice(health.Pods, func(i, j int) bool {
+		return health.Pods[i].Name < health.Pods[j].Name
+	})
+	if len(health.Pods) > maxReportedPods {
+		health.Pods = health.Pods[:maxReportedPods]
+	}
+	health.Summary = podHealthSummary(health)
+
+	changed.Status.PodHealth = health
+	return expiry, nil
+}
+
+func podHealthSummary(health *v1alpha1.CollectorPodHealth) string {
+	parts := []string{fmt.Sprintf("%d/%d pods ready", health.Ready, health.Total)}
+	if health.RecentlyOOMKilled > 0 {
+		parts = append(parts, fmt.Sprintf("%d/%d pods OOMKilled in the last hour", health.RecentlyOOMKilled, health.Total))
+	}
+	if health.RecentlyCrashed > 0 {
+		parts = append(parts, fmt.Sprintf("%d/%d pods crashed in the last hour", health.RecentlyCrashed, health.Total))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+)
+
+func newCollectorPod(otelcol v1alpha1.OpenTelemetryCollector, name string, ready bool, restarts int32, reason string, finishedAt time.Time) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: otelcol.Namespace,
+			Labels:    manifestutils.SelectorLabels(otelcol.ObjectMeta, collector.ComponentOpenTelemetryCollector),
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:         "otc-container",
+				Image:        "collector:0.1",
+				Ready:        ready,
+				RestartCount: restarts,
+			}},
+		},
+	}
+	if ready {
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	}
+	if reason != "" {
+		pod.Status.ContainerStatuses[0].LastTerminationState.Terminated = &corev1.ContainerStateTerminated{
+			Reason:     reason,
+			FinishedAt: metav1.NewTime(finishedAt),
+		}
+	}
+	return pod
+}
+
+func TestUpdatePodHealth(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	now := time.Now()
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+	}
+	canary := newCollectorPod(otelcol, "test-canary", false, 5, "OOMKilled", now)
+	canary.Labels[collector.CanaryLabel] = "true"
+	other := newCollectorPod(v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+	}, "other-a", false, 1, "Error", now)
+
+	objects := []client.Object{
+		newCollectorPod(otelcol, "test-c", true, 0, "", time.Time{}),
+		newCollectorPod(otelcol, "test-a", false, 3, "OOMKilled", now.Add(-10*time.Minute)),
+		newCollectorPod(otelcol, "test-b", true, 1, "Error", now.Add(-2*time.Hour)),
+		canary,
+		other,
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+	requeueAfter, err := updatePodHealth(context.Background(), cli, &otelcol, now)
+	require.NoError(t, err)
+	// the OOMKilled termination leaves the window in 50 minutes, the other ones already left it
+	assert.InDelta(t, float64(50*time.Minute), float64(requeueAfter), float64(2*time.Second))
+
+	health := otelcol.Status.PodHealth
+	require.NotNil(t, health)
+	assert.Equal(t, int32(3), health.Total)
+	assert.Equal(t, int32(2), health.Ready)
+	assert.Equal(t, int32(4), health.Restarts)
+	assert.Equal(t, int32(1), health.RecentlyOOMKilled)
+	assert.Equal(t, int32(0), health.RecentlyCrashed)
+	assert.Equal(t, "2/3 pods ready, 1/3 pods OOMKilled in the last hour", health.Summary)
+
+	require.Len(t, health.Pods, 3)
+	assert.Equal(t, "test-a", health.Pods[0].Name)
+	assert.Equal(t, "OOMKilled", health.Pods[0].LastTerminationReason)
+	assert.Equal(t, "collector:0.1", health.Pods[0].Image)
+	assert.False(t, health.Pods[0].Ready)
+	assert.Equal(t, "test-b", health.Pods[1].Name)
+	assert.Equal(t, "test-c", health.Pods[2].Name)
+	assert.Nil(t, health.Pods[2].LastTerminationTime)
+}
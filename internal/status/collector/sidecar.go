@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/sidecar"
+)
+
+// updateSidecarStatus reports the pods the given sidecar instance was injected into, and which of them run an outdated sidecar.
+// Pods injected before the hash annotation was introduced are not considered stale, as the sidecar they run is unknown.
+func updateSidecarStatus(ctx context.Context, cli client.Client, cfg config.Config, changed *v1alpha1.OpenTelemetryCollector) error {
+	pods := &corev1.PodList{}
+	injected := naming.Truncate("%s.%s", 63, changed.Namespace, changed.Name)
+	if err := cli.List(ctx, pods, client.MatchingLabels{sidecar.InjectedLabel: injected}); err != nil {
+		return fmt.Errorf("failed to list the pods with an injected sidecar: %w", err)
+	}
+
+	status := &v1alpha1.SidecarStatus{}
+	// an invalid configuration is reported by the ConfigValid condition, no pod is considered stale then
+	currentHash, hashErr := sidecar.ConfigHash(cfg, *changed, changed.Namespace)
+	if hashErr == nil {
+		status.ConfigHash = currentHash
+	}
+	// the sidecar injected in other namespaces may differ, e.g. when the configuration is reloaded
+	hashes := map[string]string{changed.Namespace: currentHash}
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		status.InjectedPods++
+		podHash, annotated := pod.Annotations[sidecar.ConfigHashAnnotation]
+		if hashErr != nil || !annotated {
+			continue
+		}
+		expected, ok := hashes[pod.Namespace]
+		if !ok {
+			expected, _ = sidecar.ConfigHash(cfg, *changed, pod.Namespace)
+			hashes[pod.Namespace] = expected
+		}
+		if podHash == expected {
+			continue
+		}
+		status.StalePods++
+		status.StalePodNames = append(status.StalePodNames, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+	}
+
+	sort.Strings(status.StalePodNames)
+	if len(status.StalePodNames) > maxReportedPods {
+		status.StalePodNames = status.StalePodNames[:maxReportedPods]
+	}
+
+	changed.Status.Sidecar = status
+	return nil
+}
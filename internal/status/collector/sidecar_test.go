@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/sidecar"
+)
+
+func newInjectedPod(namespace, name, injected, hash string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{sidecar.InjectedLabel: injected},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "my-app"}, {Name: naming.Container()}},
+		},
+	}
+	if hash != "" {
+		pod.Annotations = map[string]string{sidecar.ConfigHashAnnotation: hash}
+	}
+	return pod
+}
+
+func TestUpdateSidecarStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "observability"},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:                v1alpha1.ModeSidecar,
+			SidecarConfigReload: true,
+			Config:              "receivers:\n  otlp:\n",
+		},
+	}
+	cfg := config.New(config.WithCollectorImage("collector:0.1"))
+	currentHash, err := sidecar.ConfigHash(cfg, otelcol, "observability")
+	require.NoError(t, err)
+	otherNamespaceHash, err := sidecar.ConfigHash(cfg, otelcol, "app-a")
+	require.NoError(t, err)
+
+	objects := []client.Object{
+		newInjectedPod("app-a", "up-to-date", "observability.test", otherNamespaceHash),
+		newInjectedPod("app-b", "outdated", "observability.test", "old-hash"),
+		newInjectedPod("app-a", "outdated", "observability.test", currentHash),
+		newInjectedPod("observability", "reloading", "observability.test", currentHash),
+		newInjectedPod("app-a", "unknown", "observability.test", ""),
+		newInjectedPod("app-a", "other-collector", "observability.other", "old-hash"),
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+	require.NoError(t, updateSidecarStatus(context.Background(), cli, cfg, &otelcol))
+
+	status := otelcol.Status.Sidecar
+	require.NotNil(t, status)
+	assert.Equal(t, currentHash, status.ConfigHash)
+	assert.Equal(t, int32(5), status.InjectedPods)
+	assert.Equal(t, int32(2), status.StalePods)
+	assert.Equal(t, []string{"app-a/outdated", "app-b/outdated"}, status.StalePodNames)
+}
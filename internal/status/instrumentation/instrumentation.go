@@ -0,0 +1,220 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/strings/slices"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
+)
+
+const (
+	reasonValid   = "Valid"
+	reasonInvalid = "Invalid"
+
+	// EventInvolvedObjectField is the field selecting the events recorded on an object.
+	EventInvolvedObjectField = "involvedObject.uid"
+
+	maxReportedWorkloads = 100
+	maxReportedFailures  = 10
+)
+
+// UpdateInstrumentationStatus reports the pods the given Instrumentation was injected into and the injections that
+// failed recently, read from the events recorded on it, along with whether it passed the given validation.
+func UpdateInstrumentationStatus(ctx context.Context, cli client.Client, events client.Reader, changed *v1alpha1.Instrumentation, validationErr error) error {
+	changed.Status.ObservedGeneration = changed.Generation
+	if validationErr != nil {
+		setCondition(changed, v1alpha1.InstrumentationConditionValid, metav1.ConditionFalse, reasonInvalid, validationErr.Error())
+	} else {
+		setCondition(changed, v1alpha1.InstrumentationConditionValid, metav1.ConditionTrue, reasonValid, "")
+	}
+
+	if err := updateInstrumentedPods(ctx, cli, changed); err != nil {
+		return err
+	}
+	return updateRecentFailures(ctx, events, changed)
+}
+
+// updateInstrumentedPods reports the pods instrumented with each language of the given Instrumentation, as recorded
+// in their annotations, along with their workloads and the auto-instrumentation images they run.
+func updateInstrumentedPods(ctx context.Context, cli client.Client, changed *v1alpha1.Instrumentation) error {
+	pods := &corev1.PodList{}
+	if err := cli.List(ctx, pods, client.MatchingLabels{instrumentation.InjectedLabel: "true"}); err != nil {
+		return fmt.Errorf("failed to list the instrumented pods: %w", err)
+	}
+
+	key := fmt.Sprintf("%s/%s", changed.Namespace, changed.Name)
+	workloads := workloadResolver{cli: cli, deployments: map[types.NamespacedName]*metav1.OwnerReference{}}
+	languages := map[string]*v1alpha1.InstrumentationLanguageStatus{}
+	langWorkloads := map[string]map[v1alpha1.InstrumentedWorkload]int32{}
+	var instrumented int32
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		images := instrumentation.InjectedImages(pod)
+		counted := false
+		for language, inst := range instrumentation.InjectedLanguages(pod) {
+			if inst != key {
+				continue
+			}
+			if !counted {
+				instrumented++
+				counted = true
+			}
+			status, ok := languages[language]
+			if !ok {
+				status = &v1alpha1.InstrumentationLanguageStatus{Language: language}
+				languages[language] = status
+				langWorkloads[language] = map[v1alpha1.InstrumentedWorkload]int32{}
+			}
+			status.Pods++
+			if image, ok := images[language]; ok && !slices.Contains(status.Images, image) {
+				status.Images = append(status.Images, image)
+			}
+			workload, err := workloads.workloadFor(ctx, pod)
+			if err != nil {
+				return err
+			}
+			langWorkloads[language][workload]++
+		}
+	}
+
+	changed.Status.InstrumentedPods = instrumented
+	changed.Status.Languages = nil
+	for language, status := range languages {
+		sort.Strings(status.Images)
+		for workload, count := range langWorkloads[language] {
+			workload.Pods = count
+			status.Workloads = append(status.Workloads, workload)
+		}
+		sort.Slice(status.Workloads, func(i, j int) bool {
+			a, b := status.Workloads[i], status.Workloads[j]
+			if a.Namespace != b.Namespace {
+				return a.Namespace < b.Namespace
+			}
+			if a.Kind != b.Kind {
+				return a.Kind < b.Kind
+			}
+			return a.Name < b.Name
+		})
+		if len(status.Workloads) > maxReportedWorkloads {
+			status.Workloads = status.Workloads[:maxReportedWorkloads]
+		}
+		changed.Status.Languages = append(changed.Status.Languages, *status)
+	}
+	sort.Slice(changed.Status.Languages, func(i, j int) bool {
+		return changed.Status.Languages[i].Language < changed.Status.Languages[j].Language
+	})
+	return nil
+}
+
+// updateRecentFailures reports the latest warning events recorded on the given Instrumentation by the pod mutator.
+func updateRecentFailures(ctx context.Context, events client.Reader, changed *v1alpha1.Instrumentation) error {
+	list := &corev1.EventList{}
+	if err := events.List(ctx, list, client.InNamespace(changed.Namespace), client.MatchingFields{EventInvolvedObjectField: string(changed.UID)}); err != nil {
+		return fmt.Errorf("failed to list the events of the instrumentation: %w", err)
+	}
+
+	var failures []v1alpha1.InjectionFailure
+	for _, event := range list.Items {
+		if event.Type != corev1.EventTypeWarning {
+			continue
+		}
+		count := event.Count
+		if count == 0 {
+			count = 1
+		}
+		failures = append(failures, v1alpha1.InjectionFailure{
+			Reason:        event.Reason,
+			Message:       event.Message,
+			Count:         count,
+			LastTimestamp: lastTimestamp(event),
+		})
+	}
+	sort.SliceStable(failures, func(i, j int) bool {
+		return failures[j].LastTimestamp.Before(&failures[i].LastTimestamp)
+	})
+	if len(failures) > maxReportedFailures {
+		failures = failures[:maxReportedFailures]
+	}
+	changed.Status.RecentFailures = failures
+	return nil
+}
+
+// lastTimestamp returns the time the event last happened, whichever of its timestamps is set.
+func lastTimestamp(event corev1.Event) metav1.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp
+	case !event.EventTime.IsZero():
+		return metav1.NewTime(event.EventTime.Time)
+	}
+	return event.CreationTimestamp
+}
+
+// workloadResolver resolves the workloads owning the pods, caching the deployments owning the replica sets.
+type workloadResolver struct {
+	cli         client.Client
+	deployments map[types.NamespacedName]*metav1.OwnerReference
+}
+
+// workloadFor returns the workload owning the given pod, following replica sets up to their deployment, or the pod
+// itself when it has no owner.
+func (r workloadResolver) workloadFor(ctx context.Context, pod corev1.Pod) (v1alpha1.InstrumentedWorkload, error) {
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		return v1alpha1.InstrumentedWorkload{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name}, nil
+	}
+	if owner.Kind == "ReplicaSet" {
+		key := types.NamespacedName{Namespace: pod.Namespace, Name: owner.Name}
+		deployment, ok := r.deployments[key]
+		if !ok {
+			rs := &appsv1.ReplicaSet{}
+			if err := r.cli.Get(ctx, key, rs); client.IgnoreNotFound(err) != nil {
+				return v1alpha1.InstrumentedWorkload{}, fmt.Errorf("failed to get the replica set of an instrumented pod: %w", err)
+			}
+			if rsOwner := metav1.GetControllerOf(rs); rsOwner != nil && rsOwner.Kind == "Deployment" {
+				deployment = rsOwner
+			}
+			r.deployments[key] = deployment
+		}
+		if deployment != nil {
+			owner = deployment
+		}
+	}
+	return v1alpha1.InstrumentedWorkload{Kind: owner.Kind, Namespace: pod.Namespace, Name: owner.Name}, nil
+}
+
+func setCondition(inst *v1alpha1.Instrumentation, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&inst.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: inst.Generation,
+	})
+}
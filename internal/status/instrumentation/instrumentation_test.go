@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
+)
+
+func newInstrumentedPod(namespace, name, languages, images string, owner *metav1.OwnerReference) *corev1.Pod {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+		Labels:    map[string]string{instrumentation.InjectedLabel: "true"},
+		Annotations: map[string]string{
+			instrumentation.InjectedLanguagesAnnotation: languages,
+			instrumentation.InjectedImagesAnnotation:    images,
+		},
+	}}
+	if owner != nil {
+		pod.OwnerReferences = []metav1.OwnerReference{*owner}
+	}
+	return pod
+}
+
+func newEvent(name, eventType, reason, message string, count int32, last time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "observability"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Instrumentation", Namespace: "observability", Name: "my-inst", UID: "inst-uid"},
+		Type:           eventType,
+		Reason:         reason,
+		Message:        message,
+		Count:          count,
+		LastTimestamp:  metav1.NewTime(last),
+	}
+}
+
+func TestUpdateInstrumentationStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	isController := true
+	rsOwner := &metav1.OwnerReference{Kind: "ReplicaSet", Name: "checkout-5d9f", Controller: &isController}
+	now := time.Now().Truncate(time.Second)
+	objects := []client.Object{
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name:            "checkout-5d9f",
+			Namespace:       "app",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "checkout", Controller: &isController}},
+		}},
+		newInstrumentedPod("app", "checkout-5d9f-a", "java=observability/my-inst,python=observability/my-inst", "java=java:2,python=python:1", rsOwner),
+		newInstrumentedPod("app", "checkout-5d9f-b", "java=observability/my-inst", "java=java:1", rsOwner),
+		newInstrumentedPod("app", "db-0", "java=observability/my-inst", "java=java:2", &metav1.OwnerReference{Kind: "StatefulSet", Name: "db", Controller: &isController}),
+		newInstrumentedPod("app", "debug", "python=observability/my-inst", "python=python:1", nil),
+		newInstrumentedPod("app", "other", "java=observability/other-inst", "java=java:2", nil),
+		newEvent("failed", corev1.EventTypeWarning, "InstrumentationInjectionFailed", "Deployment app/cart: failed", 3, now.Add(-time.Minute)),
+		newEvent("rejected", corev1.EventTypeWarning, "InstrumentationRequestRejected", "Deployment app/cart: rejected", 1, now),
+		newEvent("normal", corev1.EventTypeNormal, "Info", "unrelated", 1, now),
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).
+		WithIndex(&corev1.Event{}, EventInvolvedObjectField, func(obj client.Object) []string {
+			return []string{string(obj.(*corev1.Event).InvolvedObject.UID)}
+		}).Build()
+
+	inst := &v1alpha1.Instrumentation{ObjectMeta: metav1.ObjectMeta{Name: "my-inst", Namespace: "observability", UID: "inst-uid", Generation: 2}}
+
+	// test
+	err := UpdateInstrumentationStatus(context.Background(), cli, cli, inst, nil)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), inst.Status.ObservedGeneration)
+	assert.Equal(t, int32(4), inst.Status.InstrumentedPods)
+	assert.Equal(t, []v1alpha1.InstrumentationLanguageStatus{
+		{
+			Language: "java",
+			Pods:     3,
+			Images:   []string{"java:1", "java:2"},
+			Workloads: []v1alpha1.InstrumentedWorkload{
+				{Kind: "Deployment", Namespace: "app", Name: "checkout", Pods: 2},
+				{Kind: "StatefulSet", Namespace: "app", Name: "db", Pods: 1},
+			},
+		},
+		{
+			Language: "python",
+			Pods:     2,
+			Images:   []string{"python:1"},
+			Workloads: []v1alpha1.InstrumentedWorkload{
+				{Kind: "Deployment", Namespace: "app", Name: "checkout", Pods: 1},
+				{Kind: "Pod", Namespace: "app", Name: "debug", Pods: 1},
+			},
+		},
+	}, inst.Status.Languages)
+	assert.Equal(t, []v1alpha1.InjectionFailure{
+		{Reason: "InstrumentationRequestRejected", Message: "Deployment app/cart: rejected", Count: 1, LastTimestamp: metav1.NewTime(now)},
+		{Reason: "InstrumentationInjectionFailed", Message: "Deployment app/cart: failed", Count: 3, LastTimestamp: metav1.NewTime(now.Add(-time.Minute))},
+	}, inst.Status.RecentFailures)
+	assert.True(t, meta.IsStatusConditionTrue(inst.Status.Conditions, v1alpha1.InstrumentationConditionValid))
+}
+
+func TestUpdateInstrumentationStatusInvalid(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	cli := fake.NewClientBuilder().WithScheme(scheme).
+		WithIndex(&corev1.Event{}, EventInvolvedObjectField, func(obj client.Object) []string {
+			return []string{string(obj.(*corev1.Event).InvolvedObject.UID)}
+		}).Build()
+	inst := &v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-inst", Namespace: "observability", UID: "inst-uid"},
+		Status:     v1alpha1.InstrumentationStatus{InstrumentedPods: 1, Languages: []v1alpha1.InstrumentationLanguageStatus{{Language: "java", Pods: 1}}},
+	}
+
+	// test
+	err := UpdateInstrumentationStatus(context.Background(), cli, cli, inst, errors.New("spec.sampler.type is not valid: foo"))
+
+	// verify
+	require.NoError(t, err)
+	assert.Zero(t, inst.Status.InstrumentedPods)
+	assert.Empty(t, inst.Status.Languages)
+	assert.Empty(t, inst.Status.RecentFailures)
+	valid := meta.FindStatusCondition(inst.Status.Conditions, v1alpha1.InstrumentationConditionValid)
+	require.NotNil(t, valid)
+	assert.Equal(t, metav1.ConditionFalse, valid.Status)
+	assert.Equal(t, "spec.sampler.type is not valid: foo", valid.Message)
+}
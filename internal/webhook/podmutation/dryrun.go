@@ -0,0 +1,296 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podmutation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"gomodules.xyz/jsonpatch/v2"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// MutatorsFactory creates the PodMutators run by the webhook, logging to the given logger and recording their events
+// with the given recorder.
+type MutatorsFactory func(logger logr.Logger, recorder record.EventRecorder) []PodMutator
+
+// DryRunRequest is the body of the requests of the dry run handler.
+type DryRunRequest struct {
+	// Namespace is the namespace the pod would be created in, the pod's namespace by default.
+	Namespace string `json:"namespace,omitempty"`
+	// Pod is the pod as it would be sent to the webhook.
+	Pod corev1.Pod `json:"pod"`
+}
+
+// DryRunResponse is the body of the responses of the dry run handler.
+type DryRunResponse struct {
+	// Allowed is false when the pod would be rejected, because of the injection failure policy.
+	Allowed bool `json:"allowed"`
+	// Rejection is the reason the pod would be rejected for.
+	Rejection string `json:"rejection,omitempty"`
+	// Error is the error the pod would be admitted unchanged with.
+	Error string `json:"error,omitempty"`
+	// Patch is the JSON patch the webhook would respond with.
+	Patch []jsonpatch.JsonPatchOperation `json:"patch,omitempty"`
+	// Trace holds the decisions logged by the mutators, in order.
+	Trace []DryRunTraceEntry `json:"trace"`
+	// Events are the events the mutators would have recorded.
+	Events []string `json:"events,omitempty"`
+}
+
+// DryRunTraceEntry is a message logged by a mutator during a dry run.
+type DryRunTraceEntry struct {
+	Logger  string            `json:"logger,omitempty"`
+	Message string            `json:"message"`
+	Error   string            `json:"error,omitempty"`
+	Values  map[string]string `json:"values,omitempty"`
+}
+
+// dryRunHandler runs the mutators of the webhook against a pod without admitting it.
+type dryRunHandler struct {
+	client   client.Client
+	logger   logr.Logger
+	mutators MutatorsFactory
+}
+
+// NewDryRunHandler creates the HTTP handler previewing the mutations of the pods posted to it, see DryRunRequest.
+// The mutators are created for each request, so that their logs are traced and their events aren't recorded.
+// As the mutations can hold the configuration of the injected collectors, the callers are authenticated with their
+// bearer token, and need to be allowed to create the pod in its namespace.
+func NewDryRunHandler(logger logr.Logger, cl client.Client, mutators MutatorsFactory) http.Handler {
+	return &dryRunHandler{
+		client:   cl,
+		logger:   logger,
+		mutators: mutators,
+	}
+}
+
+func (h *dryRunHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	user, err := h.authenticate(r)
+	if err != nil {
+		h.logger.Error(err, "failed to authenticate the dry run request")
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	req := DryRunRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode the request: %s", err), http.StatusBadRequest)
+		return
+	}
+	if req.Namespace == "" {
+		req.Namespace = req.Pod.Namespace
+	}
+	if req.Namespace == "" {
+		http.Error(w, "the namespace of the pod isn't set", http.StatusBadRequest)
+		return
+	}
+	if err := h.authorize(r.Context(), user, req.Namespace); err != nil {
+		h.logger.Error(err, "failed to authorize the dry run request", "user", user.Username, "namespace", req.Namespace)
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	res, err := h.dryRun(r.Context(), req)
+	if err != nil {
+		h.logger.Error(err, "failed to run the pod mutators", "namespace", req.Namespace, "name", req.Pod.Name)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		h.logger.Error(err, "failed to write the dry run response")
+	}
+}
+
+// authenticate returns the user whose bearer token authorizes the request, reviewed by the API server.
+func (h *dryRunHandler) authenticate(r *http.Request) (authenticationv1.UserInfo, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return authenticationv1.UserInfo{}, errors.New("a bearer token is required")
+	}
+	review := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}
+	if err := h.client.Create(r.Context(), review); err != nil {
+		return authenticationv1.UserInfo{}, fmt.Errorf("failed to review the token: %w", err)
+	}
+	if !review.Status.Authenticated {
+		return authenticationv1.UserInfo{}, fmt.Errorf("the token isn't valid: %s", review.Status.Error)
+	}
+	return review.Status.User, nil
+}
+
+// authorize checks that the given user may create pods in the given namespace, the dry run previewing their creation.
+func (h *dryRunHandler) authorize(ctx context.Context, user authenticationv1.UserInfo, namespace string) error {
+	extra := map[string]authorizationv1.ExtraValue{}
+	for key, values := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(values)
+	}
+	review := &authorizationv1.SubjectAccessReview{Spec: authorizationv1.SubjectAccessReviewSpec{
+		User:   user.Username,
+		UID:    user.UID,
+		Groups: user.Groups,
+		Extra:  extra,
+		ResourceAttributes: &authorizationv1.ResourceAttributes{
+			Namespace: namespace,
+			Verb:      "create",
+			Resource:  "pods",
+		},
+	}}
+	if err := h.client.Create(ctx, review); err != nil {
+		return fmt.Errorf("failed to review the access: %w", err)
+	}
+	if !review.Status.Allowed {
+		return fmt.Errorf("%s isn't allowed to create pods in the namespace %s", user.Username, namespace)
+	}
+	return nil
+}
+
+// dryRun runs the mutators against the pod of the request like the webhook does.
+func (h *dryRunHandler) dryRun(ctx context.Context, req DryRunRequest) (DryRunResponse, error) {
+	ns := corev1.Namespace{}
+	if err := h.client.Get(ctx, types.NamespacedName{Name: req.Namespace}, &ns); err != nil {
+		return DryRunResponse{}, fmt.Errorf("failed to get the namespace %s: %w", req.Namespace, err)
+	}
+	original, err := json.Marshal(req.Pod)
+	if err != nil {
+		return DryRunResponse{}, err
+	}
+
+	trace := &dryRunTrace{}
+	recorder := &dryRunRecorder{}
+	res := DryRunResponse{Allowed: true}
+	pod := req.Pod
+	for _, m := range h.mutators(logr.New(&traceSink{trace: trace}), recorder) {
+		pod, err = m.Mutate(ctx, ns, pod)
+		if err != nil {
+			break
+		}
+	}
+	res.Trace = trace.entries
+	res.Events = recorder.events
+
+	if err != nil {
+		var rejection *RejectionError
+		if errors.As(err, &rejection) {
+			res.Allowed = false
+			res.Rejection = rejection.Error()
+		} else {
+			res.Error = err.Error()
+		}
+		return res, nil
+	}
+
+	mutated, err := json.Marshal(pod)
+	if err != nil {
+		return DryRunResponse{}, err
+	}
+	res.Patch = admission.PatchResponseFromRaw(original, mutated).Patches
+	return res, nil
+}
+
+var _ record.EventRecorder = (*dryRunRecorder)(nil)
+
+// dryRunRecorder is a record.EventRecorder keeping the events of a dry run, as "<type> <reason> <message>", without
+// recording them. Unlike record.FakeRecorder, it never blocks, however many events are recorded.
+type dryRunRecorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *dryRunRecorder) Event(_ runtime.Object, eventtype, reason, message string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, fmt.Sprintf("%s %s %s", eventtype, reason, message))
+}
+
+func (r *dryRunRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *dryRunRecorder) AnnotatedEventf(object runtime.Object, _ map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Eventf(object, eventtype, reason, messageFmt, args...)
+}
+
+// dryRunTrace holds the entries logged by the mutators of a dry run.
+type dryRunTrace struct {
+	entries []DryRunTraceEntry
+}
+
+var _ logr.LogSink = (*traceSink)(nil)
+
+// traceSink is a logr.LogSink adding the messages of all the levels to a dryRunTrace.
+type traceSink struct {
+	trace  *dryRunTrace
+	name   string
+	values []interface{}
+}
+
+func (s *traceSink) Init(logr.RuntimeInfo) {}
+
+func (s *traceSink) Enabled(int) bool { return true }
+
+func (s *traceSink) Info(_ int, msg string, keysAndValues ...interface{}) {
+	s.add(msg, nil, keysAndValues)
+}
+
+func (s *traceSink) Error(err error, msg string, keysAndValues ...interface{}) {
+	s.add(msg, err, keysAndValues)
+}
+
+func (s *traceSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	values := append(append([]interface{}{}, s.values...), keysAndValues...)
+	return &traceSink{trace: s.trace, name: s.name, values: values}
+}
+
+func (s *traceSink) WithName(name string) logr.LogSink {
+	if s.name != "" {
+		name = s.name + "." + name
+	}
+	return &traceSink{trace: s.trace, name: name, values: s.values}
+}
+
+func (s *traceSink) add(msg string, err error, keysAndValues []interface{}) {
+	entry := DryRunTraceEntry{Logger: s.name, Message: msg}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	values := append(append([]interface{}{}, s.values...), keysAndValues...)
+	for i := 0; i+1 < len(values); i += 2 {
+		if entry.Values == nil {
+			entry.Values = map[string]string{}
+		}
+		entry.Values[fmt.Sprint(values[i])] = fmt.Sprint(values[i+1])
+	}
+	s.trace.entries = append(s.trace.entries, entry)
+}
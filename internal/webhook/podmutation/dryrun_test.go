@@ -0,0 +1,248 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podmutation_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gomodules.xyz/jsonpatch/v2"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	. "github.com/open-telemetry/opentelemetry-operator/internal/webhook/podmutation"
+)
+
+// labelMutator labels the pods of the namespaces having the label, and fails for the other ones.
+type labelMutator struct {
+	logger   logr.Logger
+	reporter *FailureReporter
+}
+
+func (m labelMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod corev1.Pod) (corev1.Pod, error) {
+	logger := m.logger.WithValues("namespace", ns.Name)
+	if ns.Labels["inject"] != "true" {
+		err := errors.New("injection not enabled")
+		logger.Error(err, "skipping injection")
+		return pod, m.reporter.Report(ctx, ns, pod, FailurePolicy(ns), "InjectionFailed", err)
+	}
+	logger.V(1).Info("injecting label")
+	pod.Labels = map[string]string{"injected": "true"}
+	return pod, nil
+}
+
+// reviewingClient reviews the token "valid-token" as the user "developer", allowed to create pods in all the
+// namespaces but "restricted".
+func reviewingClient(objs ...client.Object) client.Client {
+	return fake.NewClientBuilder().WithObjects(objs...).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			switch review := obj.(type) {
+			case *authenticationv1.TokenReview:
+				review.Status.Authenticated = review.Spec.Token == "valid-token"
+				if review.Status.Authenticated {
+					review.Status.User = authenticationv1.UserInfo{Username: "developer"}
+				}
+			case *authorizationv1.SubjectAccessReview:
+				attributes := review.Spec.ResourceAttributes
+				review.Status.Allowed = review.Spec.User == "developer" && attributes.Verb == "create" &&
+					attributes.Resource == "pods" && attributes.Namespace != "restricted"
+			default:
+				return cl.Create(ctx, obj, opts...)
+			}
+			return nil
+		},
+	}).Build()
+}
+
+func TestDryRun(t *testing.T) {
+	cli := reviewingClient(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "enabled", Labels: map[string]string{"inject": "true"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "disabled"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:        "strict",
+			Annotations: map[string]string{InjectionFailurePolicyAnnotation: string(v1alpha1.InjectionFailurePolicyFail)},
+		}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "restricted", Labels: map[string]string{"inject": "true"}}},
+	)
+	handler := NewDryRunHandler(logr.Discard(), cli, func(logger logr.Logger, recorder record.EventRecorder) []PodMutator {
+		return []PodMutator{labelMutator{logger: logger.WithName("label"), reporter: NewFailureReporter(cli, recorder)}}
+	})
+
+	tests := []struct {
+		name     string
+		method   string
+		token    string
+		body     string
+		status   int
+		expected DryRunResponse
+	}{
+		{
+			name:   "mutated pod",
+			method: http.MethodPost,
+			token:  "valid-token",
+			body:   `{"namespace": "enabled", "pod": {"metadata": {"name": "my-app"}}}`,
+			status: http.StatusOK,
+			expected: DryRunResponse{
+				Allowed: true,
+				Patch: []jsonpatch.JsonPatchOperation{
+					{Operation: "add", Path: "/metadata/labels", Value: map[string]interface{}{"injected": "true"}},
+				},
+				Trace: []DryRunTraceEntry{
+					{Logger: "label", Message: "injecting label", Values: map[string]string{"namespace": "enabled"}},
+				},
+			},
+		},
+		{
+			name:   "failed injection",
+			method: http.MethodPost,
+			token:  "valid-token",
+			body:   `{"pod": {"metadata": {"name": "my-app", "namespace": "disabled"}}}`,
+			status: http.StatusOK,
+			expected: DryRunResponse{
+				Allowed: true,
+				Trace: []DryRunTraceEntry{
+					{Logger: "label", Message: "skipping injection", Error: "injection not enabled", Values: map[string]string{"namespace": "disabled"}},
+				},
+				Events: []string{"Warning InjectionFailed injection not enabled"},
+			},
+		},
+		{
+			name:   "rejected pod",
+			method: http.MethodPost,
+			token:  "valid-token",
+			body:   `{"namespace": "strict", "pod": {"metadata": {"name": "my-app"}}}`,
+			status: http.StatusOK,
+			expected: DryRunResponse{
+				Allowed:   false,
+				Rejection: "InjectionFailed: injection not enabled",
+				Trace: []DryRunTraceEntry{
+					{Logger: "label", Message: "skipping injection", Error: "injection not enabled", Values: map[string]string{"namespace": "strict"}},
+				},
+				Events: []string{"Warning InjectionFailed pod rejected: injection not enabled"},
+			},
+		},
+		{
+			name:   "unknown namespace",
+			method: http.MethodPost,
+			token:  "valid-token",
+			body:   `{"namespace": "unknown", "pod": {}}`,
+			status: http.StatusInternalServerError,
+		},
+		{
+			name:   "no namespace",
+			method: http.MethodPost,
+			token:  "valid-token",
+			body:   `{"pod": {}}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "invalid request",
+			method: http.MethodPost,
+			token:  "valid-token",
+			body:   `{"pod": []}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "no token",
+			method: http.MethodPost,
+			body:   `{"namespace": "enabled", "pod": {}}`,
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "invalid token",
+			method: http.MethodPost,
+			token:  "invalid-token",
+			body:   `{"namespace": "enabled", "pod": {}}`,
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "not allowed to create pods in the namespace",
+			method: http.MethodPost,
+			token:  "valid-token",
+			body:   `{"namespace": "restricted", "pod": {}}`,
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "not a POST",
+			method: http.MethodGet,
+			status: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, "/dry-run-v1-pod", bytes.NewBufferString(tt.body))
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.status, rec.Code, rec.Body.String())
+			if tt.status != http.StatusOK {
+				return
+			}
+			res := DryRunResponse{}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+// eventsMutator records the given number of events.
+type eventsMutator struct {
+	recorder record.EventRecorder
+	events   int
+}
+
+func (m eventsMutator) Mutate(_ context.Context, _ corev1.Namespace, pod corev1.Pod) (corev1.Pod, error) {
+	for i := 0; i < m.events; i++ {
+		m.recorder.Eventf(&pod, corev1.EventTypeNormal, "Mutated", "event %d", i)
+	}
+	return pod, nil
+}
+
+func TestDryRunManyEvents(t *testing.T) {
+	cli := reviewingClient(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "enabled"}})
+	handler := NewDryRunHandler(logr.Discard(), cli, func(_ logr.Logger, recorder record.EventRecorder) []PodMutator {
+		return []PodMutator{eventsMutator{recorder: recorder, events: 1000}}
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/dry-run-v1-pod", bytes.NewBufferString(`{"namespace": "enabled", "pod": {}}`))
+	req.Header.Set("Authorization", "Bearer valid-token")
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	res := DryRunResponse{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Len(t, res.Events, 1000)
+	assert.Equal(t, "Normal Mutated event 999", res.Events[999])
+}
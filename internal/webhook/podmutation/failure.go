@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podmutation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// InjectionFailurePolicyAnnotation is the namespace annotation setting the injection failure policy of its pods.
+const InjectionFailurePolicyAnnotation = "opentelemetry.io/injection-failure-policy"
+
+// RejectionError is returned by a PodMutator to have the webhook reject the pod.
+type RejectionError struct {
+	Reason string
+	Err    error
+}
+
+func (e *RejectionError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Reason, e.Err)
+}
+
+func (e *RejectionError) Unwrap() error {
+	return e.Err
+}
+
+// FailurePolicy returns the strictest of the namespace's policy and the given ones.
+func FailurePolicy(ns corev1.Namespace, policies ...v1alpha1.InjectionFailurePolicy) v1alpha1.InjectionFailurePolicy {
+	policies = append(policies, v1alpha1.InjectionFailurePolicy(strings.ToLower(ns.Annotations[InjectionFailurePolicyAnnotation])))
+	for _, policy := range policies {
+		if policy == v1alpha1.InjectionFailurePolicyFail {
+			return v1alpha1.InjectionFailurePolicyFail
+		}
+	}
+	return v1alpha1.InjectionFailurePolicyIgnore
+}
+
+// FailureReporter reports the injections that failed on the workloads owning the pods.
+type FailureReporter struct {
+	client   client.Client
+	recorder record.EventRecorder
+}
+
+// NewFailureReporter creates a new FailureReporter.
+func NewFailureReporter(cl client.Client, recorder record.EventRecorder) *FailureReporter {
+	return &FailureReporter{
+		client:   cl,
+		recorder: recorder,
+	}
+}
+
+// Report records a warning event with the given reason on the workload owning the pod, or on the pod itself when
+// it has no owner, and on the given related objects, e.g. the Instrumentations the injection was requested from.
+// It returns a RejectionError when the policy requires the pod to be rejected, nil otherwise.
+func (r *FailureReporter) Report(ctx context.Context, ns corev1.Namespace, pod corev1.Pod, policy v1alpha1.InjectionFailurePolicy, reason string, err error, related ...runtime.Object) error {
+	message := err.Error()
+	if policy == v1alpha1.InjectionFailurePolicyFail {
+		message = fmt.Sprintf("pod rejected: %s", message)
+	}
+	workload := r.workloadFor(ctx, ns, pod)
+	r.recorder.Event(workload, corev1.EventTypeWarning, reason, message)
+	for _, obj := range related {
+		r.recorder.Eventf(obj, corev1.EventTypeWarning, reason, "%s: %s", describe(workload), message)
+	}
+
+	if policy != v1alpha1.InjectionFailurePolicyFail {
+		return nil
+	}
+	return &RejectionError{Reason: reason, Err: err}
+}
+
+// workloadFor returns the object owning the pod, following replica sets up to their deployment.
+func (r *FailureReporter) workloadFor(ctx context.Context, ns corev1.Namespace, pod corev1.Pod) runtime.Object {
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		if len(pod.OwnerReferences) == 0 {
+			p := pod.DeepCopy()
+			p.Namespace = ns.Name
+			if p.Name == "" {
+				// the pods created with a generated name aren't named yet when they're admitted
+				p.Name = p.GenerateName
+			}
+			return p
+		}
+		owner = &pod.OwnerReferences[0]
+	}
+
+	if owner.Kind == "ReplicaSet" {
+		rs := appsv1.ReplicaSet{}
+		if err := r.client.Get(ctx, types.NamespacedName{Name: owner.Name, Namespace: ns.Name}, &rs); err == nil {
+			if deployment := metav1.GetControllerOf(&rs); deployment != nil && deployment.Kind == "Deployment" {
+				owner = deployment
+			}
+		}
+	}
+
+	return &metav1.PartialObjectMetadata{
+		TypeMeta: metav1.TypeMeta{APIVersion: owner.APIVersion, Kind: owner.Kind},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      owner.Name,
+			Namespace: ns.Name,
+			UID:       owner.UID,
+		},
+	}
+}
+
+// describe returns the kind, namespace and name of the given workload, as returned by workloadFor.
+func describe(workload runtime.Object) string {
+	switch w := workload.(type) {
+	case *corev1.Pod:
+		return fmt.Sprintf("Pod %s/%s", w.Namespace, w.Name)
+	case *metav1.PartialObjectMetadata:
+		return fmt.Sprintf("%s %s/%s", w.Kind, w.Namespace, w.Name)
+	}
+	return ""
+}
@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podmutation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	. "github.com/open-telemetry/opentelemetry-operator/internal/webhook/podmutation"
+)
+
+func TestFailurePolicy(t *testing.T) {
+	failNs := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        "my-ns",
+		Annotations: map[string]string{InjectionFailurePolicyAnnotation: "Fail"},
+	}}
+	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "my-ns"}}
+
+	assert.Equal(t, v1alpha1.InjectionFailurePolicyIgnore, FailurePolicy(ns))
+	assert.Equal(t, v1alpha1.InjectionFailurePolicyIgnore, FailurePolicy(ns, "", v1alpha1.InjectionFailurePolicyIgnore))
+	assert.Equal(t, v1alpha1.InjectionFailurePolicyFail, FailurePolicy(ns, v1alpha1.InjectionFailurePolicyIgnore, v1alpha1.InjectionFailurePolicyFail))
+	assert.Equal(t, v1alpha1.InjectionFailurePolicyFail, FailurePolicy(failNs, v1alpha1.InjectionFailurePolicyIgnore))
+}
+
+func TestReportFailure(t *testing.T) {
+	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "my-ns"}}
+	isController := true
+	rs := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-app-6d7f8b",
+		Namespace: "my-ns",
+		OwnerReferences: []metav1.OwnerReference{
+			{APIVersion: "apps/v1", Kind: "Deployment", Name: "my-app", UID: "deployment-uid", Controller: &isController},
+		},
+	}}
+	cli := fake.NewClientBuilder().WithObjects(rs).Build()
+	injectionErr := errors.New("no OpenTelemetry Collector instances available")
+
+	for _, tt := range []struct {
+		desc      string
+		pod       corev1.Pod
+		policy    v1alpha1.InjectionFailurePolicy
+		event     string
+		rejection bool
+	}{
+		{
+			desc: "deployment pod",
+			pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "my-app-6d7f8b-",
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "my-app-6d7f8b", Controller: &isController},
+				},
+			}},
+			policy: v1alpha1.InjectionFailurePolicyIgnore,
+			event:  "Warning SidecarInjectionFailed no OpenTelemetry Collector instances available involvedObject{kind=Deployment,apiVersion=apps/v1}",
+		},
+		{
+			desc: "rejected statefulset pod",
+			pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Name: "my-db-0",
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "my-db", Controller: &isController},
+				},
+			}},
+			policy:    v1alpha1.InjectionFailurePolicyFail,
+			event:     "Warning SidecarInjectionFailed pod rejected: no OpenTelemetry Collector instances available involvedObject{kind=StatefulSet,apiVersion=apps/v1}",
+			rejection: true,
+		},
+		{
+			desc: "bare pod",
+			pod: corev1.Pod{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+				ObjectMeta: metav1.ObjectMeta{Name: "my-pod"},
+			},
+			policy: v1alpha1.InjectionFailurePolicyIgnore,
+			event:  "Warning SidecarInjectionFailed no OpenTelemetry Collector instances available involvedObject{kind=Pod,apiVersion=v1}",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(1)
+			recorder.IncludeObject = true
+			reporter := NewFailureReporter(cli, recorder)
+
+			err := reporter.Report(context.Background(), ns, tt.pod, tt.policy, "SidecarInjectionFailed", injectionErr)
+
+			if tt.rejection {
+				var rejection *RejectionError
+				require.ErrorAs(t, err, &rejection)
+				assert.ErrorIs(t, err, injectionErr)
+				assert.Equal(t, "SidecarInjectionFailed", rejection.Reason)
+			} else {
+				assert.NoError(t, err)
+			}
+			require.Len(t, recorder.Events, 1)
+			assert.Equal(t, tt.event, <-recorder.Events)
+		})
+	}
+}
+
+func TestReportFailureRelated(t *testing.T) {
+	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "my-ns"}}
+	inst := &v1alpha1.Instrumentation{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "opentelemetry.io/v1alpha1", Kind: "Instrumentation"},
+		ObjectMeta: metav1.ObjectMeta{Name: "my-inst", Namespace: "my-ns"},
+	}
+	pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{GenerateName: "my-job-"}}
+	recorder := record.NewFakeRecorder(2)
+	recorder.IncludeObject = true
+	reporter := NewFailureReporter(fake.NewClientBuilder().Build(), recorder)
+
+	err := reporter.Report(context.Background(), ns, pod, v1alpha1.InjectionFailurePolicyIgnore, "InstrumentationInjectionFailed", errors.New("no OpenTelemetry Instrumentation instances available"), inst)
+
+	assert.NoError(t, err)
+	require.Len(t, recorder.Events, 2)
+	assert.Equal(t, "Warning InstrumentationInjectionFailed no OpenTelemetry Instrumentation instances available involvedObject{kind=,apiVersion=}", <-recorder.Events)
+	assert.Equal(t, "Warning InstrumentationInjectionFailed Pod my-ns/my-job-: no OpenTelemetry Instrumentation instances available involvedObject{kind=Instrumentation,apiVersion=opentelemetry.io/v1alpha1}", <-recorder.Events)
+}
+
+// objectRecorder records the objects the events are recorded on.
+type objectRecorder struct {
+	objects []runtime.Object
+}
+
+func (r *objectRecorder) Event(object runtime.Object, _, _, _ string) {
+	r.objects = append(r.objects, object)
+}
+
+func (r *objectRecorder) Eventf(object runtime.Object, _, _, _ string, _ ...interface{}) {
+	r.objects = append(r.objects, object)
+}
+
+func (r *objectRecorder) AnnotatedEventf(object runtime.Object, _ map[string]string, _, _, _ string, _ ...interface{}) {
+	r.objects = append(r.objects, object)
+}
+
+func TestReportFailureGeneratedName(t *testing.T) {
+	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "my-ns"}}
+	pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{GenerateName: "my-job-"}}
+	recorder := &objectRecorder{}
+	reporter := NewFailureReporter(fake.NewClientBuilder().Build(), recorder)
+
+	err := reporter.Report(context.Background(), ns, pod, v1alpha1.InjectionFailurePolicyIgnore, "InstrumentationInjectionFailed", errors.New("no OpenTelemetry Instrumentation instances available"))
+
+	assert.NoError(t, err)
+	require.Len(t, recorder.objects, 1)
+	// the event names are built from the names of the objects, which can't be empty
+	recorded, ok := recorder.objects[0].(*corev1.Pod)
+	require.True(t, ok)
+	assert.Equal(t, "my-job-", recorded.Name)
+	assert.Equal(t, "my-ns", recorded.Namespace)
+}
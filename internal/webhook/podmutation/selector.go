@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podmutation
+
+import (
+	"k8s.io/utils/strings/slices"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+// SelectorNamespaces returns the namespaces of the resources whose selectors may select the pods of the given
+// namespace: its own namespace, then the ones the operator's configuration allows to select the pods of other
+// namespaces. Listing the resources of these namespaces only avoids a cluster-wide lookup on every admission.
+func SelectorNamespaces(cfg config.Config, namespace string) []string {
+	namespaces := []string{namespace}
+	for _, allowed := range cfg.CrossNamespaceSelectorNamespaces() {
+		if !slices.Contains(namespaces, allowed) {
+			namespaces = append(namespaces, allowed)
+		}
+	}
+	return namespaces
+}
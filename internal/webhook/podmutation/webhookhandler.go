@@ -18,9 +18,11 @@ package podmutation
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/go-logr/logr"
+	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -91,6 +93,12 @@ func (p *podMutationWebhook) Handle(ctx context.Context, req admission.Request)
 	for _, m := range p.podMutators {
 		pod, err = m.Mutate(ctx, ns, pod)
 		if err != nil {
+			var rejection *RejectionError
+			if errors.As(err, &rejection) && req.Operation == admissionv1.Create {
+				// the injection failure policy requires the pod to be rejected. The pods already running, e.g.
+				// admitted while the operator was down, are never rejected: that would prevent their clean-up.
+				return admission.Denied(rejection.Error())
+			}
 			res := admission.Errored(http.StatusInternalServerError, err)
 			res.Allowed = true
 			return res
@@ -17,6 +17,7 @@ package podmutation_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"testing"
 
@@ -26,7 +27,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/kubectl/pkg/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -152,7 +155,7 @@ func TestShouldInjectSidecar(t *testing.T) {
 			// the webhook handler
 			cfg := config.New()
 			decoder := admission.NewDecoder(scheme.Scheme)
-			injector := NewWebhookHandler(cfg, logger, decoder, k8sClient, []PodMutator{sidecar.NewMutator(logger, cfg, k8sClient)})
+			injector := NewWebhookHandler(cfg, logger, decoder, k8sClient, []PodMutator{sidecar.NewMutator(logger, cfg, k8sClient, record.NewFakeRecorder(100))})
 
 			// test
 			res := injector.Handle(context.Background(), req)
@@ -371,7 +374,7 @@ func TestPodShouldNotBeChanged(t *testing.T) {
 			// the webhook handler
 			cfg := config.New()
 			decoder := admission.NewDecoder(scheme.Scheme)
-			injector := NewWebhookHandler(cfg, logger, decoder, k8sClient, []PodMutator{sidecar.NewMutator(logger, cfg, k8sClient)})
+			injector := NewWebhookHandler(cfg, logger, decoder, k8sClient, []PodMutator{sidecar.NewMutator(logger, cfg, k8sClient, record.NewFakeRecorder(100))})
 			require.NoError(t, err)
 
 			// test
@@ -429,7 +432,7 @@ func TestFailOnInvalidRequest(t *testing.T) {
 			// prepare
 			cfg := config.New()
 			decoder := admission.NewDecoder(scheme.Scheme)
-			injector := NewWebhookHandler(cfg, logger, decoder, k8sClient, []PodMutator{sidecar.NewMutator(logger, cfg, k8sClient)})
+			injector := NewWebhookHandler(cfg, logger, decoder, k8sClient, []PodMutator{sidecar.NewMutator(logger, cfg, k8sClient, record.NewFakeRecorder(100))})
 
 			// test
 			res := injector.Handle(context.Background(), tt.req)
@@ -441,3 +444,37 @@ func TestFailOnInvalidRequest(t *testing.T) {
 		})
 	}
 }
+
+type rejectingMutator struct{}
+
+func (rejectingMutator) Mutate(context.Context, corev1.Namespace, corev1.Pod) (corev1.Pod, error) {
+	return corev1.Pod{}, &RejectionError{Reason: "SidecarInjectionFailed", Err: errors.New("no OpenTelemetry Collector instances available")}
+}
+
+func TestRejectOnlyOnCreate(t *testing.T) {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "my-ns"}}
+	encoded, err := json.Marshal(corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "my-pod", Namespace: "my-ns"}})
+	require.NoError(t, err)
+	injector := NewWebhookHandler(config.New(), logger, admission.NewDecoder(scheme.Scheme), fake.NewClientBuilder().WithObjects(ns).Build(), []PodMutator{rejectingMutator{}})
+
+	for _, tt := range []struct {
+		operation admv1.Operation
+		allowed   bool
+	}{
+		{operation: admv1.Create, allowed: false},
+		// a pod admitted without the injection, e.g. while the operator was down, can still be updated
+		{operation: admv1.Update, allowed: true},
+	} {
+		t.Run(string(tt.operation), func(t *testing.T) {
+			res := injector.Handle(context.Background(), admission.Request{
+				AdmissionRequest: admv1.AdmissionRequest{
+					Operation: tt.operation,
+					Namespace: "my-ns",
+					Object:    runtime.RawExtension{Raw: encoded},
+				},
+			})
+
+			assert.Equal(t, tt.allowed, res.Allowed)
+		})
+	}
+}
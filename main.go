@@ -24,6 +24,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/go-logr/logr"
 	routev1 "github.com/openshift/api/route/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/spf13/pflag"
@@ -42,6 +43,8 @@ import (
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	otelv1alpha1 "github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/controllers"
@@ -72,6 +75,8 @@ func init() {
 	utilruntime.Must(otelv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(routev1.AddToScheme(scheme))
 	utilruntime.Must(monitoringv1.AddToScheme(scheme))
+	utilruntime.Must(gatewayv1beta1.AddToScheme(scheme))
+	utilruntime.Must(gatewayv1alpha2.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -101,17 +106,24 @@ func main() {
 		probeAddr                      string
 		pprofAddr                      string
 		enableLeaderElection           bool
+		enableInjectionDryRun          bool
 		collectorImage                 string
 		targetAllocatorImage           string
 		operatorOpAMPBridgeImage       string
+		sidecarConfigReloaderImage     string
+		persistenceInitImage           string
 		autoInstrumentationJava        string
 		autoInstrumentationNodeJS      string
 		autoInstrumentationPython      string
 		autoInstrumentationDotNet      string
 		autoInstrumentationApacheHttpd string
 		autoInstrumentationNginx       string
+		autoInstrumentationRuby        string
+		autoInstrumentationPHP         string
 		autoInstrumentationGo          string
 		labelsFilter                   []string
+		crossNamespaceSelectors        []string
+		injectionRolloutInterval       time.Duration
 		webhookPort                    int
 		tlsOpt                         tlsConfig
 	)
@@ -125,6 +137,8 @@ func main() {
 	stringFlagOrEnv(&collectorImage, "collector-image", "RELATED_IMAGE_COLLECTOR", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector:%s", v.OpenTelemetryCollector), "The default OpenTelemetry collector image. This image is used when no image is specified in the CustomResource.")
 	stringFlagOrEnv(&targetAllocatorImage, "target-allocator-image", "RELATED_IMAGE_TARGET_ALLOCATOR", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/target-allocator:%s", v.TargetAllocator), "The default OpenTelemetry target allocator image. This image is used when no image is specified in the CustomResource.")
 	stringFlagOrEnv(&operatorOpAMPBridgeImage, "operator-opamp-bridge-image", "RELATED_IMAGE_OPERATOR_OPAMP_BRIDGE", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/operator-opamp-bridge:%s", v.OperatorOpAMPBridge), "The default OpenTelemetry Operator OpAMP Bridge image. This image is used when no image is specified in the CustomResource.")
+	stringFlagOrEnv(&sidecarConfigReloaderImage, "sidecar-config-reloader-image", "RELATED_IMAGE_SIDECAR_CONFIG_RELOADER", "docker.io/library/busybox:1.36", "The image of the container reloading the configuration of sidecar collectors with sidecarConfigReload enabled.")
+	stringFlagOrEnv(&persistenceInitImage, "persistence-init-image", "RELATED_IMAGE_PERSISTENCE_INIT", "docker.io/library/busybox:1.36", "The image of the init container giving the daemonset collectors with persistence enabled the ownership of their host path.")
 	stringFlagOrEnv(&autoInstrumentationJava, "auto-instrumentation-java-image", "RELATED_IMAGE_AUTO_INSTRUMENTATION_JAVA", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-java:%s", v.AutoInstrumentationJava), "The default OpenTelemetry Java instrumentation image. This image is used when no image is specified in the CustomResource.")
 	stringFlagOrEnv(&autoInstrumentationNodeJS, "auto-instrumentation-nodejs-image", "RELATED_IMAGE_AUTO_INSTRUMENTATION_NODEJS", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-nodejs:%s", v.AutoInstrumentationNodeJS), "The default OpenTelemetry NodeJS instrumentation image. This image is used when no image is specified in the CustomResource.")
 	stringFlagOrEnv(&autoInstrumentationPython, "auto-instrumentation-python-image", "RELATED_IMAGE_AUTO_INSTRUMENTATION_PYTHON", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-python:%s", v.AutoInstrumentationPython), "The default OpenTelemetry Python instrumentation image. This image is used when no image is specified in the CustomResource.")
@@ -132,8 +146,13 @@ func main() {
 	stringFlagOrEnv(&autoInstrumentationGo, "auto-instrumentation-go-image", "RELATED_IMAGE_AUTO_INSTRUMENTATION_GO", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-go-instrumentation/autoinstrumentation-go:%s", v.AutoInstrumentationGo), "The default OpenTelemetry Go instrumentation image. This image is used when no image is specified in the CustomResource.")
 	stringFlagOrEnv(&autoInstrumentationApacheHttpd, "auto-instrumentation-apache-httpd-image", "RELATED_IMAGE_AUTO_INSTRUMENTATION_APACHE_HTTPD", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-apache-httpd:%s", v.AutoInstrumentationApacheHttpd), "The default OpenTelemetry Apache HTTPD instrumentation image. This image is used when no image is specified in the CustomResource.")
 	stringFlagOrEnv(&autoInstrumentationNginx, "auto-instrumentation-nginx-image", "RELATED_IMAGE_AUTO_INSTRUMENTATION_NGINX", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-apache-httpd:%s", v.AutoInstrumentationNginx), "The default OpenTelemetry Nginx instrumentation image. This image is used when no image is specified in the CustomResource.")
+	stringFlagOrEnv(&autoInstrumentationRuby, "auto-instrumentation-ruby-image", "RELATED_IMAGE_AUTO_INSTRUMENTATION_RUBY", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-ruby:%s", v.AutoInstrumentationRuby), "The default OpenTelemetry Ruby instrumentation image. This image is used when no image is specified in the CustomResource.")
+	stringFlagOrEnv(&autoInstrumentationPHP, "auto-instrumentation-php-image", "RELATED_IMAGE_AUTO_INSTRUMENTATION_PHP", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-php:%s", v.AutoInstrumentationPHP), "The default OpenTelemetry PHP instrumentation image. This image is used when no image is specified in the CustomResource.")
 	pflag.StringArrayVar(&labelsFilter, "labels", []string{}, "Labels to filter away from propagating onto deploys")
+	pflag.StringSliceVar(&crossNamespaceSelectors, "cross-namespace-selector-namespaces", nil, "Comma-separated list of the namespaces whose OpenTelemetryCollectors and Instrumentations may inject the pods of other namespaces with their namespace selectors. By default, the selectors only apply to the pods of their own namespace.")
+	pflag.DurationVar(&injectionRolloutInterval, "injection-rollout-interval", 30*time.Second, "The minimum time between two restarts of workloads running an outdated sidecar or auto-instrumentation, when the operator.injection.rollout feature gate is enabled.")
 	pflag.IntVar(&webhookPort, "webhook-port", 9443, "The port the webhook endpoint binds to.")
+	pflag.BoolVar(&enableInjectionDryRun, "enable-injection-dry-run", false, "Enable the /dry-run-v1-pod endpoint of the webhook server, previewing the injections into the posted pods.")
 	pflag.StringVar(&tlsOpt.minVersion, "tls-min-version", "VersionTLS12", "Minimum TLS version supported. Value must match version names from https://golang.org/pkg/crypto/tls/#pkg-constants.")
 	pflag.StringSliceVar(&tlsOpt.cipherSuites, "tls-cipher-suites", nil, "Comma-separated list of cipher suites for the server. Values are from tls package constants (https://golang.org/pkg/crypto/tls/#pkg-constants). If omitted, the default Go cipher suites will be used")
 	pflag.Parse()
@@ -146,6 +165,8 @@ func main() {
 		"opentelemetry-collector", collectorImage,
 		"opentelemetry-targetallocator", targetAllocatorImage,
 		"operator-opamp-bridge", operatorOpAMPBridgeImage,
+		"sidecar-config-reloader", sidecarConfigReloaderImage,
+		"persistence-init", persistenceInitImage,
 		"auto-instrumentation-java", autoInstrumentationJava,
 		"auto-instrumentation-nodejs", autoInstrumentationNodeJS,
 		"auto-instrumentation-python", autoInstrumentationPython,
@@ -153,12 +174,15 @@ func main() {
 		"auto-instrumentation-go", autoInstrumentationGo,
 		"auto-instrumentation-apache-httpd", autoInstrumentationApacheHttpd,
 		"auto-instrumentation-nginx", autoInstrumentationNginx,
+		"auto-instrumentation-ruby", autoInstrumentationRuby,
+		"auto-instrumentation-php", autoInstrumentationPHP,
 		"feature-gates", flagset.Lookup(featuregate.FeatureGatesFlag).Value.String(),
 		"build-date", v.BuildDate,
 		"go-version", v.Go,
 		"go-arch", runtime.GOARCH,
 		"go-os", runtime.GOOS,
 		"labels-filter", labelsFilter,
+		"cross-namespace-selector-namespaces", crossNamespaceSelectors,
 	)
 
 	restConfig := ctrl.GetConfigOrDie()
@@ -176,6 +200,8 @@ func main() {
 		config.WithCollectorImage(collectorImage),
 		config.WithTargetAllocatorImage(targetAllocatorImage),
 		config.WithOperatorOpAMPBridgeImage(operatorOpAMPBridgeImage),
+		config.WithSidecarConfigReloaderImage(sidecarConfigReloaderImage),
+		config.WithPersistenceInitImage(persistenceInitImage),
 		config.WithAutoInstrumentationJavaImage(autoInstrumentationJava),
 		config.WithAutoInstrumentationNodeJSImage(autoInstrumentationNodeJS),
 		config.WithAutoInstrumentationPythonImage(autoInstrumentationPython),
@@ -183,8 +209,11 @@ func main() {
 		config.WithAutoInstrumentationGoImage(autoInstrumentationGo),
 		config.WithAutoInstrumentationApacheHttpdImage(autoInstrumentationApacheHttpd),
 		config.WithAutoInstrumentationNginxImage(autoInstrumentationNginx),
+		config.WithAutoInstrumentationRubyImage(autoInstrumentationRuby),
+		config.WithAutoInstrumentationPHPImage(autoInstrumentationPHP),
 		config.WithAutoDetect(ad),
 		config.WithLabelFilters(labelsFilter),
+		config.WithCrossNamespaceSelectorNamespaces(crossNamespaceSelectors),
 	)
 	err = cfg.AutoDetect()
 	if err != nil {
@@ -270,6 +299,32 @@ func main() {
 		os.Exit(1)
 	}
 
+	if featuregate.EnableInjectionRollout.IsEnabled() {
+		if err = controllers.NewInjectionRolloutReconciler(controllers.InjectionRolloutReconcilerParams{
+			Client:   mgr.GetClient(),
+			Log:      ctrl.Log.WithName("controllers").WithName("InjectionRollout"),
+			Config:   cfg,
+			Recorder: mgr.GetEventRecorderFor("opentelemetry-operator"),
+			Interval: injectionRolloutInterval,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "InjectionRollout")
+			os.Exit(1)
+		}
+	}
+
+	if featuregate.EnableInstrumentationStatus.IsEnabled() {
+		if err = controllers.NewInstrumentationStatusReconciler(controllers.InstrumentationStatusReconcilerParams{
+			Client: mgr.GetClient(),
+			Events: mgr.GetAPIReader(),
+			Scheme: mgr.GetScheme(),
+			Log:    ctrl.Log.WithName("controllers").WithName("InstrumentationStatus"),
+			Config: cfg,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "InstrumentationStatus")
+			os.Exit(1)
+		}
+	}
+
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
 		if err = otelv1alpha1.SetupCollectorWebhook(mgr, cfg); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "OpenTelemetryCollector")
@@ -280,13 +335,20 @@ func main() {
 			os.Exit(1)
 		}
 		decoder := admission.NewDecoder(mgr.GetScheme())
+		podMutators := func(logger logr.Logger, recorder record.EventRecorder) []podmutation.PodMutator {
+			return []podmutation.PodMutator{
+				sidecar.NewMutator(logger, cfg, mgr.GetClient(), recorder),
+				instrumentation.NewMutator(logger, cfg, mgr.GetClient(), recorder),
+			}
+		}
 		mgr.GetWebhookServer().Register("/mutate-v1-pod", &webhook.Admission{
 			Handler: podmutation.NewWebhookHandler(cfg, ctrl.Log.WithName("pod-webhook"), decoder, mgr.GetClient(),
-				[]podmutation.PodMutator{
-					sidecar.NewMutator(logger, cfg, mgr.GetClient()),
-					instrumentation.NewMutator(logger, mgr.GetClient(), mgr.GetEventRecorderFor("opentelemetry-operator")),
-				}),
+				podMutators(logger, mgr.GetEventRecorderFor("opentelemetry-operator"))),
 		})
+		if enableInjectionDryRun {
+			mgr.GetWebhookServer().Register("/dry-run-v1-pod",
+				podmutation.NewDryRunHandler(ctrl.Log.WithName("pod-dry-run"), mgr.GetClient(), podMutators))
+		}
 
 		if err = otelv1alpha1.SetupOpAMPBridgeWebhook(mgr, cfg); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "OpAMPBridge")
@@ -339,6 +401,8 @@ func addDependencies(_ context.Context, mgr ctrl.Manager, cfg config.Config, v v
 			DefaultAutoInstGo:          cfg.AutoInstrumentationDotNetImage(),
 			DefaultAutoInstApacheHttpd: cfg.AutoInstrumentationApacheHttpdImage(),
 			DefaultAutoInstNginx:       cfg.AutoInstrumentationNginxImage(),
+			DefaultAutoInstRuby:        cfg.AutoInstrumentationRubyImage(),
+			DefaultAutoInstPHP:         cfg.AutoInstrumentationPHPImage(),
 			Client:                     mgr.GetClient(),
 			Recorder:                   mgr.GetEventRecorderFor("opentelemetry-operator"),
 		}
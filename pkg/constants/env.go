@@ -22,6 +22,12 @@ const (
 	EnvOTELTracesSampler        = "OTEL_TRACES_SAMPLER"
 	EnvOTELTracesSamplerArg     = "OTEL_TRACES_SAMPLER_ARG"
 
+	EnvOTELExporterOTLPProtocol          = "OTEL_EXPORTER_OTLP_PROTOCOL"
+	EnvOTELExporterOTLPHeaders           = "OTEL_EXPORTER_OTLP_HEADERS"
+	EnvOTELExporterOTLPCertificate       = "OTEL_EXPORTER_OTLP_CERTIFICATE"
+	EnvOTELExporterOTLPClientCertificate = "OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE"
+	EnvOTELExporterOTLPClientKey         = "OTEL_EXPORTER_OTLP_CLIENT_KEY"
+
 	InstrumentationPrefix                           = "instrumentation.opentelemetry.io/"
 	AnnotationDefaultAutoInstrumentationJava        = InstrumentationPrefix + "default-auto-instrumentation-java-image"
 	AnnotationDefaultAutoInstrumentationNodeJS      = InstrumentationPrefix + "default-auto-instrumentation-nodejs-image"
@@ -30,8 +36,11 @@ const (
 	AnnotationDefaultAutoInstrumentationGo          = InstrumentationPrefix + "default-auto-instrumentation-go-image"
 	AnnotationDefaultAutoInstrumentationApacheHttpd = InstrumentationPrefix + "default-auto-instrumentation-apache-httpd-image"
 	AnnotationDefaultAutoInstrumentationNginx       = InstrumentationPrefix + "default-auto-instrumentation-nginx-image"
+	AnnotationDefaultAutoInstrumentationRuby        = InstrumentationPrefix + "default-auto-instrumentation-ruby-image"
+	AnnotationDefaultAutoInstrumentationPHP         = InstrumentationPrefix + "default-auto-instrumentation-php-image"
 
 	EnvPodName  = "OTEL_RESOURCE_ATTRIBUTES_POD_NAME"
 	EnvPodUID   = "OTEL_RESOURCE_ATTRIBUTES_POD_UID"
 	EnvNodeName = "OTEL_RESOURCE_ATTRIBUTES_NODE_NAME"
+	EnvNodeIP   = "OTEL_NODE_IP"
 )
@@ -67,6 +67,18 @@ var (
 		featuregate.WithRegisterDescription("controls whether the operator supports Nginx auto-instrumentation"),
 		featuregate.WithRegisterFromVersion("v0.86.0"),
 	)
+	EnableRubyAutoInstrumentationSupport = featuregate.GlobalRegistry().MustRegister(
+		"operator.autoinstrumentation.ruby",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("controls whether the operator supports Ruby auto-instrumentation"),
+		featuregate.WithRegisterFromVersion("v0.91.0"),
+	)
+	EnablePHPAutoInstrumentationSupport = featuregate.GlobalRegistry().MustRegister(
+		"operator.autoinstrumentation.php",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("controls whether the operator supports PHP auto-instrumentation"),
+		featuregate.WithRegisterFromVersion("v0.91.0"),
+	)
 
 	EnableMultiInstrumentationSupport = featuregate.GlobalRegistry().MustRegister(
 		"operator.autoinstrumentation.multi-instrumentation",
@@ -83,6 +95,24 @@ var (
 		featuregate.WithRegisterFromVersion("v0.76.1"),
 	)
 
+	// EnableInjectionRollout is the feature gate that enables restarting the workloads whose pods run an outdated
+	// sidecar or auto-instrumentation.
+	EnableInjectionRollout = featuregate.GlobalRegistry().MustRegister(
+		"operator.injection.rollout",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("controls whether the operator restarts the workloads running an outdated sidecar or auto-instrumentation"),
+		featuregate.WithRegisterFromVersion("v0.90.0"),
+	)
+
+	// EnableInstrumentationStatus is the feature gate that enables reporting the pods an Instrumentation was injected
+	// into, and the injections that failed, in its status.
+	EnableInstrumentationStatus = featuregate.GlobalRegistry().MustRegister(
+		"operator.instrumentation.status",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("controls whether the operator reports the instrumented pods and the failed injections in the Instrumentations' status"),
+		featuregate.WithRegisterFromVersion("v0.91.0"),
+	)
+
 	// PrometheusOperatorIsAvailable is the feature gate that enables features associated to the Prometheus Operator.
 	PrometheusOperatorIsAvailable = featuregate.GlobalRegistry().MustRegister(
 		"operator.observability.prometheus",
@@ -17,6 +17,7 @@ package instrumentation
 import (
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -24,6 +25,7 @@ const (
 	// annotationInjectJava indicates whether java auto-instrumentation should be injected or not.
 	// Possible values are "true", "false" or "<Instrumentation>" name.
 	annotationInjectContainerName             = "instrumentation.opentelemetry.io/container-names"
+	annotationInjectAuto                      = "instrumentation.opentelemetry.io/inject-auto"
 	annotationInjectJava                      = "instrumentation.opentelemetry.io/inject-java"
 	annotationInjectJavaContainersName        = "instrumentation.opentelemetry.io/java-container-names"
 	annotationInjectNodeJS                    = "instrumentation.opentelemetry.io/inject-nodejs"
@@ -42,6 +44,10 @@ const (
 	annotationInjectApacheHttpdContainersName = "instrumentation.opentelemetry.io/apache-httpd-container-names"
 	annotationInjectNginx                     = "instrumentation.opentelemetry.io/inject-nginx"
 	annotationInjectNginxContainersName       = "instrumentation.opentelemetry.io/inject-nginx-container-names"
+	annotationInjectRuby                      = "instrumentation.opentelemetry.io/inject-ruby"
+	annotationInjectRubyContainersName        = "instrumentation.opentelemetry.io/ruby-container-names"
+	annotationInjectPHP                       = "instrumentation.opentelemetry.io/inject-php"
+	annotationInjectPHPContainersName         = "instrumentation.opentelemetry.io/php-container-names"
 )
 
 // annotationValue returns the effective annotationInjectJava value, based on the annotations from the pod and namespace.
@@ -75,3 +81,13 @@ func annotationValue(ns metav1.ObjectMeta, pod metav1.ObjectMeta, annotation str
 	// so, the namespace annotation can be used
 	return nsAnnValue
 }
+
+// annotated returns whether the pod or its namespace requests the injection of a language with an annotation.
+func annotated(ns corev1.Namespace, pod corev1.Pod) bool {
+	for _, lang := range languageInjectors {
+		if value := annotationValue(ns.ObjectMeta, pod.ObjectMeta, lang.Annotation()); value != "" && !strings.EqualFold(value, "false") {
+			return true
+		}
+	}
+	return false
+}
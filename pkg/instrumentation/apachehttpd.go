@@ -15,6 +15,7 @@
 package instrumentation
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
@@ -24,6 +25,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 const (
@@ -59,7 +61,7 @@ const (
 	6) Inject mounting of volumes / files into appropriate directories in application container
 */
 
-func injectApacheHttpdagent(_ logr.Logger, apacheSpec v1alpha1.ApacheHttpd, pod corev1.Pod, index int, otlpEndpoint string, resourceMap map[string]string) corev1.Pod {
+func injectApacheHttpdagent(_ logr.Logger, apacheSpec v1alpha1.ApacheHttpd, pod corev1.Pod, index int, exporter v1alpha1.Exporter, sampler v1alpha1.Sampler, resourceMap map[string]string) corev1.Pod {
 
 	// caller checks if there is at least one container
 	container := &pod.Spec.Containers[index]
@@ -162,7 +164,7 @@ func injectApacheHttpdagent(_ logr.Logger, apacheSpec v1alpha1.ApacheHttpd, pod
 			Env: []corev1.EnvVar{
 				{
 					Name:  apacheAttributesEnvVar,
-					Value: getApacheOtelConfig(pod, apacheSpec, index, otlpEndpoint, resourceMap),
+					Value: getApacheOtelConfig(pod, apacheSpec, index, exporter, sampler, resourceMap),
 				},
 				{Name: apacheServiceInstanceIdEnvVar,
 					ValueFrom: &corev1.EnvVarSource{
@@ -184,6 +186,12 @@ func injectApacheHttpdagent(_ logr.Logger, apacheSpec v1alpha1.ApacheHttpd, pod
 				},
 			},
 		})
+
+		// the node-local endpoint of the configuration refers to the node's IP, which has to be defined before
+		if exporter.NodeLocal != nil {
+			initContainer := &pod.Spec.InitContainers[len(pod.Spec.InitContainers)-1]
+			initContainer.Env = append([]corev1.EnvVar{nodeIPEnvVar()}, initContainer.Env...)
+		}
 	}
 
 	return pod
@@ -201,7 +209,7 @@ func isApacheInitContainerMissing(pod corev1.Pod, containerName string) bool {
 
 // Calculate Apache HTTPD agent configuration file based on attributes provided by the injection rules
 // and by the pod values.
-func getApacheOtelConfig(pod corev1.Pod, apacheSpec v1alpha1.ApacheHttpd, index int, otelEndpoint string, resourceMap map[string]string) string {
+func getApacheOtelConfig(pod corev1.Pod, apacheSpec v1alpha1.ApacheHttpd, index int, exporter v1alpha1.Exporter, sampler v1alpha1.Sampler, resourceMap map[string]string) string {
 	template := `
 #Load the Otel Webserver SDK
 LoadFile %[1]s/sdk_lib/lib/libopentelemetry_common.so
@@ -219,6 +227,7 @@ LoadFile %[1]s/sdk_lib/lib/libopentelemetry_webserver_sdk.so
 LoadModule otel_apache_module %[1]s/WebServerModule/Apache/libmod_apache_otel%[2]s.so
 #Attributes
 `
+	otelEndpoint := exporterEndpoint(exporter)
 	if otelEndpoint == "" {
 		otelEndpoint = "http://localhost:4317/"
 	}
@@ -254,6 +263,15 @@ LoadModule otel_apache_module %[1]s/WebServerModule/Apache/libmod_apache_otel%[2
 		"ApacheModuleResolveBackends": " ON",
 		"ApacheModuleTraceAsError":    " ON",
 	}
+	if exporter.TLS != nil {
+		if ca, _, _ := exporterTLSFiles(*exporter.TLS); ca != "" {
+			attrMap["ApacheModuleOtelSslEnabled"] = "ON"
+			attrMap["ApacheModuleOtelSslCertificatePath"] = ca
+		}
+	}
+	if moduleSampler := webServerModuleSampler(sampler); moduleSampler != "" {
+		attrMap["ApacheModuleOtelSampler"] = moduleSampler
+	}
 	for _, attr := range apacheSpec.Attrs {
 		attrMap[attr.Name] = attr.Value
 	}
@@ -275,6 +293,18 @@ LoadModule otel_apache_module %[1]s/WebServerModule/Apache/libmod_apache_otel%[2
 	return configFileContent
 }
 
+// webServerModuleSampler returns the sampler of the Apache HTTPD and Nginx modules matching the given sampler.
+// The modules can only switch the tracing on and off, the other samplers are left to the modules' defaults.
+func webServerModuleSampler(sampler v1alpha1.Sampler) string {
+	switch sampler.Type {
+	case v1alpha1.AlwaysOn, v1alpha1.ParentBasedAlwaysOn:
+		return "AlwaysOn"
+	case v1alpha1.AlwaysOff, v1alpha1.ParentBasedAlwaysOff:
+		return "AlwaysOff"
+	}
+	return ""
+}
+
 func getApacheConfDir(configuredDir string) string {
 	apacheConfDir := apacheDefaultConfigDirectory
 	if configuredDir != "" {
@@ -285,3 +315,50 @@ func getApacheConfDir(configuredDir string) string {
 	}
 	return apacheConfDir
 }
+
+// apacheHttpdInjector injects the Apache HTTPD auto-instrumentation.
+type apacheHttpdInjector struct{}
+
+func (apacheHttpdInjector) Language() string { return "apache-httpd" }
+
+func (apacheHttpdInjector) Name() string { return "Apache HTTPD" }
+
+func (apacheHttpdInjector) Annotation() string { return annotationInjectApacheHttpd }
+
+func (apacheHttpdInjector) ContainersAnnotation() string {
+	return annotationInjectApacheHttpdContainersName
+}
+
+func (apacheHttpdInjector) Protocol() v1alpha1.ExporterProtocol { return v1alpha1.ExporterProtocolGRPC }
+
+func (apacheHttpdInjector) Enabled() bool {
+	return featuregate.EnableApacheHTTPAutoInstrumentationSupport.IsEnabled()
+}
+
+func (apacheHttpdInjector) Section(spec v1alpha1.InstrumentationSpec) (LanguageSection, bool) {
+	return LanguageSection{
+		Image:       spec.ApacheHttpd.Image,
+		Env:         spec.ApacheHttpd.Env,
+		Resources:   spec.ApacheHttpd.Resources,
+		Exporter:    spec.ApacheHttpd.Exporter,
+		Sampler:     spec.ApacheHttpd.Sampler,
+		Propagators: spec.ApacheHttpd.Propagators,
+	}, true
+}
+
+func (apacheHttpdInjector) inject(ctx context.Context, i *sdkInjector, inst instrumentationWithContainers, ns corev1.Namespace, pod corev1.Pod) corev1.Pod {
+	otelinst := *inst.Instrumentation
+	i.logger.V(1).Info("injecting Apache Httpd instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+
+	for _, container := range strings.Split(inst.Containers, ",") {
+		index := getContainerIndex(container, pod)
+		// Apache agent is configured via config files rather than env vars.
+		// Therefore, service name, otlp endpoint and other attributes are passed to the agent injection method
+		pod = injectApacheHttpdagent(i.logger, otelinst.Spec.ApacheHttpd, pod, index, otelinst.Spec.Exporter, otelinst.Spec.Sampler, i.createResourceMap(ctx, otelinst, ns, pod, index))
+		pod = i.injectCommonEnvVar(otelinst, pod, index)
+		pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
+		pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, apacheAgentInitContainerName)
+		pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, apacheAgentCloneContainerName)
+	}
+	return pod
+}
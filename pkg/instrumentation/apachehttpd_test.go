@@ -417,7 +417,7 @@ func TestInjectApacheHttpdagent(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			pod := injectApacheHttpdagent(logr.Discard(), test.ApacheHttpd, test.pod, 0, "http://otlp-endpoint:4317", resourceMap)
+			pod := injectApacheHttpdagent(logr.Discard(), test.ApacheHttpd, test.pod, 0, v1alpha1.Exporter{Endpoint: "http://otlp-endpoint:4317"}, v1alpha1.Sampler{}, resourceMap)
 			assert.Equal(t, test.expected, pod)
 		})
 	}
@@ -527,7 +527,7 @@ func TestInjectApacheHttpdagentUnknownNamespace(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			pod := injectApacheHttpdagent(logr.Discard(), test.ApacheHttpd, test.pod, 0, "http://otlp-endpoint:4317", resourceMap)
+			pod := injectApacheHttpdagent(logr.Discard(), test.ApacheHttpd, test.pod, 0, v1alpha1.Exporter{Endpoint: "http://otlp-endpoint:4317"}, v1alpha1.Sampler{}, resourceMap)
 			assert.Equal(t, test.expected, pod)
 		})
 	}
@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// resolveExporters resolves the exporters of the languages referencing an OpenTelemetryCollector into the ports of
+// its OTLP receivers. The Instrumentations are left untouched, the resolved exporters are set on the returned
+// instrumentations.
+func (pm *instPodMutator) resolveExporters(ctx context.Context, insts languageInstrumentations) (languageInstrumentations, error) {
+	resolved := languageInstrumentations{}
+	for _, lang := range languageInjectors {
+		inst, ok := insts[lang.Language()]
+		if !ok {
+			continue
+		}
+		resolved[lang.Language()] = inst
+		if inst.Instrumentation == nil {
+			continue
+		}
+
+		exporter := withLanguageOverrides(lang, inst).Instrumentation.Spec.Exporter
+		var err error
+		switch {
+		case exporter.Collector != nil:
+			exporter, err = pm.resolveCollectorExporter(ctx, lang, inst.Instrumentation.Namespace, exporter)
+		case exporter.NodeLocal != nil && exporter.NodeLocal.Collector != "":
+			exporter, err = pm.resolveNodeLocalExporter(ctx, lang, inst.Instrumentation.Namespace, exporter)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		inst.Exporter = &exporter
+		resolved[lang.Language()] = inst
+	}
+	return resolved, nil
+}
+
+// resolveCollectorExporter returns the exporter sending to the Service of the referenced OpenTelemetryCollector.
+func (pm *instPodMutator) resolveCollectorExporter(ctx context.Context, lang LanguageInjector, namespace string, exporter v1alpha1.Exporter) (v1alpha1.Exporter, error) {
+	name := types.NamespacedName{Namespace: exporter.Collector.Namespace, Name: exporter.Collector.Name}
+	if name.Namespace == "" {
+		name.Namespace = namespace
+	}
+	otelcol := v1alpha1.OpenTelemetryCollector{}
+	if err := pm.Client.Get(ctx, name, &otelcol); err != nil {
+		return exporter, fmt.Errorf("failed to get the OpenTelemetryCollector %s of the exporter: %w", name, err)
+	}
+	if otelcol.Spec.Mode == v1alpha1.ModeSidecar {
+		return exporter, fmt.Errorf("the OpenTelemetryCollector %s of the exporter is a sidecar, which has no Service", name)
+	}
+	port, protocol, err := collectorOTLPPort(otelcol, exporter.Protocol, lang.Protocol())
+	if err != nil {
+		return exporter, err
+	}
+
+	scheme := "http"
+	if exporter.TLS != nil {
+		scheme = "https"
+	}
+	exporter.Endpoint = fmt.Sprintf("%s://%s.%s.svc:%d", scheme, naming.Service(otelcol.Name), otelcol.Namespace, port)
+	exporter.Collector = nil
+	exporter.Protocol = protocol
+	return exporter, nil
+}
+
+// resolveNodeLocalExporter returns the exporter sending to the port of the node-local OpenTelemetryCollector.
+func (pm *instPodMutator) resolveNodeLocalExporter(ctx context.Context, lang LanguageInjector, namespace string, exporter v1alpha1.Exporter) (v1alpha1.Exporter, error) {
+	otelcol := v1alpha1.OpenTelemetryCollector{}
+	name := types.NamespacedName{Namespace: namespace, Name: exporter.NodeLocal.Collector}
+	if err := pm.Client.Get(ctx, name, &otelcol); err != nil {
+		return exporter, fmt.Errorf("failed to get the OpenTelemetryCollector %s of the node-local exporter: %w", name, err)
+	}
+	if otelcol.Spec.Mode != v1alpha1.ModeDaemonSet {
+		return exporter, fmt.Errorf("the OpenTelemetryCollector %s of the node-local exporter isn't a daemonset", name)
+	}
+	port, protocol, err := collectorOTLPPort(otelcol, exporter.Protocol, lang.Protocol())
+	if err != nil {
+		return exporter, err
+	}
+	if !otelcol.Spec.HostNetwork {
+		hostPort := pm.collectorHostPort(otelcol, port)
+		if hostPort == 0 {
+			return exporter, fmt.Errorf("the OpenTelemetryCollector %s of the node-local exporter uses neither the host network nor a host port for its OTLP receiver", name)
+		}
+		port = hostPort
+	}
+
+	nodeLocal := *exporter.NodeLocal
+	nodeLocal.Port = port
+	exporter.NodeLocal = &nodeLocal
+	exporter.Protocol = protocol
+	return exporter, nil
+}
+
+// collectorHostPort returns the host port the given container port of the collector is bound to on the node, or 0.
+func (pm *instPodMutator) collectorHostPort(otelcol v1alpha1.OpenTelemetryCollector, containerPort int32) int32 {
+	container := collector.Container(pm.config, logr.Discard(), otelcol, false)
+	for _, port := range container.Ports {
+		if port.ContainerPort == containerPort && port.HostPort != 0 {
+			return port.HostPort
+		}
+	}
+	return 0
+}
+
+// collectorOTLPPort returns the port of the collector's OTLP receiver for the given protocol, and the protocol the
+// port is for. When no protocol is given, the preferred protocol is used if the collector receives it.
+func collectorOTLPPort(otelcol v1alpha1.OpenTelemetryCollector, protocol, preferred v1alpha1.ExporterProtocol) (int32, v1alpha1.ExporterProtocol, error) {
+	name := types.NamespacedName{Namespace: otelcol.Namespace, Name: otelcol.Name}
+	config, err := adapters.ConfigFromString(otelcol.Spec.Config)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to parse the config of the OpenTelemetryCollector %s: %w", name, err)
+	}
+	ports, err := adapters.ConfigToOTLPPorts(logr.Discard(), config)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to get the OTLP receivers of the OpenTelemetryCollector %s: %w", name, err)
+	}
+
+	var grpcPort, httpPort int32
+	for _, port := range ports {
+		if port.AppProtocol == nil {
+			continue
+		}
+		if *port.AppProtocol == "grpc" && grpcPort == 0 {
+			grpcPort = port.Port
+		}
+		if *port.AppProtocol == "http" && httpPort == 0 {
+			httpPort = port.Port
+		}
+	}
+
+	isHTTP := func(protocol v1alpha1.ExporterProtocol) bool {
+		return protocol == v1alpha1.ExporterProtocolHTTPProtobuf || protocol == v1alpha1.ExporterProtocolHTTPJSON
+	}
+	switch {
+	case protocol == v1alpha1.ExporterProtocolGRPC && grpcPort != 0:
+		return grpcPort, protocol, nil
+	case isHTTP(protocol) && httpPort != 0:
+		return httpPort, protocol, nil
+	case protocol == "" && isHTTP(preferred) && httpPort != 0:
+		return httpPort, preferred, nil
+	case protocol == "" && grpcPort != 0:
+		return grpcPort, v1alpha1.ExporterProtocolGRPC, nil
+	case protocol == "" && httpPort != 0:
+		return httpPort, v1alpha1.ExporterProtocolHTTPProtobuf, nil
+	}
+	if protocol == "" {
+		return 0, "", fmt.Errorf("the OpenTelemetryCollector %s has no OTLP receiver", name)
+	}
+	return 0, "", fmt.Errorf("the OpenTelemetryCollector %s has no OTLP receiver for the %s protocol", name, protocol)
+}
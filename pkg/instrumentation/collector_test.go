@@ -0,0 +1,289 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
+)
+
+const nodeAgentConfig = `receivers:
+  otlp:
+    protocols:
+      grpc:
+      http:
+        endpoint: 0.0.0.0:14318
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`
+
+func TestResolveExporters(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	collector := func(name string, mode v1alpha1.Mode, hostNetwork bool, config string) *v1alpha1.OpenTelemetryCollector {
+		return &v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "observability"},
+			Spec:       v1alpha1.OpenTelemetryCollectorSpec{Mode: mode, HostNetwork: hostNetwork, Config: config},
+		}
+	}
+	hostPortsAgent := collector("host-ports", v1alpha1.ModeDaemonSet, false, nodeAgentConfig)
+	hostPortsAgent.Spec.Agent = v1alpha1.AgentSpec{Enabled: true, HostPorts: true}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		collector("agent", v1alpha1.ModeDaemonSet, true, nodeAgentConfig),
+		collector("gateway", v1alpha1.ModeDeployment, true, nodeAgentConfig),
+		collector("sidecar", v1alpha1.ModeSidecar, false, nodeAgentConfig),
+		collector("pod-network", v1alpha1.ModeDaemonSet, false, nodeAgentConfig),
+		hostPortsAgent,
+		collector("no-otlp", v1alpha1.ModeDaemonSet, true, "receivers:\n  zipkin:\nexporters:\n  debug:\nservice:\n  pipelines:\n    traces:\n      receivers: [zipkin]\n      exporters: [debug]\n"),
+	).Build()
+	mutator := NewMutator(logr.Discard(), config.New(), cli, record.NewFakeRecorder(1))
+
+	tests := []struct {
+		name     string
+		spec     v1alpha1.InstrumentationSpec
+		expected map[string]*v1alpha1.Exporter
+		err      string
+	}{
+		{
+			name: "fixed endpoint",
+			spec: v1alpha1.InstrumentationSpec{Exporter: v1alpha1.Exporter{Endpoint: "http://collector:4317"}},
+			expected: map[string]*v1alpha1.Exporter{
+				"java":   nil,
+				"python": nil,
+			},
+		},
+		{
+			name: "node-local collector",
+			spec: v1alpha1.InstrumentationSpec{
+				Exporter: v1alpha1.Exporter{NodeLocal: &v1alpha1.NodeLocal{Collector: "agent"}},
+				Python: v1alpha1.Python{
+					Exporter: &v1alpha1.Exporter{
+						NodeLocal: &v1alpha1.NodeLocal{Collector: "agent"},
+						Protocol:  v1alpha1.ExporterProtocolHTTPProtobuf,
+					},
+				},
+			},
+			expected: map[string]*v1alpha1.Exporter{
+				"java": {
+					NodeLocal: &v1alpha1.NodeLocal{Collector: "agent", Port: 4317},
+					Protocol:  v1alpha1.ExporterProtocolGRPC,
+				},
+				"python": {
+					NodeLocal: &v1alpha1.NodeLocal{Collector: "agent", Port: 14318},
+					Protocol:  v1alpha1.ExporterProtocolHTTPProtobuf,
+				},
+			},
+		},
+		{
+			name: "node-local collector with host ports",
+			spec: v1alpha1.InstrumentationSpec{
+				Exporter: v1alpha1.Exporter{NodeLocal: &v1alpha1.NodeLocal{Collector: "host-ports"}},
+			},
+			expected: map[string]*v1alpha1.Exporter{
+				"java": {
+					NodeLocal: &v1alpha1.NodeLocal{Collector: "host-ports", Port: 4317},
+					Protocol:  v1alpha1.ExporterProtocolGRPC,
+				},
+			},
+		},
+		{
+			name: "node-local port",
+			spec: v1alpha1.InstrumentationSpec{Exporter: v1alpha1.Exporter{NodeLocal: &v1alpha1.NodeLocal{Port: 4317}}},
+			expected: map[string]*v1alpha1.Exporter{
+				"java":   nil,
+				"python": nil,
+			},
+		},
+		{
+			name: "collector reference",
+			spec: v1alpha1.InstrumentationSpec{Exporter: v1alpha1.Exporter{Collector: &v1alpha1.CollectorReference{Name: "gateway"}}},
+			expected: map[string]*v1alpha1.Exporter{
+				"java": {
+					Endpoint: "http://gateway-collector.observability.svc:4317",
+					Protocol: v1alpha1.ExporterProtocolGRPC,
+				},
+				"python": {
+					Endpoint: "http://gateway-collector.observability.svc:14318",
+					Protocol: v1alpha1.ExporterProtocolHTTPProtobuf,
+				},
+			},
+		},
+		{
+			name: "collector reference with a protocol and TLS",
+			spec: v1alpha1.InstrumentationSpec{Exporter: v1alpha1.Exporter{
+				Collector: &v1alpha1.CollectorReference{Name: "gateway", Namespace: "observability"},
+				Protocol:  v1alpha1.ExporterProtocolGRPC,
+				TLS:       &v1alpha1.TLS{CA: "/etc/otel/ca.crt"},
+			}},
+			expected: map[string]*v1alpha1.Exporter{
+				"java": {
+					Endpoint: "https://gateway-collector.observability.svc:4317",
+					Protocol: v1alpha1.ExporterProtocolGRPC,
+					TLS:      &v1alpha1.TLS{CA: "/etc/otel/ca.crt"},
+				},
+				"python": {
+					Endpoint: "https://gateway-collector.observability.svc:4317",
+					Protocol: v1alpha1.ExporterProtocolGRPC,
+					TLS:      &v1alpha1.TLS{CA: "/etc/otel/ca.crt"},
+				},
+			},
+		},
+		{
+			name: "missing referenced collector",
+			spec: v1alpha1.InstrumentationSpec{Exporter: v1alpha1.Exporter{Collector: &v1alpha1.CollectorReference{Name: "gateway", Namespace: "app"}}},
+			err:  "failed to get the OpenTelemetryCollector app/gateway of the exporter",
+		},
+		{
+			name: "referenced collector in sidecar mode",
+			spec: v1alpha1.InstrumentationSpec{Exporter: v1alpha1.Exporter{Collector: &v1alpha1.CollectorReference{Name: "sidecar"}}},
+			err:  "the OpenTelemetryCollector observability/sidecar of the exporter is a sidecar, which has no Service",
+		},
+		{
+			name: "missing collector",
+			spec: v1alpha1.InstrumentationSpec{Exporter: v1alpha1.Exporter{NodeLocal: &v1alpha1.NodeLocal{Collector: "missing"}}},
+			err:  "failed to get the OpenTelemetryCollector observability/missing of the node-local exporter",
+		},
+		{
+			name: "collector not in daemonset mode",
+			spec: v1alpha1.InstrumentationSpec{Exporter: v1alpha1.Exporter{NodeLocal: &v1alpha1.NodeLocal{Collector: "gateway"}}},
+			err:  "the OpenTelemetryCollector observability/gateway of the node-local exporter isn't a daemonset",
+		},
+		{
+			name: "collector on the pod network",
+			spec: v1alpha1.InstrumentationSpec{Exporter: v1alpha1.Exporter{NodeLocal: &v1alpha1.NodeLocal{Collector: "pod-network"}}},
+			err:  "the OpenTelemetryCollector observability/pod-network of the node-local exporter uses neither the host network nor a host port for its OTLP receiver",
+		},
+		{
+			name: "collector without OTLP receiver",
+			spec: v1alpha1.InstrumentationSpec{Exporter: v1alpha1.Exporter{NodeLocal: &v1alpha1.NodeLocal{Collector: "no-otlp"}}},
+			err:  "the OpenTelemetryCollector observability/no-otlp has no OTLP receiver",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inst := &v1alpha1.Instrumentation{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-inst", Namespace: "observability"},
+				Spec:       tt.spec,
+			}
+			insts := languageInstrumentations{
+				"java":   {Instrumentation: inst, Containers: "java-app"},
+				"python": {Instrumentation: inst, Containers: "python-app"},
+			}
+
+			resolved, err := mutator.resolveExporters(context.Background(), insts)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			for language, exporter := range tt.expected {
+				assert.Equal(t, exporter, resolved[language].Exporter, language)
+				assert.Same(t, inst, resolved[language].Instrumentation, language)
+				assert.Equal(t, insts[language].Containers, resolved[language].Containers, language)
+			}
+			assert.Equal(t, tt.spec, inst.Spec)
+		})
+	}
+}
+
+func TestMutatePodNodeLocalExporter(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	inst := &v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-inst", Namespace: "app"},
+		Spec: v1alpha1.InstrumentationSpec{
+			Exporter: v1alpha1.Exporter{NodeLocal: &v1alpha1.NodeLocal{Collector: "agent"}},
+		},
+	}
+	otelcol := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "app"},
+		Spec:       v1alpha1.OpenTelemetryCollectorSpec{Mode: v1alpha1.ModeDaemonSet, HostNetwork: true, Config: nodeAgentConfig},
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(inst, otelcol).Build()
+	mutator := NewMutator(logr.Discard(), config.New(), cli, record.NewFakeRecorder(1))
+	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}}
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "app", Annotations: map[string]string{annotationInjectSdk: "true"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+	}
+
+	mutated, err := mutator.Mutate(context.Background(), ns, pod)
+	require.NoError(t, err)
+
+	env := mutated.Spec.Containers[0].Env
+	nodeIP := getIndexOfEnv(env, constants.EnvNodeIP)
+	endpoint := getIndexOfEnv(env, constants.EnvOTELExporterOTLPEndpoint)
+	require.NotEqual(t, -1, nodeIP)
+	require.NotEqual(t, -1, endpoint)
+	assert.Less(t, nodeIP, endpoint)
+	assert.Equal(t, "status.hostIP", env[nodeIP].ValueFrom.FieldRef.FieldPath)
+	assert.Equal(t, "http://$(OTEL_NODE_IP):4317", env[endpoint].Value)
+	assert.Equal(t, "grpc", env[getIndexOfEnv(env, constants.EnvOTELExporterOTLPProtocol)].Value)
+}
+
+func TestMutatePodCollectorExporter(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	inst := &v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-inst", Namespace: "app"},
+		Spec: v1alpha1.InstrumentationSpec{
+			Exporter: v1alpha1.Exporter{Collector: &v1alpha1.CollectorReference{Name: "gateway", Namespace: "observability"}},
+		},
+	}
+	otelcol := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "observability"},
+		Spec:       v1alpha1.OpenTelemetryCollectorSpec{Mode: v1alpha1.ModeDeployment, Config: nodeAgentConfig},
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(inst, otelcol).Build()
+	mutator := NewMutator(logr.Discard(), config.New(), cli, record.NewFakeRecorder(1))
+	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}}
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "app", Annotations: map[string]string{annotationInjectSdk: "true"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+	}
+
+	mutated, err := mutator.Mutate(context.Background(), ns, pod)
+	require.NoError(t, err)
+
+	env := mutated.Spec.Containers[0].Env
+	assert.Equal(t, "http://gateway-collector.observability.svc:4317", env[getIndexOfEnv(env, constants.EnvOTELExporterOTLPEndpoint)].Value)
+	assert.Equal(t, "grpc", env[getIndexOfEnv(env, constants.EnvOTELExporterOTLPProtocol)].Value)
+	assert.Equal(t, -1, getIndexOfEnv(env, constants.EnvNodeIP))
+}
@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// shells are the executables whose script is looked at to detect the language of a container, as in
+// `sh -c "java -jar app.jar"`.
+var shells = map[string]bool{"sh": true, "bash": true, "ash": true, "dash": true}
+
+// executableLanguages are the languages of the well-known executables starting the containers' processes.
+var executableLanguages = map[string]string{
+	"java":       "java",
+	"node":       "nodejs",
+	"nodejs":     "nodejs",
+	"npm":        "nodejs",
+	"npx":        "nodejs",
+	"yarn":       "nodejs",
+	"python":     "python",
+	"python3":    "python",
+	"gunicorn":   "python",
+	"uvicorn":    "python",
+	"celery":     "python",
+	"dotnet":     "dotnet",
+	"ruby":       "ruby",
+	"bundle":     "ruby",
+	"rails":      "ruby",
+	"puma":       "ruby",
+	"php":        "php",
+	"php-fpm":    "php",
+	"nginx":      "nginx",
+	"httpd":      "apache-httpd",
+	"apachectl":  "apache-httpd",
+	"apache2ctl": "apache-httpd",
+}
+
+// envLanguages are the languages of the well-known env vars set by the languages' runtimes and official images.
+var envLanguages = []struct {
+	name     string
+	language string
+}{
+	{"JAVA_HOME", "java"},
+	{"JAVA_VERSION", "java"},
+	{"JAVA_TOOL_OPTIONS", "java"},
+	{"NODE_VERSION", "nodejs"},
+	{"NODE_OPTIONS", "nodejs"},
+	{"PYTHON_VERSION", "python"},
+	{"PYTHONPATH", "python"},
+	{"DOTNET_VERSION", "dotnet"},
+	{"ASPNETCORE_URLS", "dotnet"},
+	{"RUBY_VERSION", "ruby"},
+	{"GEM_HOME", "ruby"},
+	{"PHP_VERSION", "php"},
+	{"PHP_INI_DIR", "php"},
+	{"NGINX_VERSION", "nginx"},
+	{"HTTPD_VERSION", "apache-httpd"},
+}
+
+// imageLanguages are the languages of the well-known runtime images, by repository name.
+var imageLanguages = map[string]string{
+	"openjdk":             "java",
+	"eclipse-temurin":     "java",
+	"amazoncorretto":      "java",
+	"ibm-semeru-runtimes": "java",
+	"sapmachine":          "java",
+	"node":                "nodejs",
+	"python":              "python",
+	"aspnet":              "dotnet",
+	"runtime":             "dotnet",
+	"ruby":                "ruby",
+	"php":                 "php",
+	"nginx":               "nginx",
+	"nginx-unprivileged":  "nginx",
+	"httpd":               "apache-httpd",
+}
+
+// detectLanguage returns the language of the container, see LanguageInjector.Language, or an empty string when it
+// can't be detected. The mappings of the Instrumentation take precedence over the container's command and args, its
+// env vars and lastly the name of its image.
+func detectLanguage(detection v1alpha1.LanguageDetection, container corev1.Container) string {
+	for _, mapping := range detection.Images {
+		if matched, _ := path.Match(mapping.Image, container.Image); matched {
+			return mapping.Language
+		}
+	}
+	if language := commandLanguage(append(append([]string{}, container.Command...), container.Args...)); language != "" {
+		return language
+	}
+	for _, env := range envLanguages {
+		if getIndexOfEnv(container.Env, env.name) > -1 {
+			return env.language
+		}
+	}
+	return imageLanguage(container.Image)
+}
+
+// commandLanguage returns the language of the executable starting the command, looking into the scripts run by shells.
+func commandLanguage(command []string) string {
+	if len(command) == 0 {
+		return ""
+	}
+	executable := path.Base(command[0])
+	if shells[executable] {
+		for i, arg := range command[1:] {
+			if arg == "-c" && i+2 < len(command) {
+				return commandLanguage(strings.Fields(command[i+2]))
+			}
+		}
+		return ""
+	}
+	if executable == "exec" {
+		return commandLanguage(command[1:])
+	}
+	if language, ok := executableLanguages[executable]; ok {
+		return language
+	}
+	// versioned executables, e.g. python3.11 or php-fpm8.2
+	if language, ok := executableLanguages[strings.TrimRight(executable, "0123456789.")]; ok {
+		return language
+	}
+	return ""
+}
+
+// imageLanguage returns the language of the well-known runtime image the given image is, or is derived from by name.
+func imageLanguage(image string) string {
+	if i := strings.Index(image, "@"); i > -1 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	repository := path.Base(image)
+	// the .NET images are the aspnet and runtime repositories of mcr.microsoft.com/dotnet
+	if (repository == "aspnet" || repository == "runtime") && path.Base(path.Dir(image)) != "dotnet" {
+		return ""
+	}
+	return imageLanguages[repository]
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	detection := v1alpha1.LanguageDetection{
+		Images: []v1alpha1.ImageLanguage{
+			{Image: "registry.example.com/payments/*", Language: "nodejs"},
+			{Image: "registry.example.com/*:legacy", Language: "java"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		container corev1.Container
+		expected  string
+	}{
+		{
+			name:      "mapped image",
+			container: corev1.Container{Image: "registry.example.com/payments/api:1", Command: []string{"python"}},
+			expected:  "nodejs",
+		},
+		{
+			name:      "mapped image tag",
+			container: corev1.Container{Image: "registry.example.com/billing:legacy"},
+			expected:  "java",
+		},
+		{
+			name:      "java jar",
+			container: corev1.Container{Image: "registry.example.com/api", Command: []string{"java", "-jar", "app.jar"}},
+			expected:  "java",
+		},
+		{
+			name:      "absolute executable path",
+			container: corev1.Container{Image: "app", Command: []string{"/usr/local/bin/node"}, Args: []string{"server.js"}},
+			expected:  "nodejs",
+		},
+		{
+			name:      "args only",
+			container: corev1.Container{Image: "app", Args: []string{"dotnet", "App.dll"}},
+			expected:  "dotnet",
+		},
+		{
+			name:      "versioned executable",
+			container: corev1.Container{Image: "app", Command: []string{"python3.11", "-m", "app"}},
+			expected:  "python",
+		},
+		{
+			name:      "shell script",
+			container: corev1.Container{Image: "app", Command: []string{"/bin/sh", "-c", "exec bundle exec puma"}},
+			expected:  "ruby",
+		},
+		{
+			name:      "command taking precedence over the env vars",
+			container: corev1.Container{Image: "app", Command: []string{"php-fpm8.2"}, Env: []corev1.EnvVar{{Name: "NODE_VERSION", Value: "20"}}},
+			expected:  "php",
+		},
+		{
+			name:      "well-known env var",
+			container: corev1.Container{Image: "app", Env: []corev1.EnvVar{{Name: "JAVA_TOOL_OPTIONS", Value: "-Xmx1g"}}},
+			expected:  "java",
+		},
+		{
+			name:      "runtime image",
+			container: corev1.Container{Image: "docker.io/library/eclipse-temurin:21-jre"},
+			expected:  "java",
+		},
+		{
+			name:      "runtime image digest",
+			container: corev1.Container{Image: "node@sha256:4b0f1e1c7d3c"},
+			expected:  "nodejs",
+		},
+		{
+			name:      "dotnet image",
+			container: corev1.Container{Image: "mcr.microsoft.com/dotnet/aspnet:8.0"},
+			expected:  "dotnet",
+		},
+		{
+			name:      "registry with a port",
+			container: corev1.Container{Image: "localhost:5000/nginx"},
+			expected:  "nginx",
+		},
+		{
+			name:      "runtime repository outside of dotnet",
+			container: corev1.Container{Image: "example/runtime:1"},
+			expected:  "",
+		},
+		{
+			name:      "unknown",
+			container: corev1.Container{Image: "envoyproxy/envoy:v1.28", Command: []string{"envoy"}},
+			expected:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, detectLanguage(detection, tt.container))
+		})
+	}
+}
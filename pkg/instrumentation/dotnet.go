@@ -15,12 +15,15 @@
 package instrumentation
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 const (
@@ -154,3 +157,52 @@ func setDotNetEnvVar(container *corev1.Container, envVarName string, envVarValue
 		container.Env[idx].Value = fmt.Sprintf("%s:%s", container.Env[idx].Value, envVarValue)
 	}
 }
+
+// dotNetInjector injects the .NET auto-instrumentation.
+type dotNetInjector struct{}
+
+func (dotNetInjector) Language() string { return "dotnet" }
+
+func (dotNetInjector) Name() string { return ".NET" }
+
+func (dotNetInjector) Annotation() string { return annotationInjectDotNet }
+
+func (dotNetInjector) ContainersAnnotation() string { return annotationInjectDotnetContainersName }
+
+func (dotNetInjector) Protocol() v1alpha1.ExporterProtocol {
+	return v1alpha1.ExporterProtocolHTTPProtobuf
+}
+
+func (dotNetInjector) Enabled() bool {
+	return featuregate.EnableDotnetAutoInstrumentationSupport.IsEnabled()
+}
+
+func (dotNetInjector) Section(spec v1alpha1.InstrumentationSpec) (LanguageSection, bool) {
+	return LanguageSection{
+		Image:       spec.DotNet.Image,
+		Env:         spec.DotNet.Env,
+		Resources:   spec.DotNet.Resources,
+		Exporter:    spec.DotNet.Exporter,
+		Sampler:     spec.DotNet.Sampler,
+		Propagators: spec.DotNet.Propagators,
+	}, true
+}
+
+func (dotNetInjector) inject(ctx context.Context, i *sdkInjector, inst instrumentationWithContainers, ns corev1.Namespace, pod corev1.Pod) corev1.Pod {
+	otelinst := *inst.Instrumentation
+	var err error
+	i.logger.V(1).Info("injecting DotNet instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+
+	for _, container := range strings.Split(inst.Containers, ",") {
+		index := getContainerIndex(container, pod)
+		pod, err = injectDotNetSDK(otelinst.Spec.DotNet, pod, index, annotationValue(ns.ObjectMeta, pod.ObjectMeta, annotationDotNetRuntime))
+		if err != nil {
+			i.logger.Info("Skipping DotNet SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
+		} else {
+			pod = i.injectCommonEnvVar(otelinst, pod, index)
+			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
+			pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, dotnetInitContainerName)
+		}
+	}
+	return pod
+}
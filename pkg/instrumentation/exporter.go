@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
+)
+
+const (
+	defaultNodeLocalGRPCPort int32 = 4317
+	defaultNodeLocalHTTPPort int32 = 4318
+
+	exporterSecretVolumePrefix    = "otel-auto-secret-"
+	exporterConfigMapVolumePrefix = "otel-auto-configmap-"
+	exporterSecretMountPrefix     = "/otel-auto-instrumentation-secret-"
+	exporterConfigMapMountPrefix  = "/otel-auto-instrumentation-configmap-"
+)
+
+// exporterEndpoint returns the endpoint of the exporter. In node-local mode, it's built from the node's IP, set in
+// the env var returned by nodeIPEnvVar.
+func exporterEndpoint(exporter v1alpha1.Exporter) string {
+	if exporter.NodeLocal == nil {
+		return exporter.Endpoint
+	}
+
+	scheme := "http"
+	if exporter.TLS != nil {
+		scheme = "https"
+	}
+	port := exporter.NodeLocal.Port
+	if port == 0 {
+		port = defaultNodeLocalGRPCPort
+		if exporter.Protocol == v1alpha1.ExporterProtocolHTTPProtobuf || exporter.Protocol == v1alpha1.ExporterProtocolHTTPJSON {
+			port = defaultNodeLocalHTTPPort
+		}
+	}
+	return fmt.Sprintf("%s://$(%s):%d", scheme, constants.EnvNodeIP, port)
+}
+
+// nodeIPEnvVar returns the env var holding the IP of the pod's node, which the node-local endpoints refer to.
+func nodeIPEnvVar() corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: constants.EnvNodeIP,
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: "status.hostIP",
+			},
+		},
+	}
+}
+
+// injectExporterConfig configures the protocol, headers and TLS of the OTLP exporter of the container at the given
+// index. The Secret and ConfigMap holding the certificates are mounted into the container.
+func injectExporterConfig(exporter v1alpha1.Exporter, pod corev1.Pod, index int) corev1.Pod {
+	container := &pod.Spec.Containers[index]
+
+	if exporter.Protocol != "" && getIndexOfEnv(container.Env, constants.EnvOTELExporterOTLPProtocol) == -1 {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  constants.EnvOTELExporterOTLPProtocol,
+			Value: string(exporter.Protocol),
+		})
+	}
+
+	if len(exporter.Headers) > 0 && getIndexOfEnv(container.Env, constants.EnvOTELExporterOTLPHeaders) == -1 {
+		headers := make([]string, 0, len(exporter.Headers))
+		for i, header := range exporter.Headers {
+			value := header.Value
+			// the values of the secrets are referenced by env vars, which have to be defined before
+			if header.SecretKeyRef != nil {
+				name := fmt.Sprintf("%s_%d", constants.EnvOTELExporterOTLPHeaders, i)
+				container.Env = append(container.Env, corev1.EnvVar{
+					Name:      name,
+					ValueFrom: &corev1.EnvVarSource{SecretKeyRef: header.SecretKeyRef},
+				})
+				value = fmt.Sprintf("$(%s)", name)
+			}
+			headers = append(headers, fmt.Sprintf("%s=%s", header.Name, value))
+		}
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  constants.EnvOTELExporterOTLPHeaders,
+			Value: strings.Join(headers, ","),
+		})
+	}
+
+	if exporter.TLS == nil {
+		return pod
+	}
+	tls := *exporter.TLS
+	ca, cert, key := exporterTLSFiles(tls)
+	for _, file := range []struct {
+		env  string
+		path string
+	}{
+		{constants.EnvOTELExporterOTLPCertificate, ca},
+		{constants.EnvOTELExporterOTLPClientCertificate, cert},
+		{constants.EnvOTELExporterOTLPClientKey, key},
+	} {
+		if file.path != "" && getIndexOfEnv(container.Env, file.env) == -1 {
+			container.Env = append(container.Env, corev1.EnvVar{Name: file.env, Value: file.path})
+		}
+	}
+
+	if tls.SecretName != "" && (isMountedFile(cert) || isMountedFile(key) || (tls.ConfigMapName == "" && isMountedFile(ca))) {
+		pod = mountExporterVolume(pod, index, corev1.Volume{
+			Name: exporterVolumeName(exporterSecretVolumePrefix, tls.SecretName),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: tls.SecretName},
+			},
+		}, exporterSecretMountPrefix+tls.SecretName)
+	}
+	if tls.ConfigMapName != "" && isMountedFile(ca) {
+		pod = mountExporterVolume(pod, index, corev1.Volume{
+			Name: exporterVolumeName(exporterConfigMapVolumePrefix, tls.ConfigMapName),
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: tls.ConfigMapName},
+				},
+			},
+		}, exporterConfigMapMountPrefix+tls.ConfigMapName)
+	}
+	return pod
+}
+
+// exporterTLSFiles returns the paths of the CA certificate, client certificate and client key of the exporter in the
+// instrumented containers. A path is empty when its file isn't set.
+func exporterTLSFiles(tls v1alpha1.TLS) (ca, cert, key string) {
+	secretFile := func(file string) string {
+		if file == "" || filepath.IsAbs(file) {
+			return file
+		}
+		return filepath.Join(exporterSecretMountPrefix+tls.SecretName, file)
+	}
+
+	ca = secretFile(tls.CA)
+	if tls.CA != "" && !filepath.IsAbs(tls.CA) && tls.ConfigMapName != "" {
+		ca = filepath.Join(exporterConfigMapMountPrefix+tls.ConfigMapName, tls.CA)
+	}
+	return ca, secretFile(tls.Cert), secretFile(tls.Key)
+}
+
+// isMountedFile returns whether the given path is in one of the Secrets or ConfigMaps mounted by the operator.
+func isMountedFile(path string) bool {
+	return strings.HasPrefix(path, exporterSecretMountPrefix) || strings.HasPrefix(path, exporterConfigMapMountPrefix)
+}
+
+// exporterVolumeName returns the name of the volume of the given Secret or ConfigMap, which may contain dots.
+func exporterVolumeName(prefix, name string) string {
+	return naming.DNSName(naming.Truncate(prefix+"%s", 63, name))
+}
+
+// mountExporterVolume mounts the given volume read-only into the container at the given index, adding it to the pod
+// unless it was added for another container.
+func mountExporterVolume(pod corev1.Pod, index int, volume corev1.Volume, mountPath string) corev1.Pod {
+	found := false
+	for _, v := range pod.Spec.Volumes {
+		if v.Name == volume.Name {
+			found = true
+			break
+		}
+	}
+	if !found {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, volume)
+	}
+
+	container := &pod.Spec.Containers[index]
+	for _, mount := range container.VolumeMounts {
+		if mount.Name == volume.Name {
+			return pod
+		}
+	}
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      volume.Name,
+		MountPath: mountPath,
+		ReadOnly:  true,
+	})
+	return pod
+}
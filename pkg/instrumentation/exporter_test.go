@@ -0,0 +1,224 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestInjectExporterConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		exporter v1alpha1.Exporter
+		pod      corev1.Pod
+		expected corev1.Pod
+	}{
+		{
+			name:     "endpoint only",
+			exporter: v1alpha1.Exporter{Endpoint: "http://collector:4317"},
+			pod: corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "app"},
+			}}},
+			expected: corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "app"},
+			}}},
+		},
+		{
+			name: "protocol and headers",
+			exporter: v1alpha1.Exporter{
+				Protocol: v1alpha1.ExporterProtocolHTTPProtobuf,
+				Headers: []v1alpha1.ExporterHeader{
+					{Name: "x-tenant", Value: "payments"},
+					{Name: "authorization", SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "otlp-auth"},
+						Key:                  "token",
+					}},
+				},
+			},
+			pod: corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "app"},
+			}}},
+			expected: corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{
+				{
+					Name: "app",
+					Env: []corev1.EnvVar{
+						{Name: "OTEL_EXPORTER_OTLP_PROTOCOL", Value: "http/protobuf"},
+						{Name: "OTEL_EXPORTER_OTLP_HEADERS_1", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "otlp-auth"},
+							Key:                  "token",
+						}}},
+						{Name: "OTEL_EXPORTER_OTLP_HEADERS", Value: "x-tenant=payments,authorization=$(OTEL_EXPORTER_OTLP_HEADERS_1)"},
+					},
+				},
+			}}},
+		},
+		{
+			name: "container env vars take precedence",
+			exporter: v1alpha1.Exporter{
+				Protocol: v1alpha1.ExporterProtocolGRPC,
+				Headers:  []v1alpha1.ExporterHeader{{Name: "x-tenant", Value: "payments"}},
+			},
+			pod: corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "app", Env: []corev1.EnvVar{
+					{Name: "OTEL_EXPORTER_OTLP_PROTOCOL", Value: "http/json"},
+					{Name: "OTEL_EXPORTER_OTLP_HEADERS", Value: "x-tenant=billing"},
+				}},
+			}}},
+			expected: corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "app", Env: []corev1.EnvVar{
+					{Name: "OTEL_EXPORTER_OTLP_PROTOCOL", Value: "http/json"},
+					{Name: "OTEL_EXPORTER_OTLP_HEADERS", Value: "x-tenant=billing"},
+				}},
+			}}},
+		},
+		{
+			name: "tls from a secret and a configmap",
+			exporter: v1alpha1.Exporter{
+				TLS: &v1alpha1.TLS{
+					SecretName:    "otlp.client-certs",
+					ConfigMapName: "otlp-ca",
+					CA:            "ca.crt",
+					Cert:          "tls.crt",
+					Key:           "tls.key",
+				},
+			},
+			pod: corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "app"},
+			}}},
+			expected: corev1.Pod{Spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{
+					{
+						Name: "otel-auto-secret-otlp-client-certs",
+						VolumeSource: corev1.VolumeSource{
+							Secret: &corev1.SecretVolumeSource{SecretName: "otlp.client-certs"},
+						},
+					},
+					{
+						Name: "otel-auto-configmap-otlp-ca",
+						VolumeSource: corev1.VolumeSource{
+							ConfigMap: &corev1.ConfigMapVolumeSource{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "otlp-ca"},
+							},
+						},
+					},
+				},
+				Containers: []corev1.Container{
+					{
+						Name: "app",
+						Env: []corev1.EnvVar{
+							{Name: "OTEL_EXPORTER_OTLP_CERTIFICATE", Value: "/otel-auto-instrumentation-configmap-otlp-ca/ca.crt"},
+							{Name: "OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE", Value: "/otel-auto-instrumentation-secret-otlp.client-certs/tls.crt"},
+							{Name: "OTEL_EXPORTER_OTLP_CLIENT_KEY", Value: "/otel-auto-instrumentation-secret-otlp.client-certs/tls.key"},
+						},
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "otel-auto-secret-otlp-client-certs", MountPath: "/otel-auto-instrumentation-secret-otlp.client-certs", ReadOnly: true},
+							{Name: "otel-auto-configmap-otlp-ca", MountPath: "/otel-auto-instrumentation-configmap-otlp-ca", ReadOnly: true},
+						},
+					},
+				},
+			}},
+		},
+		{
+			name: "tls ca from an absolute path",
+			exporter: v1alpha1.Exporter{
+				TLS: &v1alpha1.TLS{
+					SecretName: "otlp-certs",
+					CA:         "/var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt",
+				},
+			},
+			pod: corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "app"},
+			}}},
+			expected: corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{
+				{
+					Name: "app",
+					Env: []corev1.EnvVar{
+						{Name: "OTEL_EXPORTER_OTLP_CERTIFICATE", Value: "/var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt"},
+					},
+				},
+			}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := injectExporterConfig(test.exporter, test.pod, 0)
+			assert.Equal(t, test.expected, pod)
+		})
+	}
+}
+
+func TestInjectExporterConfigMultipleContainers(t *testing.T) {
+	exporter := v1alpha1.Exporter{
+		TLS: &v1alpha1.TLS{SecretName: "otlp-certs", CA: "ca.crt"},
+	}
+	pod := corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{
+		{Name: "app1"},
+		{Name: "app2"},
+	}}}
+
+	pod = injectExporterConfig(exporter, pod, 0)
+	pod = injectExporterConfig(exporter, pod, 1)
+
+	assert.Len(t, pod.Spec.Volumes, 1)
+	for _, container := range pod.Spec.Containers {
+		assert.Equal(t, []corev1.VolumeMount{
+			{Name: "otel-auto-secret-otlp-certs", MountPath: "/otel-auto-instrumentation-secret-otlp-certs", ReadOnly: true},
+		}, container.VolumeMounts)
+		assert.Equal(t, []corev1.EnvVar{
+			{Name: "OTEL_EXPORTER_OTLP_CERTIFICATE", Value: "/otel-auto-instrumentation-secret-otlp-certs/ca.crt"},
+		}, container.Env)
+	}
+}
+
+func TestExporterEndpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		exporter v1alpha1.Exporter
+		expected string
+	}{
+		{
+			name:     "fixed endpoint",
+			exporter: v1alpha1.Exporter{Endpoint: "http://collector:4317"},
+			expected: "http://collector:4317",
+		},
+		{
+			name:     "node-local default port",
+			exporter: v1alpha1.Exporter{NodeLocal: &v1alpha1.NodeLocal{}},
+			expected: "http://$(OTEL_NODE_IP):4317",
+		},
+		{
+			name:     "node-local default http port",
+			exporter: v1alpha1.Exporter{NodeLocal: &v1alpha1.NodeLocal{}, Protocol: v1alpha1.ExporterProtocolHTTPJSON},
+			expected: "http://$(OTEL_NODE_IP):4318",
+		},
+		{
+			name:     "node-local port with tls",
+			exporter: v1alpha1.Exporter{NodeLocal: &v1alpha1.NodeLocal{Port: 14317}, TLS: &v1alpha1.TLS{CA: "/etc/ca.crt"}},
+			expected: "https://$(OTEL_NODE_IP):14317",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, exporterEndpoint(test.exporter))
+		})
+	}
+}
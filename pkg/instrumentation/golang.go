@@ -15,6 +15,7 @@
 package instrumentation
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -99,3 +100,56 @@ func injectGoSDK(goSpec v1alpha1.Go, pod corev1.Pod) (corev1.Pod, error) {
 	})
 	return pod, nil
 }
+
+// goInjector injects the Go auto-instrumentation.
+type goInjector struct{}
+
+func (goInjector) Language() string { return "go" }
+
+func (goInjector) Name() string { return "Go" }
+
+func (goInjector) Annotation() string { return annotationInjectGo }
+
+func (goInjector) ContainersAnnotation() string { return annotationInjectGoContainersName }
+
+func (goInjector) Protocol() v1alpha1.ExporterProtocol { return v1alpha1.ExporterProtocolHTTPProtobuf }
+
+func (goInjector) Enabled() bool { return featuregate.EnableGoAutoInstrumentationSupport.IsEnabled() }
+
+func (goInjector) Section(spec v1alpha1.InstrumentationSpec) (LanguageSection, bool) {
+	return LanguageSection{
+		Image:       spec.Go.Image,
+		Env:         spec.Go.Env,
+		Resources:   spec.Go.Resources,
+		Exporter:    spec.Go.Exporter,
+		Sampler:     spec.Go.Sampler,
+		Propagators: spec.Go.Propagators,
+	}, true
+}
+
+func (goInjector) inject(ctx context.Context, i *sdkInjector, inst instrumentationWithContainers, ns corev1.Namespace, pod corev1.Pod) corev1.Pod {
+	origPod := pod
+	otelinst := *inst.Instrumentation
+	var err error
+	i.logger.V(1).Info("injecting Go instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+
+	// Go instrumentation supports only single container instrumentation.
+	index := getContainerIndex(inst.Containers, pod)
+	pod, err = injectGoSDK(otelinst.Spec.Go, pod)
+	if err != nil {
+		i.logger.Info("Skipping Go SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
+		return pod
+	}
+
+	// Common env vars and config need to be applied to the agent contain.
+	pod = i.injectCommonEnvVar(otelinst, pod, len(pod.Spec.Containers)-1)
+	pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, len(pod.Spec.Containers)-1, 0)
+
+	// Ensure that after all the env var coalescing we have a value for OTEL_GO_AUTO_TARGET_EXE
+	idx := getIndexOfEnv(pod.Spec.Containers[len(pod.Spec.Containers)-1].Env, envOtelTargetExe)
+	if idx == -1 {
+		i.logger.Info("Skipping Go SDK injection", "reason", "OTEL_GO_AUTO_TARGET_EXE not set", "container", pod.Spec.Containers[index].Name)
+		return origPod
+	}
+	return pod
+}
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+const (
+	// InjectedLabel is set on the pods auto-instrumentation was injected into.
+	InjectedLabel = "instrumentation.opentelemetry.io/injected"
+	// InjectionHashesAnnotation records the Instrumentations a pod was injected with, and for which languages,
+	// as comma-separated "namespace/name/language=hash" entries, see InjectionHash.
+	InjectionHashesAnnotation = "instrumentation.opentelemetry.io/injection-hashes"
+)
+
+// injectedSpec holds the settings of an Instrumentation spec which determine what is injected for a language.
+type injectedSpec struct {
+	Exporter    v1alpha1.Exporter     `json:"exporter"`
+	Resource    v1alpha1.Resource     `json:"resource"`
+	Propagators []v1alpha1.Propagator `json:"propagators,omitempty"`
+	Sampler     v1alpha1.Sampler      `json:"sampler"`
+	Env         []corev1.EnvVar       `json:"env,omitempty"`
+	Language    interface{}           `json:"language,omitempty"`
+}
+
+// InjectionHash returns the hash of the settings of the given Instrumentation which determine what is injected into
+// the pods for the given language: the settings shared by the languages, and the language's section. Changes to the
+// sections of the other languages don't change it.
+func InjectionHash(inst v1alpha1.Instrumentation, language string) (string, error) {
+	b, err := json.Marshal(injectedSpec{
+		Exporter:    inst.Spec.Exporter,
+		Resource:    inst.Spec.Resource,
+		Propagators: inst.Spec.Propagators,
+		Sampler:     inst.Spec.Sampler,
+		Env:         inst.Spec.Env,
+		Language:    languageSpec(inst.Spec, language),
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(b)), nil
+}
+
+// languageSpec returns the section of the given language in an Instrumentation spec, if it has one.
+func languageSpec(spec v1alpha1.InstrumentationSpec, language string) interface{} {
+	switch language {
+	case javaInjector{}.Language():
+		return spec.Java
+	case nodeJSInjector{}.Language():
+		return spec.NodeJS
+	case pythonInjector{}.Language():
+		return spec.Python
+	case dotNetInjector{}.Language():
+		return spec.DotNet
+	case goInjector{}.Language():
+		return spec.Go
+	case apacheHttpdInjector{}.Language():
+		return spec.ApacheHttpd
+	case nginxInjector{}.Language():
+		return spec.Nginx
+	case rubyInjector{}.Language():
+		return spec.Ruby
+	case phpInjector{}.Language():
+		return spec.PHP
+	default:
+		return nil
+	}
+}
+
+// InjectionHashes returns the hashes the given pod was injected with, by "namespace/name/language".
+func InjectionHashes(pod corev1.Pod) map[string]string {
+	hashes := map[string]string{}
+	for _, entry := range strings.Split(pod.Annotations[InjectionHashesAnnotation], ",") {
+		if key, hash, ok := strings.Cut(entry, "="); ok {
+			hashes[key] = hash
+		}
+	}
+	return hashes
+}
+
+// recordInjectionHashes records the Instrumentations used for the given pod, so that the pod can be restarted
+// when they change. They are only recorded when the injection rollout is enabled.
+func recordInjectionHashes(insts languageInstrumentations, pod corev1.Pod) corev1.Pod {
+	if !featuregate.EnableInjectionRollout.IsEnabled() {
+		return pod
+	}
+
+	hashes := map[string]string{}
+	for _, lang := range languageInjectors {
+		inst := insts[lang.Language()].Instrumentation
+		if inst == nil {
+			continue
+		}
+		hash, err := InjectionHash(*inst, lang.Language())
+		if err != nil {
+			continue
+		}
+		hashes[fmt.Sprintf("%s/%s/%s", inst.Namespace, inst.Name, lang.Language())] = hash
+	}
+	if len(hashes) == 0 {
+		return pod
+	}
+
+	entries := make([]string, 0, len(hashes))
+	for key, hash := range hashes {
+		entries = append(entries, fmt.Sprintf("%s=%s", key, hash))
+	}
+	sort.Strings(entries)
+
+	if pod.Labels == nil {
+		pod.Labels = map[string]string{}
+	}
+	pod.Labels[InjectedLabel] = "true"
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[InjectionHashesAnnotation] = strings.Join(entries, ",")
+	return pod
+}
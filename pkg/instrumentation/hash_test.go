@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+func TestRecordInjectionHashes(t *testing.T) {
+	java := &v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{Name: "java", Namespace: "app"},
+		Spec:       v1alpha1.InstrumentationSpec{Java: v1alpha1.Java{Image: "java:1"}},
+	}
+	sdk := &v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{Name: "sdk", Namespace: "platform"},
+		Spec:       v1alpha1.InstrumentationSpec{Exporter: v1alpha1.Exporter{Endpoint: "http://collector:4317"}},
+	}
+	insts := languageInstrumentations{
+		"java": {Instrumentation: java},
+		"sdk":  {Instrumentation: sdk},
+	}
+
+	// disabled by default, the pods are left untouched
+	pod := recordInjectionHashes(insts, corev1.Pod{})
+	assert.Empty(t, pod.Labels)
+	assert.Empty(t, pod.Annotations)
+
+	originalVal := featuregate.EnableInjectionRollout.IsEnabled()
+	require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableInjectionRollout.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableInjectionRollout.ID(), originalVal))
+	})
+
+	pod = recordInjectionHashes(insts, corev1.Pod{})
+	assert.Equal(t, "true", pod.Labels[InjectedLabel])
+
+	javaHash, err := InjectionHash(*java, "java")
+	require.NoError(t, err)
+	sdkHash, err := InjectionHash(*sdk, "sdk")
+	require.NoError(t, err)
+	assert.NotEqual(t, javaHash, sdkHash)
+	assert.Equal(t, "app/java/java="+javaHash+",platform/sdk/sdk="+sdkHash, pod.Annotations[InjectionHashesAnnotation])
+	assert.Equal(t, map[string]string{"app/java/java": javaHash, "platform/sdk/sdk": sdkHash}, InjectionHashes(pod))
+}
+
+func TestInjectionHash(t *testing.T) {
+	inst := v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{Name: "multi", Namespace: "app"},
+		Spec: v1alpha1.InstrumentationSpec{
+			Exporter: v1alpha1.Exporter{Endpoint: "http://collector:4317"},
+			Java:     v1alpha1.Java{Image: "java:1"},
+			Python:   v1alpha1.Python{Image: "python:1"},
+		},
+	}
+	javaHash, err := InjectionHash(inst, "java")
+	require.NoError(t, err)
+	pythonHash, err := InjectionHash(inst, "python")
+	require.NoError(t, err)
+	assert.NotEqual(t, javaHash, pythonHash)
+
+	// the sections of the other languages don't matter
+	inst.Spec.Python.Image = "python:2"
+	unchanged, err := InjectionHash(inst, "java")
+	require.NoError(t, err)
+	assert.Equal(t, javaHash, unchanged)
+	updatedPython, err := InjectionHash(inst, "python")
+	require.NoError(t, err)
+	assert.NotEqual(t, pythonHash, updatedPython)
+
+	// the language's section does
+	inst.Spec.Java.Image = "java:2"
+	updatedJava, err := InjectionHash(inst, "java")
+	require.NoError(t, err)
+	assert.NotEqual(t, javaHash, updatedJava)
+
+	// and so do the settings shared by the languages
+	inst.Spec.Exporter.Endpoint = "http://other-collector:4317"
+	updatedExporter, err := InjectionHash(inst, "java")
+	require.NoError(t, err)
+	assert.NotEqual(t, updatedJava, updatedExporter)
+
+	// the failure policy doesn't change what is injected
+	inst.Spec.InjectionFailurePolicy = v1alpha1.InjectionFailurePolicyFail
+	unchanged, err = InjectionHash(inst, "java")
+	require.NoError(t, err)
+	assert.Equal(t, updatedExporter, unchanged)
+}
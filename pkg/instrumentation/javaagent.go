@@ -15,9 +15,13 @@
 package instrumentation
 
 import (
+	"context"
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 const (
@@ -83,3 +87,50 @@ func injectJavaagent(javaSpec v1alpha1.Java, pod corev1.Pod, index int) (corev1.
 	}
 	return pod, err
 }
+
+// javaInjector injects the Java auto-instrumentation.
+type javaInjector struct{}
+
+func (javaInjector) Language() string { return "java" }
+
+func (javaInjector) Name() string { return "Java" }
+
+func (javaInjector) Annotation() string { return annotationInjectJava }
+
+func (javaInjector) ContainersAnnotation() string { return annotationInjectJavaContainersName }
+
+func (javaInjector) Protocol() v1alpha1.ExporterProtocol { return v1alpha1.ExporterProtocolGRPC }
+
+func (javaInjector) Enabled() bool {
+	return featuregate.EnableJavaAutoInstrumentationSupport.IsEnabled()
+}
+
+func (javaInjector) Section(spec v1alpha1.InstrumentationSpec) (LanguageSection, bool) {
+	return LanguageSection{
+		Image:       spec.Java.Image,
+		Env:         spec.Java.Env,
+		Resources:   spec.Java.Resources,
+		Exporter:    spec.Java.Exporter,
+		Sampler:     spec.Java.Sampler,
+		Propagators: spec.Java.Propagators,
+	}, true
+}
+
+func (javaInjector) inject(ctx context.Context, i *sdkInjector, inst instrumentationWithContainers, ns corev1.Namespace, pod corev1.Pod) corev1.Pod {
+	otelinst := *inst.Instrumentation
+	var err error
+	i.logger.V(1).Info("injecting Java instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+
+	for _, container := range strings.Split(inst.Containers, ",") {
+		index := getContainerIndex(container, pod)
+		pod, err = injectJavaagent(otelinst.Spec.Java, pod, index)
+		if err != nil {
+			i.logger.Info("Skipping javaagent injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
+		} else {
+			pod = i.injectCommonEnvVar(otelinst, pod, index)
+			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
+			pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, javaInitContainerName)
+		}
+	}
+	return pod
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// LanguageInjector injects the auto-instrumentation of a language into the pods requesting it.
+// A language is supported once its injector is registered in languageInjectors.
+type LanguageInjector interface {
+	// Language returns the identifier of the language, e.g. "java" for the "inject-java" annotation.
+	Language() string
+	// Name returns the name of the language used in the logs and events, e.g. "Java".
+	Name() string
+	// Annotation returns the annotation of the pods and namespaces requesting the injection.
+	Annotation() string
+	// ContainersAnnotation returns the annotation naming the containers to instrument when multiple
+	// instrumentations are enabled.
+	ContainersAnnotation() string
+	// Enabled returns whether the support of the language is enabled by its feature gate.
+	Enabled() bool
+	// Protocol returns the OTLP protocol the language's auto-instrumentation exports with by default.
+	Protocol() v1alpha1.ExporterProtocol
+	// Section returns the language's section of the given Instrumentation spec, if it has one.
+	Section(spec v1alpha1.InstrumentationSpec) (LanguageSection, bool)
+
+	// inject injects the given Instrumentation into the containers of the pod it's requested for.
+	inject(ctx context.Context, i *sdkInjector, inst instrumentationWithContainers, ns corev1.Namespace, pod corev1.Pod) corev1.Pod
+}
+
+// LanguageSection holds the settings shared by the languages' sections of an Instrumentation spec.
+type LanguageSection struct {
+	Image       string
+	Env         []corev1.EnvVar
+	Resources   corev1.ResourceRequirements
+	Exporter    *v1alpha1.Exporter
+	Sampler     *v1alpha1.Sampler
+	Propagators []v1alpha1.Propagator
+}
+
+// languageInjectors are the supported languages, in the order they're injected in.
+var languageInjectors = []LanguageInjector{
+	javaInjector{},
+	nodeJSInjector{},
+	pythonInjector{},
+	dotNetInjector{},
+	goInjector{},
+	apacheHttpdInjector{},
+	nginxInjector{},
+	rubyInjector{},
+	phpInjector{},
+	sdkOnlyInjector{},
+}
+
+// LanguageInjectors returns the supported languages, in the order they're injected in.
+func LanguageInjectors() []LanguageInjector {
+	return append([]LanguageInjector{}, languageInjectors...)
+}
+
+// withLanguageOverrides returns the Instrumentation to inject for the given language: the exporter, sampler and
+// propagators set in the language's section replace the ones of the Instrumentation, and the resolved exporter
+// replaces both.
+func withLanguageOverrides(lang LanguageInjector, inst instrumentationWithContainers) instrumentationWithContainers {
+	section, ok := lang.Section(inst.Instrumentation.Spec)
+	if !ok {
+		section = LanguageSection{}
+	}
+	if inst.Exporter == nil && section.Exporter == nil && section.Sampler == nil && len(section.Propagators) == 0 {
+		return inst
+	}
+
+	otelinst := inst.Instrumentation.DeepCopy()
+	if section.Exporter != nil {
+		otelinst.Spec.Exporter = *section.Exporter
+	}
+	if inst.Exporter != nil {
+		otelinst.Spec.Exporter = *inst.Exporter
+	}
+	if section.Sampler != nil {
+		otelinst.Spec.Sampler = *section.Sampler
+	}
+	if len(section.Propagators) > 0 {
+		otelinst.Spec.Propagators = section.Propagators
+	}
+	return instrumentationWithContainers{Instrumentation: otelinst, Containers: inst.Containers, Exporter: inst.Exporter}
+}
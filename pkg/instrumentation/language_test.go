@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestLanguageInjectors(t *testing.T) {
+	languages := map[string]bool{}
+	containersAnnotations := map[string]bool{}
+	for _, lang := range LanguageInjectors() {
+		assert.False(t, languages[lang.Language()], "duplicated language %s", lang.Language())
+		assert.False(t, containersAnnotations[lang.ContainersAnnotation()], "duplicated containers annotation %s", lang.ContainersAnnotation())
+		languages[lang.Language()] = true
+		containersAnnotations[lang.ContainersAnnotation()] = true
+
+		assert.Equal(t, "instrumentation.opentelemetry.io/inject-"+lang.Language(), lang.Annotation())
+		assert.NotEmpty(t, lang.Name())
+	}
+}
+
+func TestWithLanguageOverrides(t *testing.T) {
+	inst := &v1alpha1.Instrumentation{
+		Spec: v1alpha1.InstrumentationSpec{
+			Exporter:    v1alpha1.Exporter{Endpoint: "http://collector:4318"},
+			Sampler:     v1alpha1.Sampler{Type: v1alpha1.ParentBasedTraceIDRatio, Argument: "0.25"},
+			Propagators: []v1alpha1.Propagator{v1alpha1.TraceContext, v1alpha1.Baggage},
+			Java: v1alpha1.Java{
+				Exporter:    &v1alpha1.Exporter{Endpoint: "http://gateway:4317"},
+				Propagators: []v1alpha1.Propagator{v1alpha1.B3},
+			},
+			Nginx: v1alpha1.Nginx{
+				Sampler: &v1alpha1.Sampler{Type: v1alpha1.AlwaysOff},
+			},
+		},
+	}
+
+	java := withLanguageOverrides(javaInjector{}, instrumentationWithContainers{Instrumentation: inst, Containers: "app"})
+	assert.Equal(t, "app", java.Containers)
+	assert.Equal(t, v1alpha1.Exporter{Endpoint: "http://gateway:4317"}, java.Instrumentation.Spec.Exporter)
+	assert.Equal(t, inst.Spec.Sampler, java.Instrumentation.Spec.Sampler)
+	assert.Equal(t, []v1alpha1.Propagator{v1alpha1.B3}, java.Instrumentation.Spec.Propagators)
+
+	nginx := withLanguageOverrides(nginxInjector{}, instrumentationWithContainers{Instrumentation: inst})
+	assert.Equal(t, inst.Spec.Exporter, nginx.Instrumentation.Spec.Exporter)
+	assert.Equal(t, v1alpha1.Sampler{Type: v1alpha1.AlwaysOff}, nginx.Instrumentation.Spec.Sampler)
+	assert.Equal(t, inst.Spec.Propagators, nginx.Instrumentation.Spec.Propagators)
+
+	// the languages without overrides, and the Instrumentation itself, are left untouched
+	nodejs := withLanguageOverrides(nodeJSInjector{}, instrumentationWithContainers{Instrumentation: inst})
+	assert.Same(t, inst, nodejs.Instrumentation)
+	sdk := withLanguageOverrides(sdkOnlyInjector{}, instrumentationWithContainers{Instrumentation: inst})
+	assert.Same(t, inst, sdk.Instrumentation)
+	assert.Equal(t, "http://collector:4318", inst.Spec.Exporter.Endpoint)
+}
@@ -15,6 +15,7 @@
 package instrumentation
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"sort"
@@ -25,6 +26,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 const (
@@ -62,7 +64,7 @@ const (
 	6) Inject mounting of volumes / files into appropriate directories in the application container
 */
 
-func injectNginxSDK(_ logr.Logger, nginxSpec v1alpha1.Nginx, pod corev1.Pod, index int, otlpEndpoint string, resourceMap map[string]string) corev1.Pod {
+func injectNginxSDK(_ logr.Logger, nginxSpec v1alpha1.Nginx, pod corev1.Pod, index int, exporter v1alpha1.Exporter, sampler v1alpha1.Sampler, resourceMap map[string]string) corev1.Pod {
 
 	// caller checks if there is at least one container
 	container := &pod.Spec.Containers[index]
@@ -217,7 +219,7 @@ mv ${NGINX_AGENT_CONF_DIR_FULL}/opentelemetry_agent.conf  ${NGINX_AGENT_CONF_DIR
 			Env: []corev1.EnvVar{
 				{
 					Name:  nginxAttributesEnvVar,
-					Value: getNginxOtelConfig(pod, nginxSpec, index, otlpEndpoint, resourceMap),
+					Value: getNginxOtelConfig(pod, nginxSpec, index, exporter, sampler, resourceMap),
 				},
 				{
 					Name:  "OTEL_NGINX_I13N_SCRIPT",
@@ -246,6 +248,12 @@ mv ${NGINX_AGENT_CONF_DIR_FULL}/opentelemetry_agent.conf  ${NGINX_AGENT_CONF_DIR
 			SecurityContext: pod.Spec.Containers[index].SecurityContext,
 		})
 
+		// the node-local endpoint of the configuration refers to the node's IP, which has to be defined before
+		if exporter.NodeLocal != nil {
+			initContainer := &pod.Spec.InitContainers[len(pod.Spec.InitContainers)-1]
+			initContainer.Env = append([]corev1.EnvVar{nodeIPEnvVar()}, initContainer.Env...)
+		}
+
 		found := false
 		for i, e := range container.Env {
 			if e.Name == nginxLibraryPathEnv {
@@ -277,8 +285,9 @@ func isNginxInitContainerMissing(pod corev1.Pod, containerName string) bool {
 
 // Calculate Nginx agent configuration file based on attributes provided by the injection rules
 // and by the pod values.
-func getNginxOtelConfig(pod corev1.Pod, nginxSpec v1alpha1.Nginx, index int, otelEndpoint string, resourceMap map[string]string) string {
+func getNginxOtelConfig(pod corev1.Pod, nginxSpec v1alpha1.Nginx, index int, exporter v1alpha1.Exporter, sampler v1alpha1.Sampler, resourceMap map[string]string) string {
 
+	otelEndpoint := exporterEndpoint(exporter)
 	if otelEndpoint == "" {
 		otelEndpoint = "http://localhost:4317/"
 	}
@@ -303,6 +312,15 @@ func getNginxOtelConfig(pod corev1.Pod, nginxSpec v1alpha1.Nginx, index int, ote
 		"NginxModuleResolveBackends":      "ON",
 		"NginxModuleTraceAsError":         "ON",
 	}
+	if exporter.TLS != nil {
+		if ca, _, _ := exporterTLSFiles(*exporter.TLS); ca != "" {
+			attrMap["NginxModuleOtelSslEnabled"] = "ON"
+			attrMap["NginxModuleOtelSslCertificatePath"] = ca
+		}
+	}
+	if moduleSampler := webServerModuleSampler(sampler); moduleSampler != "" {
+		attrMap["NginxModuleOtelSampler"] = moduleSampler
+	}
 	for _, attr := range nginxSpec.Attrs {
 		attrMap[attr.Name] = attr.Value
 	}
@@ -352,3 +370,46 @@ func prepareCommandFromTemplate(template string, params ...any) string {
 
 	return command
 }
+
+// nginxInjector injects the Nginx auto-instrumentation.
+type nginxInjector struct{}
+
+func (nginxInjector) Language() string { return "nginx" }
+
+func (nginxInjector) Name() string { return "Nginx" }
+
+func (nginxInjector) Annotation() string { return annotationInjectNginx }
+
+func (nginxInjector) ContainersAnnotation() string { return annotationInjectNginxContainersName }
+
+func (nginxInjector) Protocol() v1alpha1.ExporterProtocol { return v1alpha1.ExporterProtocolGRPC }
+
+func (nginxInjector) Enabled() bool {
+	return featuregate.EnableNginxAutoInstrumentationSupport.IsEnabled()
+}
+
+func (nginxInjector) Section(spec v1alpha1.InstrumentationSpec) (LanguageSection, bool) {
+	return LanguageSection{
+		Image:       spec.Nginx.Image,
+		Env:         spec.Nginx.Env,
+		Resources:   spec.Nginx.Resources,
+		Exporter:    spec.Nginx.Exporter,
+		Sampler:     spec.Nginx.Sampler,
+		Propagators: spec.Nginx.Propagators,
+	}, true
+}
+
+func (nginxInjector) inject(ctx context.Context, i *sdkInjector, inst instrumentationWithContainers, ns corev1.Namespace, pod corev1.Pod) corev1.Pod {
+	otelinst := *inst.Instrumentation
+	i.logger.V(1).Info("injecting Nginx instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+
+	for _, container := range strings.Split(inst.Containers, ",") {
+		index := getContainerIndex(container, pod)
+		// Nginx agent is configured via config files rather than env vars.
+		// Therefore, service name, otlp endpoint and other attributes are passed to the agent injection method
+		pod = injectNginxSDK(i.logger, otelinst.Spec.Nginx, pod, index, otelinst.Spec.Exporter, otelinst.Spec.Sampler, i.createResourceMap(ctx, otelinst, ns, pod, index))
+		pod = i.injectCommonEnvVar(otelinst, pod, index)
+		pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
+	}
+	return pod
+}
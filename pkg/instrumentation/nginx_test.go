@@ -477,12 +477,60 @@ func TestInjectNginxSDK(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			pod := injectNginxSDK(logr.Discard(), test.Nginx, test.pod, 0, "http://otlp-endpoint:4317", resourceMap)
+			pod := injectNginxSDK(logr.Discard(), test.Nginx, test.pod, 0, v1alpha1.Exporter{Endpoint: "http://otlp-endpoint:4317"}, v1alpha1.Sampler{}, resourceMap)
 			assert.Equal(t, test.expected, pod)
 		})
 	}
 }
 
+func TestNginxOtelConfigSampler(t *testing.T) {
+	pod := corev1.Pod{ObjectMeta: v1.ObjectMeta{Namespace: "req-namespace"}}
+	resourceMap := map[string]string{string(semconv.K8SDeploymentNameKey): "nginx-service-name"}
+
+	config := getNginxOtelConfig(pod, v1alpha1.Nginx{}, 0, v1alpha1.Exporter{Endpoint: "http://otlp-endpoint:4317"}, v1alpha1.Sampler{Type: v1alpha1.ParentBasedAlwaysOff}, resourceMap)
+	assert.Contains(t, config, "NginxModuleOtelSampler AlwaysOff;\n")
+
+	// the module doesn't support ratio based sampling
+	config = getNginxOtelConfig(pod, v1alpha1.Nginx{}, 0, v1alpha1.Exporter{Endpoint: "http://otlp-endpoint:4317"}, v1alpha1.Sampler{Type: v1alpha1.TraceIDRatio, Argument: "0.5"}, resourceMap)
+	assert.NotContains(t, config, "NginxModuleOtelSampler")
+
+	// the attributes take precedence
+	nginxSpec := v1alpha1.Nginx{Attrs: []corev1.EnvVar{{Name: "NginxModuleOtelSampler", Value: "AlwaysOn"}}}
+	config = getNginxOtelConfig(pod, nginxSpec, 0, v1alpha1.Exporter{Endpoint: "http://otlp-endpoint:4317"}, v1alpha1.Sampler{Type: v1alpha1.AlwaysOff}, resourceMap)
+	assert.Contains(t, config, "NginxModuleOtelSampler AlwaysOn;\n")
+}
+
+func TestNginxOtelConfigTLS(t *testing.T) {
+	pod := corev1.Pod{ObjectMeta: v1.ObjectMeta{Namespace: "req-namespace"}}
+	resourceMap := map[string]string{string(semconv.K8SDeploymentNameKey): "nginx-service-name"}
+	exporter := v1alpha1.Exporter{
+		Endpoint: "https://otlp-endpoint:4317",
+		TLS:      &v1alpha1.TLS{ConfigMapName: "otlp-ca", CA: "ca.crt"},
+	}
+
+	config := getNginxOtelConfig(pod, v1alpha1.Nginx{}, 0, exporter, v1alpha1.Sampler{}, resourceMap)
+	assert.Contains(t, config, "NginxModuleOtelExporterEndpoint https://otlp-endpoint:4317;\n")
+	assert.Contains(t, config, "NginxModuleOtelSslCertificatePath /otel-auto-instrumentation-configmap-otlp-ca/ca.crt;\n")
+	assert.Contains(t, config, "NginxModuleOtelSslEnabled ON;\n")
+}
+
+func TestInjectNginxNodeLocal(t *testing.T) {
+	pod := corev1.Pod{
+		ObjectMeta: v1.ObjectMeta{Namespace: "req-namespace"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx"}}},
+	}
+	resourceMap := map[string]string{string(semconv.K8SDeploymentNameKey): "nginx-service-name"}
+	exporter := v1alpha1.Exporter{NodeLocal: &v1alpha1.NodeLocal{}}
+
+	pod = injectNginxSDK(logr.Discard(), v1alpha1.Nginx{}, pod, 0, exporter, v1alpha1.Sampler{}, resourceMap)
+
+	// the node's IP is defined before the configuration referring to it
+	env := pod.Spec.InitContainers[1].Env
+	assert.Equal(t, nodeIPEnvVar(), env[0])
+	assert.Equal(t, nginxAttributesEnvVar, env[1].Name)
+	assert.Contains(t, env[1].Value, "NginxModuleOtelExporterEndpoint http://$(OTEL_NODE_IP):4317;\n")
+}
+
 func TestInjectNginxUnknownNamespace(t *testing.T) {
 
 	tests := []struct {
@@ -600,7 +648,7 @@ func TestInjectNginxUnknownNamespace(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			pod := injectNginxSDK(logr.Discard(), test.Nginx, test.pod, 0, "http://otlp-endpoint:4317", resourceMap)
+			pod := injectNginxSDK(logr.Discard(), test.Nginx, test.pod, 0, v1alpha1.Exporter{Endpoint: "http://otlp-endpoint:4317"}, v1alpha1.Sampler{}, resourceMap)
 			assert.Equal(t, test.expected, pod)
 		})
 	}
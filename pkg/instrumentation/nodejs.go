@@ -15,9 +15,13 @@
 package instrumentation
 
 import (
+	"context"
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 const (
@@ -83,3 +87,50 @@ func injectNodeJSSDK(nodeJSSpec v1alpha1.NodeJS, pod corev1.Pod, index int) (cor
 	}
 	return pod, nil
 }
+
+// nodeJSInjector injects the NodeJS auto-instrumentation.
+type nodeJSInjector struct{}
+
+func (nodeJSInjector) Language() string { return "nodejs" }
+
+func (nodeJSInjector) Name() string { return "NodeJS" }
+
+func (nodeJSInjector) Annotation() string { return annotationInjectNodeJS }
+
+func (nodeJSInjector) ContainersAnnotation() string { return annotationInjectNodeJSContainersName }
+
+func (nodeJSInjector) Protocol() v1alpha1.ExporterProtocol { return v1alpha1.ExporterProtocolGRPC }
+
+func (nodeJSInjector) Enabled() bool {
+	return featuregate.EnableNodeJSAutoInstrumentationSupport.IsEnabled()
+}
+
+func (nodeJSInjector) Section(spec v1alpha1.InstrumentationSpec) (LanguageSection, bool) {
+	return LanguageSection{
+		Image:       spec.NodeJS.Image,
+		Env:         spec.NodeJS.Env,
+		Resources:   spec.NodeJS.Resources,
+		Exporter:    spec.NodeJS.Exporter,
+		Sampler:     spec.NodeJS.Sampler,
+		Propagators: spec.NodeJS.Propagators,
+	}, true
+}
+
+func (nodeJSInjector) inject(ctx context.Context, i *sdkInjector, inst instrumentationWithContainers, ns corev1.Namespace, pod corev1.Pod) corev1.Pod {
+	otelinst := *inst.Instrumentation
+	var err error
+	i.logger.V(1).Info("injecting NodeJS instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+
+	for _, container := range strings.Split(inst.Containers, ",") {
+		index := getContainerIndex(container, pod)
+		pod, err = injectNodeJSSDK(otelinst.Spec.NodeJS, pod, index)
+		if err != nil {
+			i.logger.Info("Skipping NodeJS SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
+		} else {
+			pod = i.injectCommonEnvVar(otelinst, pod, index)
+			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
+			pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, nodejsInitContainerName)
+		}
+	}
+	return pod
+}
@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+const (
+	envPHPIniScanDir     = "PHP_INI_SCAN_DIR"
+	envOtelPHPAutoload   = "OTEL_PHP_AUTOLOAD_ENABLED"
+	phpIniScanDirSuffix  = ":/otel-auto-instrumentation-php/php.d"
+	phpInitContainerName = initContainerName + "-php"
+	phpVolumeName        = volumeName + "-php"
+	phpInstrMountPath    = "/otel-auto-instrumentation-php"
+	phpAutoloadEnabled   = "true"
+)
+
+func injectPHPSDK(phpSpec v1alpha1.PHP, pod corev1.Pod, index int) (corev1.Pod, error) {
+	// caller checks if there is at least one container.
+	container := &pod.Spec.Containers[index]
+
+	err := validateContainerEnv(container.Env, envPHPIniScanDir)
+	if err != nil {
+		return pod, err
+	}
+
+	// inject PHP instrumentation spec env vars.
+	for _, env := range phpSpec.Env {
+		idx := getIndexOfEnv(container.Env, env.Name)
+		if idx == -1 {
+			container.Env = append(container.Env, env)
+		}
+	}
+
+	// The leading separator keeps PHP scanning its default configuration directory.
+	idx := getIndexOfEnv(container.Env, envPHPIniScanDir)
+	if idx == -1 {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  envPHPIniScanDir,
+			Value: phpIniScanDirSuffix,
+		})
+	} else if idx > -1 {
+		container.Env[idx].Value = container.Env[idx].Value + phpIniScanDirSuffix
+	}
+
+	// Set OTEL_PHP_AUTOLOAD_ENABLED if not set by user because the extension does nothing without it.
+	idx = getIndexOfEnv(container.Env, envOtelPHPAutoload)
+	if idx == -1 {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  envOtelPHPAutoload,
+			Value: phpAutoloadEnabled,
+		})
+	}
+
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      phpVolumeName,
+		MountPath: phpInstrMountPath,
+	})
+
+	// We just inject Volumes and init containers for the first processed container.
+	if isInitContainerMissing(pod, phpInitContainerName) {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: phpVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					SizeLimit: volumeSize(phpSpec.VolumeSizeLimit),
+				},
+			}})
+
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, corev1.Container{
+			Name:      phpInitContainerName,
+			Image:     phpSpec.Image,
+			Command:   []string{"cp", "-a", "/autoinstrumentation/.", phpInstrMountPath},
+			Resources: phpSpec.Resources,
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      phpVolumeName,
+				MountPath: phpInstrMountPath,
+			}},
+		})
+	}
+	return pod, nil
+}
+
+// phpInjector injects the PHP auto-instrumentation.
+type phpInjector struct{}
+
+func (phpInjector) Language() string { return "php" }
+
+func (phpInjector) Name() string { return "PHP" }
+
+func (phpInjector) Annotation() string { return annotationInjectPHP }
+
+func (phpInjector) ContainersAnnotation() string { return annotationInjectPHPContainersName }
+
+func (phpInjector) Protocol() v1alpha1.ExporterProtocol { return v1alpha1.ExporterProtocolHTTPProtobuf }
+
+func (phpInjector) Enabled() bool {
+	return featuregate.EnablePHPAutoInstrumentationSupport.IsEnabled()
+}
+
+func (phpInjector) Section(spec v1alpha1.InstrumentationSpec) (LanguageSection, bool) {
+	return LanguageSection{
+		Image:       spec.PHP.Image,
+		Env:         spec.PHP.Env,
+		Resources:   spec.PHP.Resources,
+		Exporter:    spec.PHP.Exporter,
+		Sampler:     spec.PHP.Sampler,
+		Propagators: spec.PHP.Propagators,
+	}, true
+}
+
+func (phpInjector) inject(ctx context.Context, i *sdkInjector, inst instrumentationWithContainers, ns corev1.Namespace, pod corev1.Pod) corev1.Pod {
+	otelinst := *inst.Instrumentation
+	var err error
+	i.logger.V(1).Info("injecting PHP instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+
+	for _, container := range strings.Split(inst.Containers, ",") {
+		index := getContainerIndex(container, pod)
+		pod, err = injectPHPSDK(otelinst.Spec.PHP, pod, index)
+		if err != nil {
+			i.logger.Info("Skipping PHP SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
+		} else {
+			pod = i.injectCommonEnvVar(otelinst, pod, index)
+			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
+			pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, phpInitContainerName)
+		}
+	}
+	return pod
+}
@@ -22,11 +22,14 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/strings/slices"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/webhook/podmutation"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
@@ -36,60 +39,36 @@ var (
 	errNoInstancesAvailable      = errors.New("no OpenTelemetry Instrumentation instances available")
 )
 
+const (
+	// failureReason is the reason of the events reporting the instrumentations that couldn't be injected.
+	failureReason = "InstrumentationInjectionFailed"
+	// rejectedReason is the reason of the events reporting the instrumentations that aren't enabled.
+	rejectedReason = "InstrumentationRequestRejected"
+)
+
 type instPodMutator struct {
+	config      config.Config
 	Client      client.Client
 	sdkInjector *sdkInjector
 	Logger      logr.Logger
 	Recorder    record.EventRecorder
+	reporter    *podmutation.FailureReporter
 }
 
 type instrumentationWithContainers struct {
-	Instrumentation       *v1alpha1.Instrumentation
-	Containers            string
-	AdditionalAnnotations map[string]string
+	Instrumentation *v1alpha1.Instrumentation
+	Containers      string
+	// Exporter is the exporter of the language resolved from the collector it references, see resolveExporters.
+	// It replaces the exporters of the Instrumentation and of the language's section when it's set.
+	Exporter *v1alpha1.Exporter
 }
 
-type languageInstrumentations struct {
-	Java        instrumentationWithContainers
-	NodeJS      instrumentationWithContainers
-	Python      instrumentationWithContainers
-	DotNet      instrumentationWithContainers
-	ApacheHttpd instrumentationWithContainers
-	Nginx       instrumentationWithContainers
-	Go          instrumentationWithContainers
-	Sdk         instrumentationWithContainers
-}
+// languageInstrumentations are the instrumentations to inject, by language, see LanguageInjector.Language.
+type languageInstrumentations map[string]instrumentationWithContainers
 
 // Check if single instrumentation is configured for Pod and return which is configured.
 func (langInsts languageInstrumentations) isSingleInstrumentationEnabled() bool {
-	count := 0
-
-	if langInsts.Java.Instrumentation != nil {
-		count++
-	}
-	if langInsts.NodeJS.Instrumentation != nil {
-		count++
-	}
-	if langInsts.Python.Instrumentation != nil {
-		count++
-	}
-	if langInsts.DotNet.Instrumentation != nil {
-		count++
-	}
-	if langInsts.ApacheHttpd.Instrumentation != nil {
-		count++
-	}
-	if langInsts.Nginx.Instrumentation != nil {
-		count++
-	}
-	if langInsts.Go.Instrumentation != nil {
-		count++
-	}
-	if langInsts.Sdk.Instrumentation != nil {
-		count++
-	}
-
-	return count == 1
+	return len(langInsts.instances()) == 1
 }
 
 // Check if specific containers are provided for configured instrumentation.
@@ -99,45 +78,13 @@ func (langInsts languageInstrumentations) areContainerNamesConfiguredForMultiple
 	var allContainers []string
 
 	// Check for instrumentations with and without containers.
-	if langInsts.Java.Instrumentation != nil {
-		instrWithContainers += isInstrWithContainers(langInsts.Java)
-		instrWithoutContainers += isInstrWithoutContainers(langInsts.Java)
-		allContainers = append(allContainers, langInsts.Java.Containers)
-	}
-	if langInsts.NodeJS.Instrumentation != nil {
-		instrWithContainers += isInstrWithContainers(langInsts.NodeJS)
-		instrWithoutContainers += isInstrWithoutContainers(langInsts.NodeJS)
-		allContainers = append(allContainers, langInsts.NodeJS.Containers)
-	}
-	if langInsts.Python.Instrumentation != nil {
-		instrWithContainers += isInstrWithContainers(langInsts.Python)
-		instrWithoutContainers += isInstrWithoutContainers(langInsts.Python)
-		allContainers = append(allContainers, langInsts.Python.Containers)
-	}
-	if langInsts.DotNet.Instrumentation != nil {
-		instrWithContainers += isInstrWithContainers(langInsts.DotNet)
-		instrWithoutContainers += isInstrWithoutContainers(langInsts.DotNet)
-		allContainers = append(allContainers, langInsts.DotNet.Containers)
-	}
-	if langInsts.ApacheHttpd.Instrumentation != nil {
-		instrWithContainers += isInstrWithContainers(langInsts.ApacheHttpd)
-		instrWithoutContainers += isInstrWithoutContainers(langInsts.ApacheHttpd)
-		allContainers = append(allContainers, langInsts.ApacheHttpd.Containers)
-	}
-	if langInsts.Nginx.Instrumentation != nil {
-		instrWithContainers += isInstrWithContainers(langInsts.Nginx)
-		instrWithoutContainers += isInstrWithoutContainers(langInsts.Nginx)
-		allContainers = append(allContainers, langInsts.Nginx.Containers)
-	}
-	if langInsts.Go.Instrumentation != nil {
-		instrWithContainers += isInstrWithContainers(langInsts.Go)
-		instrWithoutContainers += isInstrWithoutContainers(langInsts.Go)
-		allContainers = append(allContainers, langInsts.Go.Containers)
-	}
-	if langInsts.Sdk.Instrumentation != nil {
-		instrWithContainers += isInstrWithContainers(langInsts.Sdk)
-		instrWithoutContainers += isInstrWithoutContainers(langInsts.Sdk)
-		allContainers = append(allContainers, langInsts.Sdk.Containers)
+	for _, lang := range languageInjectors {
+		inst := langInsts[lang.Language()]
+		if inst.Instrumentation != nil {
+			instrWithContainers += isInstrWithContainers(inst)
+			instrWithoutContainers += isInstrWithoutContainers(inst)
+			allContainers = append(allContainers, inst.Containers)
+		}
 	}
 
 	// Look for duplicated containers.
@@ -164,37 +111,31 @@ func (langInsts languageInstrumentations) areContainerNamesConfiguredForMultiple
 }
 
 // Set containers for configured instrumentation.
-func (langInsts *languageInstrumentations) setInstrumentationLanguageContainers(containers string) {
-	if langInsts.Java.Instrumentation != nil {
-		langInsts.Java.Containers = containers
-	}
-	if langInsts.NodeJS.Instrumentation != nil {
-		langInsts.NodeJS.Containers = containers
-	}
-	if langInsts.Python.Instrumentation != nil {
-		langInsts.Python.Containers = containers
-	}
-	if langInsts.DotNet.Instrumentation != nil {
-		langInsts.DotNet.Containers = containers
-	}
-	if langInsts.ApacheHttpd.Instrumentation != nil {
-		langInsts.ApacheHttpd.Containers = containers
-	}
-	if langInsts.Nginx.Instrumentation != nil {
-		langInsts.Nginx.Containers = containers
-	}
-	if langInsts.Go.Instrumentation != nil {
-		langInsts.Go.Containers = containers
+func (langInsts languageInstrumentations) setInstrumentationLanguageContainers(containers string) {
+	for language, inst := range langInsts {
+		if inst.Instrumentation != nil {
+			inst.Containers = containers
+			langInsts[language] = inst
+		}
 	}
-	if langInsts.Sdk.Instrumentation != nil {
-		langInsts.Sdk.Containers = containers
+}
+
+// instances returns the instrumentations to inject, in the order of the languages.
+func (langInsts languageInstrumentations) instances() []*v1alpha1.Instrumentation {
+	var instances []*v1alpha1.Instrumentation
+	for _, lang := range languageInjectors {
+		if inst := langInsts[lang.Language()]; inst.Instrumentation != nil {
+			instances = append(instances, inst.Instrumentation)
+		}
 	}
+	return instances
 }
 
 var _ podmutation.PodMutator = (*instPodMutator)(nil)
 
-func NewMutator(logger logr.Logger, client client.Client, recorder record.EventRecorder) *instPodMutator {
+func NewMutator(logger logr.Logger, config config.Config, client client.Client, recorder record.EventRecorder) *instPodMutator {
 	return &instPodMutator{
+		config: config,
 		Logger: logger,
 		Client: client,
 		sdkInjector: &sdkInjector{
@@ -202,6 +143,7 @@ func NewMutator(logger logr.Logger, client client.Client, recorder record.EventR
 			client: client,
 		},
 		Recorder: recorder,
+		reporter: podmutation.NewFailureReporter(client, recorder),
 	}
 }
 
@@ -214,110 +156,72 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 		return pod, nil
 	}
 
-	var inst *v1alpha1.Instrumentation
-	var err error
-
 	insts := languageInstrumentations{}
 
-	// We bail out if any annotation fails to process.
-
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectJava); err != nil {
-		// we still allow the pod to be created, but we log a message to the operator's logs
-		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
-		return pod, err
-	}
-	if featuregate.EnableJavaAutoInstrumentationSupport.IsEnabled() || inst == nil {
-		insts.Java.Instrumentation = inst
-	} else {
-		logger.Error(nil, "support for Java auto instrumentation is not enabled")
-		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for Java auto instrumentation is not enabled")
-	}
-
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectNodeJS); err != nil {
-		// we still allow the pod to be created, but we log a message to the operator's logs
-		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
-		return pod, err
-	}
-	if featuregate.EnableNodeJSAutoInstrumentationSupport.IsEnabled() || inst == nil {
-		insts.NodeJS.Instrumentation = inst
-	} else {
-		logger.Error(nil, "support for NodeJS auto instrumentation is not enabled")
-		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for NodeJS auto instrumentation is not enabled")
-	}
-
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectPython); err != nil {
-		// we still allow the pod to be created, but we log a message to the operator's logs
-		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
-		return pod, err
-	}
-	if featuregate.EnablePythonAutoInstrumentationSupport.IsEnabled() || inst == nil {
-		insts.Python.Instrumentation = inst
-	} else {
-		logger.Error(nil, "support for Python auto instrumentation is not enabled")
-		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for Python auto instrumentation is not enabled")
-	}
-
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectDotNet); err != nil {
-		// we still allow the pod to be created, but we log a message to the operator's logs
-		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
-		return pod, err
-	}
-	if featuregate.EnableDotnetAutoInstrumentationSupport.IsEnabled() || inst == nil {
-		insts.DotNet.Instrumentation = inst
-		insts.DotNet.AdditionalAnnotations = map[string]string{annotationDotNetRuntime: annotationValue(ns.ObjectMeta, pod.ObjectMeta, annotationDotNetRuntime)}
-	} else {
-		logger.Error(nil, "support for .NET auto instrumentation is not enabled")
-		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for .NET auto instrumentation is not enabled")
-	}
-
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectGo); err != nil {
-		// we still allow the pod to be created, but we log a message to the operator's logs
-		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
-		return pod, err
-	}
-	if featuregate.EnableGoAutoInstrumentationSupport.IsEnabled() || inst == nil {
-		insts.Go.Instrumentation = inst
-	} else {
-		logger.Error(err, "support for Go auto instrumentation is not enabled")
-		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for Go auto instrumentation is not enabled")
-	}
-
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectApacheHttpd); err != nil {
-		// we still allow the pod to be created, but we log a message to the operator's logs
-		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
-		return pod, err
-	}
-	if featuregate.EnableApacheHTTPAutoInstrumentationSupport.IsEnabled() || inst == nil {
-		insts.ApacheHttpd.Instrumentation = inst
-	} else {
-		logger.Error(nil, "support for Apache HTTPD auto instrumentation is not enabled")
-		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for Apache HTTPD auto instrumentation is not enabled")
+	// The Instrumentations selecting pods are used for the languages without annotation, unless the language
+	// detection is requested. Only the ones of the pod's namespace and of the namespaces allowed to select the pods
+	// of other namespaces may select it, and only when it's created: the containers of existing pods can't change.
+	var selectors []v1alpha1.Instrumentation
+	autoAnnotated := annotationValue(ns.ObjectMeta, pod.ObjectMeta, annotationInjectAuto) != ""
+	if !autoAnnotated && pod.CreationTimestamp.IsZero() {
+		for _, namespace := range podmutation.SelectorNamespaces(pm.config, ns.Name) {
+			var otelInsts v1alpha1.InstrumentationList
+			if err := pm.Client.List(ctx, &otelInsts, client.InNamespace(namespace)); err != nil {
+				logger.Error(err, "failed to list the OpenTelemetry Instrumentation instances selecting pods")
+				if !annotated(ns, pod) {
+					// the pod didn't request an injection
+					return pod, nil
+				}
+				return pod, pm.failed(ctx, ns, pod, failureReason, err)
+			}
+			selectors = append(selectors, otelInsts.Items...)
+		}
 	}
 
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectNginx); err != nil {
-		// we still allow the pod to be created, but we log a message to the operator's logs
-		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
-		return pod, err
-	}
-	if featuregate.EnableNginxAutoInstrumentationSupport.IsEnabled() || inst == nil {
-		insts.Nginx.Instrumentation = inst
-	} else {
-		logger.Error(nil, "support for Nginx auto instrumentation is not enabled")
-		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for Nginx auto instrumentation is not enabled")
+	// We bail out if any annotation fails to process.
+	for _, lang := range languageInjectors {
+		var inst *v1alpha1.Instrumentation
+		var err error
+		if annotationValue(ns.ObjectMeta, pod.ObjectMeta, lang.Annotation()) == "" {
+			inst, err = selectInstrumentationInstance(lang, selectors, ns, pod)
+			if inst != nil {
+				logger.V(1).Info("pod selected by the instrumentation", "language", lang.Language(), "otelinst-namespace", inst.Namespace, "otelinst-name", inst.Name)
+			}
+		} else {
+			inst, err = pm.getInstrumentationInstance(ctx, ns, pod, lang.Annotation())
+		}
+		if err != nil {
+			// unless the failure policy says otherwise, we still allow the pod to be created, but we log a message to the operator's logs
+			logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
+			return pod, pm.failed(ctx, ns, pod, failureReason, err)
+		}
+		if inst == nil {
+			continue
+		}
+		if !lang.Enabled() {
+			err = fmt.Errorf("support for %s auto instrumentation is not enabled", lang.Name())
+			logger.Error(err, "skipping instrumentation injection")
+			if rejection := pm.reportFailure(ctx, ns, pod, rejectedReason, err, inst); rejection != nil {
+				return pod, rejection
+			}
+			continue
+		}
+		insts[lang.Language()] = instrumentationWithContainers{Instrumentation: inst}
 	}
 
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectSdk); err != nil {
-		// we still allow the pod to be created, but we log a message to the operator's logs
-		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
-		return pod, err
+	// The language specific annotations take precedence over the language detection.
+	if len(insts) == 0 {
+		autoInst, err := pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectAuto)
+		if err != nil {
+			logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
+			return pod, pm.failed(ctx, ns, pod, failureReason, err)
+		}
+		if autoInst != nil {
+			return pm.mutateDetected(ctx, logger, ns, pod, autoInst)
+		}
 	}
-	insts.Sdk.Instrumentation = inst
-
-	if insts.Java.Instrumentation == nil && insts.NodeJS.Instrumentation == nil && insts.Python.Instrumentation == nil &&
-		insts.DotNet.Instrumentation == nil && insts.Go.Instrumentation == nil && insts.ApacheHttpd.Instrumentation == nil &&
-		insts.Nginx.Instrumentation == nil &&
-		insts.Sdk.Instrumentation == nil {
 
+	if len(insts) == 0 {
 		logger.V(1).Info("annotation not present in deployment, skipping instrumentation injection")
 		return pod, nil
 	}
@@ -325,20 +229,18 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 	// We retrieve the annotation for podname
 	if featuregate.EnableMultiInstrumentationSupport.IsEnabled() {
 		// We use annotations specific for instrumentation language
-		insts.Java.Containers = annotationValue(ns.ObjectMeta, pod.ObjectMeta, annotationInjectJavaContainersName)
-		insts.NodeJS.Containers = annotationValue(ns.ObjectMeta, pod.ObjectMeta, annotationInjectNodeJSContainersName)
-		insts.Python.Containers = annotationValue(ns.ObjectMeta, pod.ObjectMeta, annotationInjectPythonContainersName)
-		insts.DotNet.Containers = annotationValue(ns.ObjectMeta, pod.ObjectMeta, annotationInjectDotnetContainersName)
-		insts.Go.Containers = annotationValue(ns.ObjectMeta, pod.ObjectMeta, annotationInjectGoContainersName)
-		insts.ApacheHttpd.Containers = annotationValue(ns.ObjectMeta, pod.ObjectMeta, annotationInjectApacheHttpdContainersName)
-		insts.Nginx.Containers = annotationValue(ns.ObjectMeta, pod.ObjectMeta, annotationInjectNginxContainersName)
-		insts.Sdk.Containers = annotationValue(ns.ObjectMeta, pod.ObjectMeta, annotationInjectSdkContainersName)
+		for _, lang := range languageInjectors {
+			if inst, ok := insts[lang.Language()]; ok {
+				inst.Containers = annotationValue(ns.ObjectMeta, pod.ObjectMeta, lang.ContainersAnnotation())
+				insts[lang.Language()] = inst
+			}
+		}
 
 		// We check if provided annotations and instrumentations are valid
 		ok, msg := insts.areContainerNamesConfiguredForMultipleInstrumentations()
 		if !ok {
 			logger.V(1).Error(msg, "skipping instrumentation injection")
-			return pod, nil
+			return pod, pm.reportFailure(ctx, ns, pod, failureReason, msg, insts.instances()...)
 		}
 	} else {
 		// We use general annotation for container names
@@ -348,20 +250,116 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 			generalContainerNames := annotationValue(ns.ObjectMeta, pod.ObjectMeta, annotationInjectContainerName)
 			insts.setInstrumentationLanguageContainers(generalContainerNames)
 		} else {
-			logger.V(1).Error(fmt.Errorf("multiple injection annotations present"), "skipping instrumentation injection")
-			return pod, nil
+			err := fmt.Errorf("multiple injection annotations present")
+			logger.V(1).Error(err, "skipping instrumentation injection")
+			return pod, pm.reportFailure(ctx, ns, pod, failureReason, err, insts.instances()...)
 		}
 
 	}
 
+	resolved, err := pm.resolveExporters(ctx, insts)
+	if err != nil {
+		logger.Error(err, "skipping instrumentation injection")
+		return pod, pm.reportFailure(ctx, ns, pod, failureReason, err, insts.instances()...)
+	}
+
 	// once it's been determined that instrumentation is desired, none exists yet, and we know which instance it should talk to,
 	// we should inject the instrumentation.
 	modifiedPod := pod
-	modifiedPod = pm.sdkInjector.inject(ctx, insts, ns, modifiedPod)
+	modifiedPod = pm.sdkInjector.inject(ctx, resolved, ns, modifiedPod)
 
 	return modifiedPod, nil
 }
 
+// mutateDetected injects the given Instrumentation into the containers of the pod, with the injectors of the languages
+// detected for them. Only the containers named by the container-names annotation are instrumented when it's set.
+func (pm *instPodMutator) mutateDetected(ctx context.Context, logger logr.Logger, ns corev1.Namespace, pod corev1.Pod, inst *v1alpha1.Instrumentation) (corev1.Pod, error) {
+	var containerNames []string
+	if names := annotationValue(ns.ObjectMeta, pod.ObjectMeta, annotationInjectContainerName); names != "" {
+		containerNames = strings.Split(names, ",")
+	}
+
+	containers := map[string][]string{}
+	for _, container := range pod.Spec.Containers {
+		if containerNames != nil && !slices.Contains(containerNames, container.Name) {
+			continue
+		}
+		language := detectLanguage(inst.Spec.LanguageDetection, container)
+		if language == "" {
+			logger.V(1).Info("language not detected, skipping container instrumentation", "container", container.Name)
+			continue
+		}
+		logger.V(1).Info("language detected", "container", container.Name, "language", language)
+		containers[language] = append(containers[language], container.Name)
+	}
+
+	insts := languageInstrumentations{}
+	for _, lang := range languageInjectors {
+		names, ok := containers[lang.Language()]
+		if !ok {
+			continue
+		}
+		if !lang.Enabled() {
+			err := fmt.Errorf("support for %s auto instrumentation is not enabled", lang.Name())
+			logger.Error(err, "skipping instrumentation injection", "containers", names)
+			if rejection := pm.reportFailure(ctx, ns, pod, rejectedReason, err, inst); rejection != nil {
+				return pod, rejection
+			}
+			continue
+		}
+		insts[lang.Language()] = instrumentationWithContainers{Instrumentation: inst, Containers: strings.Join(names, ",")}
+	}
+
+	if len(insts) == 0 {
+		logger.V(1).Info("no supported language detected, skipping instrumentation injection")
+		return pod, nil
+	}
+
+	// The containers of different languages are instrumented like with the language specific container names annotations.
+	if len(insts) > 1 && !featuregate.EnableMultiInstrumentationSupport.IsEnabled() {
+		err := fmt.Errorf("multiple languages detected while multi instrumentation support is not enabled")
+		logger.V(1).Error(err, "skipping instrumentation injection")
+		return pod, pm.reportFailure(ctx, ns, pod, failureReason, err, inst)
+	}
+
+	resolved, err := pm.resolveExporters(ctx, insts)
+	if err != nil {
+		logger.Error(err, "skipping instrumentation injection")
+		return pod, pm.reportFailure(ctx, ns, pod, failureReason, err, inst)
+	}
+
+	return pm.sdkInjector.inject(ctx, resolved, ns, pod), nil
+}
+
+// reportFailure reports the instrumentation that couldn't be injected into the pod, and returns the error rejecting
+// the pod when the failure policy of the namespace or of the given instrumentations requires it.
+func (pm *instPodMutator) reportFailure(ctx context.Context, ns corev1.Namespace, pod corev1.Pod, reason string, err error, insts ...*v1alpha1.Instrumentation) error {
+	var policies []v1alpha1.InjectionFailurePolicy
+	var related []runtime.Object
+	recorded := map[types.NamespacedName]bool{}
+	for _, inst := range insts {
+		if inst != nil {
+			policies = append(policies, inst.Spec.InjectionFailurePolicy)
+			// the failures recorded on the Instrumentations are reported in their status
+			key := types.NamespacedName{Namespace: inst.Namespace, Name: inst.Name}
+			if featuregate.EnableInstrumentationStatus.IsEnabled() && !recorded[key] {
+				recorded[key] = true
+				related = append(related, inst)
+			}
+		}
+	}
+	return pm.reporter.Report(ctx, ns, pod, podmutation.FailurePolicy(ns, policies...), reason, err, related...)
+}
+
+// failed reports the instrumentation that couldn't be injected into the pod like reportFailure, returning the
+// given error when the pod isn't rejected.
+func (pm *instPodMutator) failed(ctx context.Context, ns corev1.Namespace, pod corev1.Pod, reason string, err error) error {
+	if rejection := pm.reportFailure(ctx, ns, pod, reason, err); rejection != nil {
+		return rejection
+	}
+	return err
+}
+
 func (pm *instPodMutator) getInstrumentationInstance(ctx context.Context, ns corev1.Namespace, pod corev1.Pod, instAnnotation string) (*v1alpha1.Instrumentation, error) {
 	instValue := annotationValue(ns.ObjectMeta, pod.ObjectMeta, instAnnotation)
 
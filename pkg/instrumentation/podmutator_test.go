@@ -16,6 +16,7 @@ package instrumentation
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -25,14 +26,20 @@ import (
 	colfeaturegate "go.opentelemetry.io/collector/featuregate"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/webhook/podmutation"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 func TestMutatePod(t *testing.T) {
-	mutator := NewMutator(logr.Discard(), k8sClient, record.NewFakeRecorder(100))
+	mutator := NewMutator(logr.Discard(), config.New(), k8sClient, record.NewFakeRecorder(100))
 	require.NotNil(t, mutator)
 
 	true := true
@@ -5174,8 +5181,8 @@ func TestSingleInstrumentationEnabled(t *testing.T) {
 		{
 			name: "Single instrumentation enabled",
 			instrumentations: languageInstrumentations{
-				Java:   instrumentationWithContainers{Instrumentation: &v1alpha1.Instrumentation{}},
-				NodeJS: instrumentationWithContainers{Instrumentation: nil},
+				"java":   {Instrumentation: &v1alpha1.Instrumentation{}},
+				"nodejs": {Instrumentation: nil},
 			},
 			expectedStatus: true,
 			expectedMsg:    "Java",
@@ -5183,8 +5190,8 @@ func TestSingleInstrumentationEnabled(t *testing.T) {
 		{
 			name: "Multiple instrumentations enabled",
 			instrumentations: languageInstrumentations{
-				Java:   instrumentationWithContainers{Instrumentation: &v1alpha1.Instrumentation{}},
-				NodeJS: instrumentationWithContainers{Instrumentation: &v1alpha1.Instrumentation{}},
+				"java":   {Instrumentation: &v1alpha1.Instrumentation{}},
+				"nodejs": {Instrumentation: &v1alpha1.Instrumentation{}},
 			},
 			expectedStatus: false,
 			expectedMsg:    "",
@@ -5192,8 +5199,8 @@ func TestSingleInstrumentationEnabled(t *testing.T) {
 		{
 			name: "Instrumentations disabled",
 			instrumentations: languageInstrumentations{
-				Java:   instrumentationWithContainers{Instrumentation: nil},
-				NodeJS: instrumentationWithContainers{Instrumentation: nil},
+				"java":   {Instrumentation: nil},
+				"nodejs": {Instrumentation: nil},
 			},
 			expectedStatus: false,
 			expectedMsg:    "",
@@ -5218,8 +5225,8 @@ func TestContainerNamesConfiguredForMultipleInstrumentations(t *testing.T) {
 		{
 			name: "Single instrumentation enabled without containers",
 			instrumentations: languageInstrumentations{
-				Java:   instrumentationWithContainers{Instrumentation: &v1alpha1.Instrumentation{}},
-				NodeJS: instrumentationWithContainers{Instrumentation: nil},
+				"java":   {Instrumentation: &v1alpha1.Instrumentation{}},
+				"nodejs": {Instrumentation: nil},
 			},
 			expectedStatus: true,
 			expectedMsg:    nil,
@@ -5227,8 +5234,8 @@ func TestContainerNamesConfiguredForMultipleInstrumentations(t *testing.T) {
 		{
 			name: "Multiple instrumentations enabled with containers",
 			instrumentations: languageInstrumentations{
-				Java:   instrumentationWithContainers{Instrumentation: &v1alpha1.Instrumentation{}, Containers: "java"},
-				NodeJS: instrumentationWithContainers{Instrumentation: &v1alpha1.Instrumentation{}, Containers: "nodejs"},
+				"java":   {Instrumentation: &v1alpha1.Instrumentation{}, Containers: "java"},
+				"nodejs": {Instrumentation: &v1alpha1.Instrumentation{}, Containers: "nodejs"},
 			},
 			expectedStatus: true,
 			expectedMsg:    nil,
@@ -5236,8 +5243,8 @@ func TestContainerNamesConfiguredForMultipleInstrumentations(t *testing.T) {
 		{
 			name: "Multiple instrumentations enabled without containers",
 			instrumentations: languageInstrumentations{
-				Java:   instrumentationWithContainers{Instrumentation: &v1alpha1.Instrumentation{}},
-				NodeJS: instrumentationWithContainers{Instrumentation: &v1alpha1.Instrumentation{}},
+				"java":   {Instrumentation: &v1alpha1.Instrumentation{}},
+				"nodejs": {Instrumentation: &v1alpha1.Instrumentation{}},
 			},
 			expectedStatus: false,
 			expectedMsg:    fmt.Errorf("incorrect instrumentation configuration - please provide container names for all instrumentations"),
@@ -5245,8 +5252,8 @@ func TestContainerNamesConfiguredForMultipleInstrumentations(t *testing.T) {
 		{
 			name: "Multiple instrumentations enabled with containers for single instrumentation",
 			instrumentations: languageInstrumentations{
-				Java:   instrumentationWithContainers{Instrumentation: &v1alpha1.Instrumentation{}, Containers: "test"},
-				NodeJS: instrumentationWithContainers{Instrumentation: &v1alpha1.Instrumentation{}},
+				"java":   {Instrumentation: &v1alpha1.Instrumentation{}, Containers: "test"},
+				"nodejs": {Instrumentation: &v1alpha1.Instrumentation{}},
 			},
 			expectedStatus: false,
 			expectedMsg:    fmt.Errorf("incorrect instrumentation configuration - please provide container names for all instrumentations"),
@@ -5254,7 +5261,7 @@ func TestContainerNamesConfiguredForMultipleInstrumentations(t *testing.T) {
 		{
 			name: "Disabled instrumentations",
 			instrumentations: languageInstrumentations{
-				NodeJS: instrumentationWithContainers{Instrumentation: nil},
+				"nodejs": {Instrumentation: nil},
 			},
 			expectedStatus: false,
 			expectedMsg:    fmt.Errorf("instrumentation configuration not provided"),
@@ -5262,8 +5269,8 @@ func TestContainerNamesConfiguredForMultipleInstrumentations(t *testing.T) {
 		{
 			name: "Multiple instrumentations enabled with duplicated containers",
 			instrumentations: languageInstrumentations{
-				Java:   instrumentationWithContainers{Instrumentation: &v1alpha1.Instrumentation{}, Containers: "app,app1,java"},
-				NodeJS: instrumentationWithContainers{Instrumentation: &v1alpha1.Instrumentation{}, Containers: "app1,app,nodejs"},
+				"java":   {Instrumentation: &v1alpha1.Instrumentation{}, Containers: "app,app1,java"},
+				"nodejs": {Instrumentation: &v1alpha1.Instrumentation{}, Containers: "app1,app,nodejs"},
 			},
 			expectedStatus: false,
 			expectedMsg:    fmt.Errorf("duplicated container names detected: [app app1]"),
@@ -5271,8 +5278,8 @@ func TestContainerNamesConfiguredForMultipleInstrumentations(t *testing.T) {
 		{
 			name: "Multiple instrumentations enabled with duplicated containers for single instrumentation",
 			instrumentations: languageInstrumentations{
-				Java:   instrumentationWithContainers{Instrumentation: &v1alpha1.Instrumentation{}, Containers: "app,app,java"},
-				NodeJS: instrumentationWithContainers{Instrumentation: &v1alpha1.Instrumentation{}, Containers: "nodejs"},
+				"java":   {Instrumentation: &v1alpha1.Instrumentation{}, Containers: "app,app,java"},
+				"nodejs": {Instrumentation: &v1alpha1.Instrumentation{}, Containers: "nodejs"},
 			},
 			expectedStatus: false,
 			expectedMsg:    fmt.Errorf("duplicated container names detected: [app]"),
@@ -5298,13 +5305,13 @@ func TestInstrumentationLanguageContainersSet(t *testing.T) {
 		{
 			name: "Set containers for enabled instrumentation",
 			instrumentations: languageInstrumentations{
-				NodeJS: instrumentationWithContainers{Instrumentation: nil},
-				Python: instrumentationWithContainers{Instrumentation: &v1alpha1.Instrumentation{}},
+				"nodejs": {Instrumentation: nil},
+				"python": {Instrumentation: &v1alpha1.Instrumentation{}},
 			},
 			containers: "python,python1",
 			expectedInstrumentations: languageInstrumentations{
-				NodeJS: instrumentationWithContainers{Instrumentation: nil},
-				Python: instrumentationWithContainers{Instrumentation: &v1alpha1.Instrumentation{}, Containers: "python,python1"},
+				"nodejs": {Instrumentation: nil},
+				"python": {Instrumentation: &v1alpha1.Instrumentation{}, Containers: "python,python1"},
 			},
 		},
 		{
@@ -5322,3 +5329,205 @@ func TestInstrumentationLanguageContainersSet(t *testing.T) {
 		})
 	}
 }
+
+func TestMutatePodInjectionFailurePolicy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	failingInst := &v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{Name: "failing-inst", Namespace: "app"},
+		Spec:       v1alpha1.InstrumentationSpec{InjectionFailurePolicy: v1alpha1.InjectionFailurePolicyFail},
+	}
+	ignoringInst := &v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{Name: "ignoring-inst", Namespace: "app"},
+	}
+
+	tests := []struct {
+		name     string
+		insts    []client.Object
+		ns       corev1.Namespace
+		pod      corev1.Pod
+		err      bool
+		rejected bool
+		event    string
+	}{
+		{
+			name: "no instance, ignored by default",
+			ns:   corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}},
+			pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{annotationInjectJava: "true"},
+			}},
+			err:   true,
+			event: "Warning InstrumentationInjectionFailed no OpenTelemetry Instrumentation instances available",
+		},
+		{
+			name: "no instance, rejected by the namespace policy",
+			ns: corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:        "app",
+				Annotations: map[string]string{podmutation.InjectionFailurePolicyAnnotation: "fail"},
+			}},
+			pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{annotationInjectJava: "true"},
+			}},
+			err:      true,
+			rejected: true,
+			event:    "Warning InstrumentationInjectionFailed pod rejected: no OpenTelemetry Instrumentation instances available",
+		},
+		{
+			name:  "disabled language, ignored by the instance policy",
+			insts: []client.Object{ignoringInst},
+			ns:    corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}},
+			pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{annotationInjectGo: "ignoring-inst"},
+			}},
+			event: "Warning InstrumentationRequestRejected support for Go auto instrumentation is not enabled",
+		},
+		{
+			name:  "disabled language, rejected by the instance policy",
+			insts: []client.Object{failingInst},
+			ns:    corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}},
+			pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{annotationInjectGo: "failing-inst"},
+			}},
+			err:      true,
+			rejected: true,
+			event:    "Warning InstrumentationRequestRejected pod rejected: support for Go auto instrumentation is not enabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.insts...).Build()
+			recorder := record.NewFakeRecorder(1)
+			mutator := NewMutator(logr.Discard(), config.New(), cli, recorder)
+
+			pod, err := mutator.Mutate(context.Background(), tt.ns, tt.pod)
+
+			var rejection *podmutation.RejectionError
+			assert.Equal(t, tt.err, err != nil)
+			assert.Equal(t, tt.rejected, errors.As(err, &rejection))
+			assert.Equal(t, tt.pod, pod)
+			require.Len(t, recorder.Events, 1)
+			assert.Equal(t, tt.event, <-recorder.Events)
+		})
+	}
+}
+
+func TestMutatePodLanguageDetection(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	inst := &v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-inst", Namespace: "app"},
+		Spec: v1alpha1.InstrumentationSpec{
+			LanguageDetection: v1alpha1.LanguageDetection{
+				Images: []v1alpha1.ImageLanguage{
+					{Image: "registry.example.com/payments/*", Language: "nodejs"},
+				},
+			},
+		},
+	}
+	polyglot := []corev1.Container{
+		{Name: "api", Image: "registry.example.com/api:1", Command: []string{"java", "-jar", "/app.jar"}},
+		{Name: "worker", Image: "python:3.11"},
+		{Name: "proxy", Image: "envoyproxy/envoy:v1.28"},
+	}
+
+	tests := []struct {
+		name           string
+		annotations    map[string]string
+		containers     []corev1.Container
+		multiInst      bool
+		initContainers []string
+		instrumented   map[string]string
+		event          string
+	}{
+		{
+			name:           "containers routed to the detected languages",
+			annotations:    map[string]string{annotationInjectAuto: "true"},
+			containers:     polyglot,
+			multiInst:      true,
+			initContainers: []string{javaInitContainerName, pythonInitContainerName},
+			instrumented:   map[string]string{"api": javaVolumeName, "worker": pythonVolumeName},
+		},
+		{
+			name:        "multiple languages without multi instrumentation",
+			annotations: map[string]string{annotationInjectAuto: "true"},
+			containers:  polyglot,
+			event:       "Warning InstrumentationInjectionFailed multiple languages detected while multi instrumentation support is not enabled",
+		},
+		{
+			name:           "containers restricted by the container names annotation",
+			annotations:    map[string]string{annotationInjectAuto: "true", annotationInjectContainerName: "worker"},
+			containers:     polyglot,
+			initContainers: []string{pythonInitContainerName},
+			instrumented:   map[string]string{"worker": pythonVolumeName},
+		},
+		{
+			name:           "image mapped by the instrumentation",
+			annotations:    map[string]string{annotationInjectAuto: "my-inst"},
+			containers:     []corev1.Container{{Name: "payments", Image: "registry.example.com/payments/api:2"}},
+			initContainers: []string{nodejsInitContainerName},
+			instrumented:   map[string]string{"payments": nodejsVolumeName},
+		},
+		{
+			name:           "language annotation taking precedence",
+			annotations:    map[string]string{annotationInjectAuto: "true", annotationInjectPython: "true"},
+			containers:     polyglot,
+			initContainers: []string{pythonInitContainerName},
+			instrumented:   map[string]string{"api": pythonVolumeName},
+		},
+		{
+			name:        "no language detected",
+			annotations: map[string]string{annotationInjectAuto: "true"},
+			containers:  []corev1.Container{{Name: "proxy", Image: "envoyproxy/envoy:v1.28"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			originalVal := featuregate.EnableMultiInstrumentationSupport.IsEnabled()
+			require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableMultiInstrumentationSupport.ID(), tt.multiInst))
+			t.Cleanup(func() {
+				require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableMultiInstrumentationSupport.ID(), originalVal))
+			})
+
+			cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(inst.DeepCopy()).Build()
+			recorder := record.NewFakeRecorder(1)
+			mutator := NewMutator(logr.Discard(), config.New(), cli, recorder)
+			ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}}
+			pod := corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "app", Annotations: tt.annotations},
+				Spec:       corev1.PodSpec{Containers: tt.containers},
+			}
+
+			mutated, err := mutator.Mutate(context.Background(), ns, *pod.DeepCopy())
+			require.NoError(t, err)
+
+			var initContainers []string
+			for _, container := range mutated.Spec.InitContainers {
+				initContainers = append(initContainers, container.Name)
+			}
+			assert.Equal(t, tt.initContainers, initContainers)
+			for _, container := range mutated.Spec.Containers {
+				var mounts []string
+				for _, mount := range container.VolumeMounts {
+					mounts = append(mounts, mount.Name)
+				}
+				if volume, ok := tt.instrumented[container.Name]; ok {
+					assert.Equal(t, []string{volume}, mounts, container.Name)
+				} else {
+					assert.Empty(t, mounts, container.Name)
+				}
+			}
+			if tt.event == "" {
+				assert.Empty(t, recorder.Events)
+			} else {
+				require.Len(t, recorder.Events, 1)
+				assert.Equal(t, tt.event, <-recorder.Events)
+			}
+		})
+	}
+}
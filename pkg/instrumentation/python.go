@@ -15,11 +15,14 @@
 package instrumentation
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 const (
@@ -126,3 +129,52 @@ func injectPythonSDK(pythonSpec v1alpha1.Python, pod corev1.Pod, index int) (cor
 	}
 	return pod, nil
 }
+
+// pythonInjector injects the Python auto-instrumentation.
+type pythonInjector struct{}
+
+func (pythonInjector) Language() string { return "python" }
+
+func (pythonInjector) Name() string { return "Python" }
+
+func (pythonInjector) Annotation() string { return annotationInjectPython }
+
+func (pythonInjector) ContainersAnnotation() string { return annotationInjectPythonContainersName }
+
+func (pythonInjector) Protocol() v1alpha1.ExporterProtocol {
+	return v1alpha1.ExporterProtocolHTTPProtobuf
+}
+
+func (pythonInjector) Enabled() bool {
+	return featuregate.EnablePythonAutoInstrumentationSupport.IsEnabled()
+}
+
+func (pythonInjector) Section(spec v1alpha1.InstrumentationSpec) (LanguageSection, bool) {
+	return LanguageSection{
+		Image:       spec.Python.Image,
+		Env:         spec.Python.Env,
+		Resources:   spec.Python.Resources,
+		Exporter:    spec.Python.Exporter,
+		Sampler:     spec.Python.Sampler,
+		Propagators: spec.Python.Propagators,
+	}, true
+}
+
+func (pythonInjector) inject(ctx context.Context, i *sdkInjector, inst instrumentationWithContainers, ns corev1.Namespace, pod corev1.Pod) corev1.Pod {
+	otelinst := *inst.Instrumentation
+	var err error
+	i.logger.V(1).Info("injecting Python instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+
+	for _, container := range strings.Split(inst.Containers, ",") {
+		index := getContainerIndex(container, pod)
+		pod, err = injectPythonSDK(otelinst.Spec.Python, pod, index)
+		if err != nil {
+			i.logger.Info("Skipping Python SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
+		} else {
+			pod = i.injectCommonEnvVar(otelinst, pod, index)
+			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
+			pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, pythonInitContainerName)
+		}
+	}
+	return pod
+}
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+const (
+	envRubyOpt            = "RUBYOPT"
+	rubyRequireArgument   = " -r/otel-auto-instrumentation-ruby/autoinstrumentation.rb"
+	rubyInitContainerName = initContainerName + "-ruby"
+	rubyVolumeName        = volumeName + "-ruby"
+	rubyInstrMountPath    = "/otel-auto-instrumentation-ruby"
+)
+
+func injectRubySDK(rubySpec v1alpha1.Ruby, pod corev1.Pod, index int) (corev1.Pod, error) {
+	// caller checks if there is at least one container.
+	container := &pod.Spec.Containers[index]
+
+	err := validateContainerEnv(container.Env, envRubyOpt)
+	if err != nil {
+		return pod, err
+	}
+
+	// inject Ruby instrumentation spec env vars.
+	for _, env := range rubySpec.Env {
+		idx := getIndexOfEnv(container.Env, env.Name)
+		if idx == -1 {
+			container.Env = append(container.Env, env)
+		}
+	}
+
+	idx := getIndexOfEnv(container.Env, envRubyOpt)
+	if idx == -1 {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  envRubyOpt,
+			Value: strings.TrimSpace(rubyRequireArgument),
+		})
+	} else if idx > -1 {
+		container.Env[idx].Value = container.Env[idx].Value + rubyRequireArgument
+	}
+
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      rubyVolumeName,
+		MountPath: rubyInstrMountPath,
+	})
+
+	// We just inject Volumes and init containers for the first processed container.
+	if isInitContainerMissing(pod, rubyInitContainerName) {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: rubyVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					SizeLimit: volumeSize(rubySpec.VolumeSizeLimit),
+				},
+			}})
+
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, corev1.Container{
+			Name:      rubyInitContainerName,
+			Image:     rubySpec.Image,
+			Command:   []string{"cp", "-a", "/autoinstrumentation/.", rubyInstrMountPath},
+			Resources: rubySpec.Resources,
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      rubyVolumeName,
+				MountPath: rubyInstrMountPath,
+			}},
+		})
+	}
+	return pod, nil
+}
+
+// rubyInjector injects the Ruby auto-instrumentation.
+type rubyInjector struct{}
+
+func (rubyInjector) Language() string { return "ruby" }
+
+func (rubyInjector) Name() string { return "Ruby" }
+
+func (rubyInjector) Annotation() string { return annotationInjectRuby }
+
+func (rubyInjector) ContainersAnnotation() string { return annotationInjectRubyContainersName }
+
+func (rubyInjector) Protocol() v1alpha1.ExporterProtocol {
+	return v1alpha1.ExporterProtocolHTTPProtobuf
+}
+
+func (rubyInjector) Enabled() bool {
+	return featuregate.EnableRubyAutoInstrumentationSupport.IsEnabled()
+}
+
+func (rubyInjector) Section(spec v1alpha1.InstrumentationSpec) (LanguageSection, bool) {
+	return LanguageSection{
+		Image:       spec.Ruby.Image,
+		Env:         spec.Ruby.Env,
+		Resources:   spec.Ruby.Resources,
+		Exporter:    spec.Ruby.Exporter,
+		Sampler:     spec.Ruby.Sampler,
+		Propagators: spec.Ruby.Propagators,
+	}, true
+}
+
+func (rubyInjector) inject(ctx context.Context, i *sdkInjector, inst instrumentationWithContainers, ns corev1.Namespace, pod corev1.Pod) corev1.Pod {
+	otelinst := *inst.Instrumentation
+	var err error
+	i.logger.V(1).Info("injecting Ruby instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+
+	for _, container := range strings.Split(inst.Containers, ",") {
+		index := getContainerIndex(container, pod)
+		pod, err = injectRubySDK(otelinst.Spec.Ruby, pod, index)
+		if err != nil {
+			i.logger.Info("Skipping Ruby SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
+		} else {
+			pod = i.injectCommonEnvVar(otelinst, pod, index)
+			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
+			pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, rubyInitContainerName)
+		}
+	}
+	return pod
+}
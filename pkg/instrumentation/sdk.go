@@ -26,11 +26,13 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 
 	"go.opentelemetry.io/otel/attribute"
 	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -57,155 +59,54 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 		return pod
 	}
 
-	if insts.Java.Instrumentation != nil {
-		otelinst := *insts.Java.Instrumentation
-		var err error
-		i.logger.V(1).Info("injecting Java instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
-
-		javaContainers := insts.Java.Containers
-
-		for _, container := range strings.Split(javaContainers, ",") {
-			index := getContainerIndex(container, pod)
-			pod, err = injectJavaagent(otelinst.Spec.Java, pod, index)
-			if err != nil {
-				i.logger.Info("Skipping javaagent injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
-			} else {
-				pod = i.injectCommonEnvVar(otelinst, pod, index)
-				pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
-				pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, javaInitContainerName)
+	// the languages whose injection was skipped for all their containers aren't recorded as injected
+	injected := languageInstrumentations{}
+	for _, lang := range languageInjectors {
+		if inst := insts[lang.Language()]; inst.Instrumentation != nil {
+			if !featuregate.EnableInstrumentationStatus.IsEnabled() {
+				pod = lang.inject(ctx, i, withLanguageOverrides(lang, inst), ns, pod)
+				continue
 			}
-		}
-	}
-	if insts.NodeJS.Instrumentation != nil {
-		otelinst := *insts.NodeJS.Instrumentation
-		var err error
-		i.logger.V(1).Info("injecting NodeJS instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
-
-		nodejsContainers := insts.NodeJS.Containers
-
-		for _, container := range strings.Split(nodejsContainers, ",") {
-			index := getContainerIndex(container, pod)
-			pod, err = injectNodeJSSDK(otelinst.Spec.NodeJS, pod, index)
-			if err != nil {
-				i.logger.Info("Skipping NodeJS SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
-			} else {
-				pod = i.injectCommonEnvVar(otelinst, pod, index)
-				pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
-				pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, nodejsInitContainerName)
+			before := pod.DeepCopy()
+			pod = lang.inject(ctx, i, withLanguageOverrides(lang, inst), ns, pod)
+			if !apiequality.Semantic.DeepEqual(before.Spec, pod.Spec) {
+				injected[lang.Language()] = inst
 			}
 		}
 	}
-	if insts.Python.Instrumentation != nil {
-		otelinst := *insts.Python.Instrumentation
-		var err error
-		i.logger.V(1).Info("injecting Python instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 
-		pythonContainers := insts.Python.Containers
+	pod = recordInjectedLanguages(injected, pod)
+	return recordInjectionHashes(insts, pod)
+}
 
-		for _, container := range strings.Split(pythonContainers, ",") {
-			index := getContainerIndex(container, pod)
-			pod, err = injectPythonSDK(otelinst.Spec.Python, pod, index)
-			if err != nil {
-				i.logger.Info("Skipping Python SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
-			} else {
-				pod = i.injectCommonEnvVar(otelinst, pod, index)
-				pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
-				pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, pythonInitContainerName)
-			}
-		}
-	}
-	if insts.DotNet.Instrumentation != nil {
-		otelinst := *insts.DotNet.Instrumentation
-		var err error
-		i.logger.V(1).Info("injecting DotNet instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+// sdkOnlyInjector injects the OpenTelemetry SDK configuration only, for the applications instrumented already.
+type sdkOnlyInjector struct{}
 
-		dotnetContainers := insts.DotNet.Containers
+func (sdkOnlyInjector) Language() string { return "sdk" }
 
-		for _, container := range strings.Split(dotnetContainers, ",") {
-			index := getContainerIndex(container, pod)
-			pod, err = injectDotNetSDK(otelinst.Spec.DotNet, pod, index, insts.DotNet.AdditionalAnnotations[annotationDotNetRuntime])
-			if err != nil {
-				i.logger.Info("Skipping DotNet SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
-			} else {
-				pod = i.injectCommonEnvVar(otelinst, pod, index)
-				pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
-				pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, dotnetInitContainerName)
-			}
-		}
-	}
-	if insts.Go.Instrumentation != nil {
-		origPod := pod
-		otelinst := *insts.Go.Instrumentation
-		var err error
-		i.logger.V(1).Info("injecting Go instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
-
-		goContainers := insts.Go.Containers
-
-		// Go instrumentation supports only single container instrumentation.
-		index := getContainerIndex(goContainers, pod)
-		pod, err = injectGoSDK(otelinst.Spec.Go, pod)
-		if err != nil {
-			i.logger.Info("Skipping Go SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
-		} else {
-			// Common env vars and config need to be applied to the agent contain.
-			pod = i.injectCommonEnvVar(otelinst, pod, len(pod.Spec.Containers)-1)
-			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, len(pod.Spec.Containers)-1, 0)
-
-			// Ensure that after all the env var coalescing we have a value for OTEL_GO_AUTO_TARGET_EXE
-			idx := getIndexOfEnv(pod.Spec.Containers[len(pod.Spec.Containers)-1].Env, envOtelTargetExe)
-			if idx == -1 {
-				i.logger.Info("Skipping Go SDK injection", "reason", "OTEL_GO_AUTO_TARGET_EXE not set", "container", pod.Spec.Containers[index].Name)
-				pod = origPod
-			}
-		}
-	}
-	if insts.ApacheHttpd.Instrumentation != nil {
-		otelinst := *insts.ApacheHttpd.Instrumentation
-		i.logger.V(1).Info("injecting Apache Httpd instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
-
-		apacheHttpdContainers := insts.ApacheHttpd.Containers
-
-		for _, container := range strings.Split(apacheHttpdContainers, ",") {
-			index := getContainerIndex(container, pod)
-			// Apache agent is configured via config files rather than env vars.
-			// Therefore, service name, otlp endpoint and other attributes are passed to the agent injection method
-			pod = injectApacheHttpdagent(i.logger, otelinst.Spec.ApacheHttpd, pod, index, otelinst.Spec.Endpoint, i.createResourceMap(ctx, otelinst, ns, pod, index))
-			pod = i.injectCommonEnvVar(otelinst, pod, index)
-			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
-			pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, apacheAgentInitContainerName)
-			pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, apacheAgentCloneContainerName)
-		}
-	}
+func (sdkOnlyInjector) Name() string { return "SDK" }
 
-	if insts.Nginx.Instrumentation != nil {
-		otelinst := *insts.Nginx.Instrumentation
-		i.logger.V(1).Info("injecting Nginx instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+func (sdkOnlyInjector) Annotation() string { return annotationInjectSdk }
 
-		nginxContainers := insts.Nginx.Containers
+func (sdkOnlyInjector) ContainersAnnotation() string { return annotationInjectSdkContainersName }
 
-		for _, container := range strings.Split(nginxContainers, ",") {
-			index := getContainerIndex(container, pod)
-			// Nginx agent is configured via config files rather than env vars.
-			// Therefore, service name, otlp endpoint and other attributes are passed to the agent injection method
-			pod = injectNginxSDK(i.logger, otelinst.Spec.Nginx, pod, index, otelinst.Spec.Endpoint, i.createResourceMap(ctx, otelinst, ns, pod, index))
-			pod = i.injectCommonEnvVar(otelinst, pod, index)
-			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
-		}
-	}
+func (sdkOnlyInjector) Protocol() v1alpha1.ExporterProtocol { return v1alpha1.ExporterProtocolGRPC }
 
-	if insts.Sdk.Instrumentation != nil {
-		otelinst := *insts.Sdk.Instrumentation
-		i.logger.V(1).Info("injecting sdk-only instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+func (sdkOnlyInjector) Enabled() bool { return true }
 
-		sdkContainers := insts.Sdk.Containers
+func (sdkOnlyInjector) Section(v1alpha1.InstrumentationSpec) (LanguageSection, bool) {
+	return LanguageSection{}, false
+}
 
-		for _, container := range strings.Split(sdkContainers, ",") {
-			index := getContainerIndex(container, pod)
-			pod = i.injectCommonEnvVar(otelinst, pod, index)
-			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
-		}
-	}
+func (sdkOnlyInjector) inject(ctx context.Context, i *sdkInjector, inst instrumentationWithContainers, ns corev1.Namespace, pod corev1.Pod) corev1.Pod {
+	otelinst := *inst.Instrumentation
+	i.logger.V(1).Info("injecting sdk-only instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 
+	for _, container := range strings.Split(inst.Containers, ",") {
+		index := getContainerIndex(container, pod)
+		pod = i.injectCommonEnvVar(otelinst, pod, index)
+		pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
+	}
 	return pod
 }
 
@@ -260,15 +161,20 @@ func (i *sdkInjector) injectCommonSDKConfig(ctx context.Context, otelinst v1alph
 			Value: chooseServiceName(pod, resourceMap, appIndex),
 		})
 	}
-	if otelinst.Spec.Exporter.Endpoint != "" {
+	if endpoint := exporterEndpoint(otelinst.Spec.Exporter); endpoint != "" {
 		idx = getIndexOfEnv(container.Env, constants.EnvOTELExporterOTLPEndpoint)
 		if idx == -1 {
+			// the node-local endpoint refers to the node's IP, which has to be defined before
+			if otelinst.Spec.Exporter.NodeLocal != nil && getIndexOfEnv(container.Env, constants.EnvNodeIP) == -1 {
+				container.Env = append(container.Env, nodeIPEnvVar())
+			}
 			container.Env = append(container.Env, corev1.EnvVar{
 				Name:  constants.EnvOTELExporterOTLPEndpoint,
-				Value: otelinst.Spec.Endpoint,
+				Value: endpoint,
 			})
 		}
 	}
+	pod = injectExporterConfig(otelinst.Spec.Exporter, pod, agentIndex)
 
 	// Some attributes might be empty, we should get them via k8s downward API
 	if resourceMap[string(semconv.K8SPodNameKey)] == "" {
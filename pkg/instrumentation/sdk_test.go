@@ -29,6 +29,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
 )
 
 var defaultVolumeLimitSize = resource.MustParse("200Mi")
@@ -501,7 +502,7 @@ func TestInjectJava(t *testing.T) {
 		},
 	}
 	insts := languageInstrumentations{
-		Java: instrumentationWithContainers{Instrumentation: &inst, Containers: ""},
+		"java": {Instrumentation: &inst, Containers: ""},
 	}
 	inj := sdkInjector{
 		logger: logr.Discard(),
@@ -605,7 +606,7 @@ func TestInjectNodeJS(t *testing.T) {
 		},
 	}
 	insts := languageInstrumentations{
-		NodeJS: instrumentationWithContainers{Instrumentation: &inst, Containers: ""},
+		"nodejs": {Instrumentation: &inst, Containers: ""},
 	}
 	inj := sdkInjector{
 		logger: logr.Discard(),
@@ -708,7 +709,7 @@ func TestInjectPython(t *testing.T) {
 		},
 	}
 	insts := languageInstrumentations{
-		Python: instrumentationWithContainers{Instrumentation: &inst, Containers: ""},
+		"python": {Instrumentation: &inst, Containers: ""},
 	}
 
 	inj := sdkInjector{
@@ -827,7 +828,7 @@ func TestInjectDotNet(t *testing.T) {
 		},
 	}
 	insts := languageInstrumentations{
-		DotNet: instrumentationWithContainers{Instrumentation: &inst, Containers: ""},
+		"dotnet": {Instrumentation: &inst, Containers: ""},
 	}
 	inj := sdkInjector{
 		logger: logr.Discard(),
@@ -955,7 +956,7 @@ func TestInjectGo(t *testing.T) {
 		{
 			name: "shared process namespace disabled",
 			insts: languageInstrumentations{
-				Go: instrumentationWithContainers{Instrumentation: &v1alpha1.Instrumentation{
+				"go": {Instrumentation: &v1alpha1.Instrumentation{
 					Spec: v1alpha1.InstrumentationSpec{
 						Go: v1alpha1.Go{
 							Image: "otel/go:1",
@@ -988,7 +989,7 @@ func TestInjectGo(t *testing.T) {
 		{
 			name: "OTEL_GO_AUTO_TARGET_EXE not set",
 			insts: languageInstrumentations{
-				Go: instrumentationWithContainers{Instrumentation: &v1alpha1.Instrumentation{
+				"go": {Instrumentation: &v1alpha1.Instrumentation{
 					Spec: v1alpha1.InstrumentationSpec{
 						Go: v1alpha1.Go{
 							Image: "otel/go:1",
@@ -1019,7 +1020,7 @@ func TestInjectGo(t *testing.T) {
 		{
 			name: "OTEL_GO_AUTO_TARGET_EXE set by inst",
 			insts: languageInstrumentations{
-				Go: instrumentationWithContainers{Instrumentation: &v1alpha1.Instrumentation{
+				"go": {Instrumentation: &v1alpha1.Instrumentation{
 					Spec: v1alpha1.InstrumentationSpec{
 						Go: v1alpha1.Go{
 							Image: "otel/go:1",
@@ -1114,7 +1115,7 @@ func TestInjectGo(t *testing.T) {
 		{
 			name: "OTEL_GO_AUTO_TARGET_EXE set by annotation",
 			insts: languageInstrumentations{
-				Go: instrumentationWithContainers{
+				"go": {
 					Containers: "",
 					Instrumentation: &v1alpha1.Instrumentation{
 						Spec: v1alpha1.InstrumentationSpec{
@@ -1236,7 +1237,7 @@ func TestInjectApacheHttpd(t *testing.T) {
 		{
 			name: "injection enabled, exporter set",
 			insts: languageInstrumentations{
-				ApacheHttpd: instrumentationWithContainers{
+				"apache-httpd": {
 					Instrumentation: &v1alpha1.Instrumentation{
 						Spec: v1alpha1.InstrumentationSpec{
 							ApacheHttpd: v1alpha1.ApacheHttpd{
@@ -1393,7 +1394,7 @@ func TestInjectNginx(t *testing.T) {
 		{
 			name: "injection enabled, exporter set",
 			insts: languageInstrumentations{
-				Nginx: instrumentationWithContainers{
+				"nginx": {
 					Instrumentation: &v1alpha1.Instrumentation{
 						Spec: v1alpha1.InstrumentationSpec{
 							Nginx: v1alpha1.Nginx{
@@ -1553,7 +1554,7 @@ func TestInjectSdkOnly(t *testing.T) {
 		},
 	}
 	insts := languageInstrumentations{
-		Sdk: instrumentationWithContainers{Instrumentation: &inst, Containers: ""},
+		"sdk": {Instrumentation: &inst, Containers: ""},
 	}
 
 	inj := sdkInjector{
@@ -1612,3 +1613,53 @@ func TestInjectSdkOnly(t *testing.T) {
 		},
 	}, pod)
 }
+
+func TestInjectLanguageOverrides(t *testing.T) {
+	inst := v1alpha1.Instrumentation{
+		Spec: v1alpha1.InstrumentationSpec{
+			Exporter:    v1alpha1.Exporter{Endpoint: "http://gateway:4317"},
+			Sampler:     v1alpha1.Sampler{Type: v1alpha1.ParentBasedTraceIDRatio, Argument: "0.25"},
+			Propagators: []v1alpha1.Propagator{v1alpha1.TraceContext},
+			Java:        v1alpha1.Java{Image: "img:1"},
+			NodeJS: v1alpha1.NodeJS{
+				Image:       "img:1",
+				Exporter:    &v1alpha1.Exporter{Endpoint: "http://node-agent:4318"},
+				Sampler:     &v1alpha1.Sampler{Type: v1alpha1.AlwaysOn},
+				Propagators: []v1alpha1.Propagator{v1alpha1.B3, v1alpha1.Baggage},
+			},
+		},
+	}
+	insts := languageInstrumentations{
+		"java":   {Instrumentation: &inst, Containers: "java-app"},
+		"nodejs": {Instrumentation: &inst, Containers: "node-app"},
+	}
+	inj := sdkInjector{
+		logger: logr.Discard(),
+	}
+	pod := inj.inject(context.Background(), insts,
+		corev1.Namespace{},
+		corev1.Pod{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "java-app", Image: "java-app:latest"},
+					{Name: "node-app", Image: "node-app:latest"},
+				},
+			},
+		})
+
+	envValue := func(container corev1.Container, name string) string {
+		if idx := getIndexOfEnv(container.Env, name); idx != -1 {
+			return container.Env[idx].Value
+		}
+		return ""
+	}
+	java, nodejs := pod.Spec.Containers[0], pod.Spec.Containers[1]
+	assert.Equal(t, "http://gateway:4317", envValue(java, constants.EnvOTELExporterOTLPEndpoint))
+	assert.Equal(t, "parentbased_traceidratio", envValue(java, constants.EnvOTELTracesSampler))
+	assert.Equal(t, "0.25", envValue(java, constants.EnvOTELTracesSamplerArg))
+	assert.Equal(t, "tracecontext", envValue(java, constants.EnvOTELPropagators))
+	assert.Equal(t, "http://node-agent:4318", envValue(nodejs, constants.EnvOTELExporterOTLPEndpoint))
+	assert.Equal(t, "always_on", envValue(nodejs, constants.EnvOTELTracesSampler))
+	assert.Equal(t, "", envValue(nodejs, constants.EnvOTELTracesSamplerArg))
+	assert.Equal(t, "b3,baggage", envValue(nodejs, constants.EnvOTELPropagators))
+}
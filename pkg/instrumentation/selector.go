@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// selectInstrumentationInstance returns the Instrumentation with a selector of the given language selecting the pod,
+// among the given Instrumentations. It's used for the languages the pod and its namespace have no annotation for.
+func selectInstrumentationInstance(lang LanguageInjector, otelInsts []v1alpha1.Instrumentation, ns corev1.Namespace, pod corev1.Pod) (*v1alpha1.Instrumentation, error) {
+	var selected []*v1alpha1.Instrumentation
+	for i := range otelInsts {
+		for _, selector := range otelInsts[i].Spec.Selectors {
+			if !selectsLanguage(selector, lang) {
+				continue
+			}
+			matches, err := selectsPod(selector, otelInsts[i].Namespace, ns, pod)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s selector of the OpenTelemetry Instrumentation %s/%s: %w", lang.Name(), otelInsts[i].Namespace, otelInsts[i].Name, err)
+			}
+			if matches {
+				selected = append(selected, &otelInsts[i])
+				break
+			}
+		}
+	}
+
+	switch len(selected) {
+	case 0:
+		return nil, nil
+	case 1:
+		return selected[0], nil
+	}
+	names := make([]string, 0, len(selected))
+	for _, inst := range selected {
+		names = append(names, types.NamespacedName{Namespace: inst.Namespace, Name: inst.Name}.String())
+	}
+	sort.Strings(names)
+	return nil, fmt.Errorf("multiple OpenTelemetry Instrumentation instances select the pod for %s: %s", lang.Name(), strings.Join(names, ", "))
+}
+
+// selectsLanguage returns whether the selector injects the auto-instrumentation of the given language.
+func selectsLanguage(selector v1alpha1.InjectionSelector, lang LanguageInjector) bool {
+	for _, language := range selector.Languages {
+		if string(language) == lang.Language() {
+			return true
+		}
+	}
+	return false
+}
+
+// selectsPod returns whether the selector of an Instrumentation in the given namespace selects the pod.
+func selectsPod(selector v1alpha1.InjectionSelector, instNamespace string, ns corev1.Namespace, pod corev1.Pod) (bool, error) {
+	if selector.PodSelector == nil && selector.NamespaceSelector == nil {
+		return false, nil
+	}
+
+	if selector.NamespaceSelector == nil && ns.Name != instNamespace {
+		return false, nil
+	}
+	for _, s := range []struct {
+		selector *metav1.LabelSelector
+		labels   map[string]string
+		exclude  bool
+	}{
+		{selector.NamespaceSelector, ns.Labels, false},
+		{selector.PodSelector, pod.Labels, false},
+		{selector.ExcludeNamespaceSelector, ns.Labels, true},
+		{selector.ExcludePodSelector, pod.Labels, true},
+	} {
+		if s.selector == nil {
+			continue
+		}
+		labelSelector, err := metav1.LabelSelectorAsSelector(s.selector)
+		if err != nil {
+			return false, err
+		}
+		if labelSelector.Matches(labels.Set(s.labels)) == s.exclude {
+			return false, nil
+		}
+	}
+	return true, nil
+}
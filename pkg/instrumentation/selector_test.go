@@ -0,0 +1,265 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/webhook/podmutation"
+)
+
+func TestSelectsPod(t *testing.T) {
+	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments", Labels: map[string]string{"team": "payments"}}}
+	pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "checkout", "tier": "backend"}}}
+	selector := func(labels map[string]string) *metav1.LabelSelector {
+		return &metav1.LabelSelector{MatchLabels: labels}
+	}
+
+	tests := []struct {
+		name          string
+		selector      v1alpha1.InjectionSelector
+		instNamespace string
+		expected      bool
+	}{
+		{
+			name:          "no selector",
+			instNamespace: "payments",
+		},
+		{
+			name:          "pod selector in the namespace of the instrumentation",
+			selector:      v1alpha1.InjectionSelector{PodSelector: selector(map[string]string{"tier": "backend"})},
+			instNamespace: "payments",
+			expected:      true,
+		},
+		{
+			name:          "pod selector in another namespace",
+			selector:      v1alpha1.InjectionSelector{PodSelector: selector(map[string]string{"tier": "backend"})},
+			instNamespace: "observability",
+		},
+		{
+			name:          "pod selector not matching",
+			selector:      v1alpha1.InjectionSelector{PodSelector: selector(map[string]string{"tier": "frontend"})},
+			instNamespace: "payments",
+		},
+		{
+			name:          "namespace selector",
+			selector:      v1alpha1.InjectionSelector{NamespaceSelector: selector(map[string]string{"team": "payments"})},
+			instNamespace: "observability",
+			expected:      true,
+		},
+		{
+			name: "namespace and pod selectors",
+			selector: v1alpha1.InjectionSelector{
+				NamespaceSelector: selector(map[string]string{"team": "payments"}),
+				PodSelector:       selector(map[string]string{"tier": "frontend"}),
+			},
+			instNamespace: "observability",
+		},
+		{
+			name: "excluded pod",
+			selector: v1alpha1.InjectionSelector{
+				NamespaceSelector:  &metav1.LabelSelector{},
+				ExcludePodSelector: selector(map[string]string{"app": "checkout"}),
+			},
+			instNamespace: "observability",
+		},
+		{
+			name: "excluded namespace",
+			selector: v1alpha1.InjectionSelector{
+				PodSelector:              selector(map[string]string{"tier": "backend"}),
+				ExcludeNamespaceSelector: selector(map[string]string{"team": "payments"}),
+			},
+			instNamespace: "payments",
+		},
+		{
+			name: "exclusion not matching",
+			selector: v1alpha1.InjectionSelector{
+				NamespaceSelector:  &metav1.LabelSelector{},
+				ExcludePodSelector: selector(map[string]string{"app": "cart"}),
+			},
+			instNamespace: "observability",
+			expected:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := selectsPod(tt.selector, tt.instNamespace, ns, pod)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, selected)
+		})
+	}
+}
+
+func TestSelectInstrumentationInstance(t *testing.T) {
+	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments", Labels: map[string]string{"team": "payments"}}}
+	pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "checkout"}}}
+	instrumentation := func(name string, selectors ...v1alpha1.InjectionSelector) v1alpha1.Instrumentation {
+		return v1alpha1.Instrumentation{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "observability"},
+			Spec:       v1alpha1.InstrumentationSpec{Selectors: selectors},
+		}
+	}
+	payments := func(languages ...v1alpha1.InjectionLanguage) v1alpha1.InjectionSelector {
+		return v1alpha1.InjectionSelector{
+			Languages:         languages,
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+		}
+	}
+	checkout := v1alpha1.InjectionSelector{
+		Languages:   []v1alpha1.InjectionLanguage{"python"},
+		PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "checkout"}},
+	}
+	otelInsts := []v1alpha1.Instrumentation{
+		instrumentation("java", payments("java", "go")),
+		// an Instrumentation selecting the pod twice is selected once
+		instrumentation("python", payments("python"), checkout),
+		instrumentation("python-too", payments("python")),
+	}
+
+	inst, err := selectInstrumentationInstance(javaInjector{}, otelInsts, ns, pod)
+	require.NoError(t, err)
+	assert.Equal(t, "java", inst.Name)
+
+	inst, err = selectInstrumentationInstance(goInjector{}, otelInsts, ns, pod)
+	require.NoError(t, err)
+	assert.Equal(t, "java", inst.Name)
+
+	inst, err = selectInstrumentationInstance(nodeJSInjector{}, otelInsts, ns, pod)
+	require.NoError(t, err)
+	assert.Nil(t, inst)
+
+	_, err = selectInstrumentationInstance(pythonInjector{}, otelInsts, ns, pod)
+	assert.EqualError(t, err, "multiple OpenTelemetry Instrumentation instances select the pod for Python: observability/python, observability/python-too")
+
+	invalid := instrumentation("invalid", v1alpha1.InjectionSelector{
+		Languages:         []v1alpha1.InjectionLanguage{"java"},
+		NamespaceSelector: &metav1.LabelSelector{},
+		PodSelector: &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Unknown"}},
+		},
+	})
+	_, err = selectInstrumentationInstance(javaInjector{}, []v1alpha1.Instrumentation{invalid}, ns, pod)
+	assert.ErrorContains(t, err, "invalid Java selector of the OpenTelemetry Instrumentation observability/invalid")
+}
+
+func TestMutatePodSelector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	inst := &v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{Name: "fleet", Namespace: "observability"},
+		Spec: v1alpha1.InstrumentationSpec{
+			Exporter: v1alpha1.Exporter{Endpoint: "http://collector:4317"},
+			Java:     v1alpha1.Java{Image: "otel/java:1"},
+			Selectors: []v1alpha1.InjectionSelector{{
+				Languages:         []v1alpha1.InjectionLanguage{"java"},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+			}},
+		},
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(inst).Build()
+	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments", Labels: map[string]string{"team": "payments"}}}
+
+	tests := []struct {
+		name        string
+		allowed     []string
+		annotations map[string]string
+		existing    bool
+		expected    bool
+	}{
+		{
+			name:     "selected pod",
+			allowed:  []string{"observability"},
+			expected: true,
+		},
+		{
+			name: "namespace not allowed to select other namespaces",
+		},
+		{
+			name:        "annotation disabling the injection",
+			allowed:     []string{"observability"},
+			annotations: map[string]string{annotationInjectJava: "false"},
+		},
+		{
+			name:        "language detection requested",
+			allowed:     []string{"observability"},
+			annotations: map[string]string{annotationInjectAuto: "false"},
+		},
+		{
+			name:     "existing pod",
+			allowed:  []string{"observability"},
+			existing: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.New(config.WithCrossNamespaceSelectorNamespaces(tt.allowed))
+			mutator := NewMutator(logr.Discard(), cfg, cli, record.NewFakeRecorder(10))
+			pod := corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "payments", Annotations: tt.annotations},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+			}
+			if tt.existing {
+				// the pods can't be injected into once created, e.g. when relabeled
+				pod.CreationTimestamp = metav1.Now()
+			}
+
+			mutated, err := mutator.Mutate(context.Background(), ns, pod)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, isAutoInstrumentationInjected(mutated))
+		})
+	}
+}
+
+func TestMutatePodSelectorListFailure(t *testing.T) {
+	// the Instrumentations can't be listed without their type in the scheme
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	cli := fake.NewClientBuilder().WithScheme(scheme).Build()
+	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        "payments",
+		Annotations: map[string]string{podmutation.InjectionFailurePolicyAnnotation: string(v1alpha1.InjectionFailurePolicyFail)},
+	}}
+	mutator := NewMutator(logr.Discard(), config.New(), cli, record.NewFakeRecorder(10))
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "payments"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+	}
+
+	// the pods which didn't request an injection are left alone
+	mutated, err := mutator.Mutate(context.Background(), ns, pod)
+	require.NoError(t, err)
+	assert.Equal(t, pod, mutated)
+
+	// the ones which did fail as requested
+	pod.Annotations = map[string]string{annotationInjectPython: "true"}
+	_, err = mutator.Mutate(context.Background(), ns, pod)
+	assert.Error(t, err)
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+const (
+	// InjectedLanguagesAnnotation records the languages injected into a pod, along with the Instrumentation each of
+	// them was injected from, as comma-separated "language=namespace/name" entries.
+	InjectedLanguagesAnnotation = "instrumentation.opentelemetry.io/injected-languages"
+	// InjectedImagesAnnotation records the auto-instrumentation images injected into a pod, as comma-separated
+	// "language=image" entries.
+	InjectedImagesAnnotation = "instrumentation.opentelemetry.io/injected-images"
+)
+
+// InjectedLanguages returns the "namespace/name" of the Instrumentations the given pod was injected with, by language.
+func InjectedLanguages(pod corev1.Pod) map[string]string {
+	return annotationEntries(pod, InjectedLanguagesAnnotation)
+}
+
+// InjectedImages returns the auto-instrumentation images the given pod was injected with, by language.
+func InjectedImages(pod corev1.Pod) map[string]string {
+	return annotationEntries(pod, InjectedImagesAnnotation)
+}
+
+func annotationEntries(pod corev1.Pod, annotation string) map[string]string {
+	entries := map[string]string{}
+	for _, entry := range strings.Split(pod.Annotations[annotation], ",") {
+		if key, value, ok := strings.Cut(entry, "="); ok {
+			entries[key] = value
+		}
+	}
+	return entries
+}
+
+// recordInjectedLanguages records the languages injected into the given pod and the Instrumentations they were
+// injected from, for the Instrumentations' status. They are only recorded when the instrumentation status is enabled.
+func recordInjectedLanguages(insts languageInstrumentations, pod corev1.Pod) corev1.Pod {
+	if !featuregate.EnableInstrumentationStatus.IsEnabled() {
+		return pod
+	}
+
+	var languages, images []string
+	for _, lang := range languageInjectors {
+		inst, ok := insts[lang.Language()]
+		if !ok || inst.Instrumentation == nil {
+			continue
+		}
+		languages = append(languages, fmt.Sprintf("%s=%s/%s", lang.Language(), inst.Instrumentation.Namespace, inst.Instrumentation.Name))
+		if section, ok := lang.Section(inst.Instrumentation.Spec); ok && section.Image != "" {
+			images = append(images, fmt.Sprintf("%s=%s", lang.Language(), section.Image))
+		}
+	}
+	if len(languages) == 0 {
+		return pod
+	}
+
+	if pod.Labels == nil {
+		pod.Labels = map[string]string{}
+	}
+	pod.Labels[InjectedLabel] = "true"
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[InjectedLanguagesAnnotation] = strings.Join(languages, ",")
+	if len(images) > 0 {
+		pod.Annotations[InjectedImagesAnnotation] = strings.Join(images, ",")
+	}
+	return pod
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+func TestRecordInjectedLanguages(t *testing.T) {
+	inst := &v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-inst", Namespace: "app"},
+		Spec: v1alpha1.InstrumentationSpec{
+			Java:   v1alpha1.Java{Image: "java:1"},
+			Python: v1alpha1.Python{Image: "python:1"},
+		},
+	}
+	insts := languageInstrumentations{
+		"python": {Instrumentation: inst},
+		"java":   {Instrumentation: inst},
+		"sdk":    {Instrumentation: inst},
+	}
+
+	// disabled by default, the pods are left untouched
+	pod := recordInjectedLanguages(insts, corev1.Pod{})
+	assert.Empty(t, pod.Labels)
+	assert.Empty(t, pod.Annotations)
+
+	originalVal := featuregate.EnableInstrumentationStatus.IsEnabled()
+	require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableInstrumentationStatus.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableInstrumentationStatus.ID(), originalVal))
+	})
+
+	pod = recordInjectedLanguages(insts, corev1.Pod{})
+	assert.Equal(t, "true", pod.Labels[InjectedLabel])
+	assert.Equal(t, "java=app/my-inst,python=app/my-inst,sdk=app/my-inst", pod.Annotations[InjectedLanguagesAnnotation])
+	assert.Equal(t, "java=java:1,python=python:1", pod.Annotations[InjectedImagesAnnotation])
+	assert.Equal(t, map[string]string{"java": "app/my-inst", "python": "app/my-inst", "sdk": "app/my-inst"}, InjectedLanguages(pod))
+	assert.Equal(t, map[string]string{"java": "java:1", "python": "python:1"}, InjectedImages(pod))
+
+	// nothing to record
+	pod = recordInjectedLanguages(languageInstrumentations{}, corev1.Pod{})
+	assert.Empty(t, pod.Labels)
+	assert.Empty(t, pod.Annotations)
+}
+
+func TestInjectRecordsInjectedLanguages(t *testing.T) {
+	originalVal := featuregate.EnableInstrumentationStatus.IsEnabled()
+	require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableInstrumentationStatus.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableInstrumentationStatus.ID(), originalVal))
+	})
+
+	inst := &v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-inst", Namespace: "app"},
+		Spec: v1alpha1.InstrumentationSpec{
+			NodeJS: v1alpha1.NodeJS{Image: "nodejs:1"},
+			Python: v1alpha1.Python{Image: "python:1"},
+		},
+	}
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: "app"},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{Name: "frontend"},
+			// the Python injection is skipped, the container's PYTHONPATH can't be extended
+			{Name: "worker", Env: []corev1.EnvVar{{Name: "PYTHONPATH", ValueFrom: &corev1.EnvVarSource{}}}},
+		}},
+	}
+	injector := sdkInjector{logger: logr.Discard(), client: fake.NewClientBuilder().Build()}
+	insts := languageInstrumentations{
+		"nodejs": {Instrumentation: inst, Containers: "frontend"},
+		"python": {Instrumentation: inst, Containers: "worker"},
+	}
+
+	pod = injector.inject(context.Background(), insts, corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}}, pod)
+
+	assert.Equal(t, map[string]string{"nodejs": "app/my-inst"}, InjectedLanguages(pod))
+	assert.Equal(t, map[string]string{"nodejs": "nodejs:1"}, InjectedImages(pod))
+}
@@ -38,6 +38,8 @@ var (
 		constants.AnnotationDefaultAutoInstrumentationGo:          featuregate.EnableGoAutoInstrumentationSupport,
 		constants.AnnotationDefaultAutoInstrumentationApacheHttpd: featuregate.EnableApacheHTTPAutoInstrumentationSupport,
 		constants.AnnotationDefaultAutoInstrumentationNginx:       featuregate.EnableNginxAutoInstrumentationSupport,
+		constants.AnnotationDefaultAutoInstrumentationRuby:        featuregate.EnableRubyAutoInstrumentationSupport,
+		constants.AnnotationDefaultAutoInstrumentationPHP:         featuregate.EnablePHPAutoInstrumentationSupport,
 	}
 )
 
@@ -52,6 +54,8 @@ type InstrumentationUpgrade struct {
 	DefaultAutoInstApacheHttpd string
 	DefaultAutoInstNginx       string
 	DefaultAutoInstGo          string
+	DefaultAutoInstRuby        string
+	DefaultAutoInstPHP         string
 }
 
 // +kubebuilder:rbac:groups=opentelemetry.io,resources=instrumentations,verbs=get;list;watch;update;patch
@@ -130,6 +134,16 @@ func (u *InstrumentationUpgrade) upgrade(_ context.Context, inst v1alpha1.Instru
 						upgraded.Spec.Nginx.Image = u.DefaultAutoInstNginx
 						upgraded.Annotations[annotation] = u.DefaultAutoInstNginx
 					}
+				case constants.AnnotationDefaultAutoInstrumentationRuby:
+					if inst.Spec.Ruby.Image == autoInst {
+						upgraded.Spec.Ruby.Image = u.DefaultAutoInstRuby
+						upgraded.Annotations[annotation] = u.DefaultAutoInstRuby
+					}
+				case constants.AnnotationDefaultAutoInstrumentationPHP:
+					if inst.Spec.PHP.Image == autoInst {
+						upgraded.Spec.PHP.Image = u.DefaultAutoInstPHP
+						upgraded.Annotations[annotation] = u.DefaultAutoInstPHP
+					}
 				}
 			} else {
 				u.Logger.Error(nil, "autoinstrumentation not enabled for this language", "flag", gate.ID())
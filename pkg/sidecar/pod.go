@@ -16,70 +16,230 @@
 package sidecar
 
 import (
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/nativesidecar"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
 
 const (
-	injectedLabel = "sidecar.opentelemetry.io/injected"
-	confEnvVar    = "OTEL_CONFIG"
+	// InjectedLabel is set on the pods a sidecar was injected into, with the collector's "namespace.name" as value.
+	InjectedLabel = "sidecar.opentelemetry.io/injected"
+	// ConfigHashAnnotation records the hash of the sidecar injected into a pod, see ConfigHash.
+	ConfigHashAnnotation = "sidecar.opentelemetry.io/config-hash"
+
+	confEnvVar = "OTEL_CONFIG"
+
+	// configReloadInterval is how often, in seconds, the reloader checks the mounted configuration for changes.
+	configReloadInterval = 5
 )
 
 // add a new sidecar container to the given pod, based on the given OpenTelemetryCollector.
 func add(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector, pod corev1.Pod, attributes []corev1.EnvVar) (corev1.Pod, error) {
-	otelColCfg, err := collector.ReplaceConfig(otelcol)
+	inj, err := newInjection(cfg, logger, otelcol)
+	if err != nil {
+		return pod, err
+	}
+	// the hash doesn't cover the resource attributes, which are specific to each pod
+	injectionHash, err := inj.hash()
 	if err != nil {
 		return pod, err
 	}
 
-	container := collector.Container(cfg, logger, otelcol, false)
-	container.Args = append(container.Args, fmt.Sprintf("--config=env:%s", confEnvVar))
-
-	container.Env = append(container.Env, corev1.EnvVar{Name: confEnvVar, Value: otelColCfg})
+	container := &inj.Containers[0]
 	if !hasResourceAttributeEnvVar(container.Env) {
 		container.Env = append(container.Env, attributes...)
 	}
-	pod.Spec.InitContainers = append(pod.Spec.InitContainers, otelcol.Spec.InitContainers...)
-	pod.Spec.Containers = append(pod.Spec.Containers, container)
-	pod.Spec.Volumes = append(pod.Spec.Volumes, otelcol.Spec.Volumes...)
+
+	pod.Spec.InitContainers = append(pod.Spec.InitContainers, inj.InitContainers...)
+	if inj.Native {
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, inj.Containers...)
+	} else {
+		pod.Spec.Containers = append(pod.Spec.Containers, inj.Containers...)
+	}
+	pod.Spec.Volumes = append(pod.Spec.Volumes, inj.Volumes...)
+	if inj.ShareProcessNamespace {
+		shareProcessNamespace := true
+		pod.Spec.ShareProcessNamespace = &shareProcessNamespace
+	}
 
 	if pod.Labels == nil {
 		pod.Labels = map[string]string{}
 	}
-	pod.Labels[injectedLabel] = naming.Truncate("%s.%s", 63, otelcol.Namespace, otelcol.Name)
+	pod.Labels[InjectedLabel] = naming.Truncate("%s.%s", 63, otelcol.Namespace, otelcol.Name)
+
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[ConfigHashAnnotation] = injectionHash
 
 	return pod, nil
 }
 
-// remove the sidecar container from the given pod.
+// injection holds what is injected into a pod for a given OpenTelemetryCollector, the sidecar container first.
+type injection struct {
+	Containers            []corev1.Container `json:"containers"`
+	InitContainers        []corev1.Container `json:"initContainers,omitempty"`
+	Volumes               []corev1.Volume    `json:"volumes,omitempty"`
+	Native                bool               `json:"native,omitempty"`
+	ShareProcessNamespace bool               `json:"shareProcessNamespace,omitempty"`
+}
+
+func newInjection(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) (injection, error) {
+	inj := injection{
+		InitContainers: otelcol.Spec.InitContainers,
+		Native:         useNativeSidecar(cfg, otelcol),
+	}
+
+	if otelcol.Spec.SidecarConfigReload {
+		// the configuration is read from the collector's config map, which the kubelet keeps up to date, and the
+		// reloader signals the collector to reload it when it changes: the collector keeps running, along with its
+		// sending queues
+		inj.Containers = append(inj.Containers, collector.Container(cfg, logger, otelcol, true), configReloaderContainer(cfg, otelcol))
+		inj.Volumes = append(inj.Volumes, collector.Volumes(cfg, otelcol)[0])
+		inj.ShareProcessNamespace = true
+	} else {
+		otelColCfg, err := collector.ReplaceConfig(otelcol)
+		if err != nil {
+			return inj, err
+		}
+		container := collector.Container(cfg, logger, otelcol, false)
+		container.Args = append(container.Args, fmt.Sprintf("--config=env:%s", confEnvVar))
+		container.Env = append(container.Env, corev1.EnvVar{Name: confEnvVar, Value: otelColCfg})
+		inj.Containers = append(inj.Containers, container)
+	}
+	inj.Volumes = append(inj.Volumes, otelcol.Spec.Volumes...)
+
+	if inj.Native {
+		// native sidecars start before the application containers and are stopped after them
+		restartPolicy := corev1.ContainerRestartPolicyAlways
+		for i := range inj.Containers {
+			inj.Containers[i].RestartPolicy = &restartPolicy
+		}
+	}
+	return inj, nil
+}
+
+func (inj injection) hash() (string, error) {
+	b, err := json.Marshal(inj)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(b)), nil
+}
+
+// ConfigHash returns the hash of the sidecar the given OpenTelemetryCollector injects into the pods of the given namespace,
+// covering its configuration, image, environment and any other setting requiring the pods to be restarted.
+// Pods record the hash they were injected with in the ConfigHashAnnotation annotation.
+func ConfigHash(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector, namespace string) (string, error) {
+	inj, err := newInjection(cfg, logr.Discard(), forNamespace(otelcol, namespace))
+	if err != nil {
+		return "", err
+	}
+	return inj.hash()
+}
+
+// forNamespace disables the configuration reload for pods in a namespace other than the collector's,
+// as config map volumes can't reference another namespace. The configuration is passed at injection time instead.
+func forNamespace(otelcol v1alpha1.OpenTelemetryCollector, namespace string) v1alpha1.OpenTelemetryCollector {
+	if otelcol.Namespace != namespace {
+		otelcol.Spec.SidecarConfigReload = false
+	}
+	return otelcol
+}
+
+// configReloaderContainer builds the container sending a SIGHUP to the collector when its mounted configuration
+// changes, for the collector to reload it. The collector process is visible to the reloader as the pod shares its
+// process namespace, and the reloader runs with the collector's security context to be allowed to signal it.
+func configReloaderContainer(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) corev1.Container {
+	configFile := fmt.Sprintf("/conf/%s", cfg.CollectorConfigMapEntry())
+	// the bracket keeps the pattern from matching the reloader's own command line
+	script := fmt.Sprintf(`last=$(md5sum %[1]s)
+while true; do
+  sleep %[2]d
+  current=$(md5sum %[1]s)
+  if [ "$current" != "$last" ]; then
+    last="$current"
+    echo "configuration changed, reloading the collector"
+    pkill -HUP -f '[-]-config=%[1]s'
+  fi
+done`, configFile, configReloadInterval)
+
+	return corev1.Container{
+		Name:    naming.ConfigReloaderContainer(),
+		Image:   cfg.SidecarConfigReloaderImage(),
+		Command: []string{"/bin/sh", "-c", script},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      naming.ConfigMapVolume(),
+			MountPath: "/conf",
+			ReadOnly:  true,
+		}},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("5m"),
+				corev1.ResourceMemory: resource.MustParse("8Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("32Mi"),
+			},
+		},
+		SecurityContext: otelcol.Spec.SecurityContext,
+	}
+}
+
+// useNativeSidecar returns whether the sidecar should be injected as a native sidecar container.
+func useNativeSidecar(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) bool {
+	switch otelcol.Spec.SidecarPlacement {
+	case v1alpha1.SidecarPlacementInitContainer:
+		return true
+	case v1alpha1.SidecarPlacementContainer:
+		return false
+	default:
+		return cfg.NativeSidecarAvailability() == nativesidecar.Available
+	}
+}
+
+// remove the sidecar container, and its config reloader if any, from the given pod, whether it was injected as a container or a native sidecar.
 func remove(pod corev1.Pod) (corev1.Pod, error) {
 	if !existsIn(pod) {
 		return pod, nil
 	}
 
-	var containers []corev1.Container
-	for _, container := range pod.Spec.Containers {
-		if container.Name != naming.Container() {
-			containers = append(containers, container)
+	pod.Spec.Containers = withoutSidecar(pod.Spec.Containers)
+	pod.Spec.InitContainers = withoutSidecar(pod.Spec.InitContainers)
+	return pod, nil
+}
+
+func withoutSidecar(containers []corev1.Container) []corev1.Container {
+	var result []corev1.Container
+	for _, container := range containers {
+		if container.Name != naming.Container() && container.Name != naming.ConfigReloaderContainer() {
+			result = append(result, container)
 		}
 	}
-	pod.Spec.Containers = containers
-	return pod, nil
+	return result
 }
 
-// existsIn checks whether a sidecar container exists in the given pod.
+// existsIn checks whether a sidecar container exists in the given pod, either as a container or a native sidecar.
 func existsIn(pod corev1.Pod) bool {
 	for _, container := range pod.Spec.Containers {
 		if container.Name == naming.Container() {
 			return true
 		}
 	}
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name == naming.Container() {
+			return true
+		}
+	}
 	return false
 }
@@ -24,6 +24,7 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/nativesidecar"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
@@ -154,6 +155,29 @@ func TestRemoveSidecar(t *testing.T) {
 	assert.Len(t, changed.Spec.Containers, 1)
 }
 
+func TestRemoveNativeSidecar(t *testing.T) {
+	// prepare
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "my-app"},
+			},
+			InitContainers: []corev1.Container{
+				{Name: "my-init"},
+				{Name: naming.Container()},
+			},
+		},
+	}
+
+	// test
+	changed, err := remove(pod)
+
+	// verify
+	assert.NoError(t, err)
+	assert.Len(t, changed.Spec.Containers, 1)
+	assert.Equal(t, []corev1.Container{{Name: "my-init"}}, changed.Spec.InitContainers)
+}
+
 func TestRemoveNonExistingSidecar(t *testing.T) {
 	// prepare
 	pod := corev1.Pod{
@@ -189,6 +213,19 @@ func TestExistsIn(t *testing.T) {
 			},
 			true},
 
+		{"has-native-sidecar",
+			corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "my-app"},
+					},
+					InitContainers: []corev1.Container{
+						{Name: naming.Container()},
+					},
+				},
+			},
+			true},
+
 		{"does-not-have-sidecar",
 			corev1.Pod{
 				Spec: corev1.PodSpec{
@@ -236,3 +273,182 @@ func TestAddSidecarWithAditionalEnv(t *testing.T) {
 	assert.Contains(t, changed.Spec.Containers[1].Env, extraEnv)
 
 }
+
+func TestAddNativeSidecar(t *testing.T) {
+	for _, tt := range []struct {
+		desc         string
+		placement    v1alpha1.SidecarPlacement
+		availability nativesidecar.Availability
+		native       bool
+	}{
+		{"auto-available", v1alpha1.SidecarPlacementAuto, nativesidecar.Available, true},
+		{"auto-not-available", v1alpha1.SidecarPlacementAuto, nativesidecar.NotAvailable, false},
+		{"unset-available", "", nativesidecar.Available, true},
+		{"container-available", v1alpha1.SidecarPlacementContainer, nativesidecar.Available, false},
+		{"init-container-not-available", v1alpha1.SidecarPlacementInitContainer, nativesidecar.NotAvailable, true},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			pod := corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers:     []corev1.Container{{Name: "my-app"}},
+					InitContainers: []corev1.Container{{Name: "my-init"}},
+				},
+			}
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Name: "otelcol-native", Namespace: "some-app"},
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Mode:             v1alpha1.ModeSidecar,
+					SidecarPlacement: tt.placement,
+					Config:           "receivers:\n  otlp:\n",
+				},
+			}
+			cfg := config.New(config.WithCollectorImage("some-default-image"), config.WithNativeSidecarAvailability(tt.availability))
+
+			// test
+			changed, err := add(cfg, logger, otelcol, pod, nil)
+
+			// verify
+			require.NoError(t, err)
+			assert.True(t, existsIn(changed))
+			if tt.native {
+				require.Len(t, changed.Spec.Containers, 1)
+				require.Len(t, changed.Spec.InitContainers, 2)
+				assert.Equal(t, "my-init", changed.Spec.InitContainers[0].Name)
+				sidecar := changed.Spec.InitContainers[1]
+				assert.Equal(t, naming.Container(), sidecar.Name)
+				require.NotNil(t, sidecar.RestartPolicy)
+				assert.Equal(t, corev1.ContainerRestartPolicyAlways, *sidecar.RestartPolicy)
+			} else {
+				require.Len(t, changed.Spec.Containers, 2)
+				require.Len(t, changed.Spec.InitContainers, 1)
+				assert.Equal(t, naming.Container(), changed.Spec.Containers[1].Name)
+				assert.Nil(t, changed.Spec.Containers[1].RestartPolicy)
+			}
+		})
+	}
+}
+
+func TestAddSidecarWithConfigReload(t *testing.T) {
+	// prepare
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "my-app"}},
+		},
+	}
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "otelcol-reload", Namespace: "some-app"},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:                v1alpha1.ModeSidecar,
+			SidecarConfigReload: true,
+			Config:              "receivers:\n  otlp:\n",
+		},
+	}
+	cfg := config.New(config.WithCollectorImage("some-default-image"), config.WithSidecarConfigReloaderImage("some-reloader-image"))
+
+	// test
+	changed, err := add(cfg, logger, otelcol, pod, nil)
+
+	// verify
+	require.NoError(t, err)
+	require.Len(t, changed.Spec.Containers, 3)
+	sidecar := changed.Spec.Containers[1]
+	assert.Equal(t, naming.Container(), sidecar.Name)
+	assert.Equal(t, []string{"--config=/conf/collector.yaml"}, sidecar.Args)
+	for _, env := range sidecar.Env {
+		assert.NotEqual(t, "OTEL_CONFIG", env.Name)
+	}
+	assert.Contains(t, sidecar.VolumeMounts, corev1.VolumeMount{Name: naming.ConfigMapVolume(), MountPath: "/conf"})
+
+	// the collector keeps its own liveness probe, the reloader signals it when the configuration changes
+	assert.Nil(t, sidecar.LivenessProbe)
+
+	reloader := changed.Spec.Containers[2]
+	assert.Equal(t, naming.ConfigReloaderContainer(), reloader.Name)
+	assert.Equal(t, "some-reloader-image", reloader.Image)
+	assert.Contains(t, reloader.Command[2], "md5sum /conf/collector.yaml")
+	assert.Contains(t, reloader.Command[2], "pkill -HUP -f '[-]-config=/conf/collector.yaml'")
+	assert.Equal(t, []corev1.VolumeMount{{Name: naming.ConfigMapVolume(), MountPath: "/conf", ReadOnly: true}}, reloader.VolumeMounts)
+
+	require.Len(t, changed.Spec.Volumes, 1)
+	assert.Equal(t, naming.ConfigMapVolume(), changed.Spec.Volumes[0].Name)
+	assert.Equal(t, naming.ConfigMap(otelcol.Name), changed.Spec.Volumes[0].ConfigMap.Name)
+	// the reloader sees the collector's process
+	require.NotNil(t, changed.Spec.ShareProcessNamespace)
+	assert.True(t, *changed.Spec.ShareProcessNamespace)
+
+	expectedHash, err := ConfigHash(cfg, otelcol, "some-app")
+	require.NoError(t, err)
+	assert.Equal(t, expectedHash, changed.Annotations[ConfigHashAnnotation])
+
+	// the reloader is removed along with the sidecar
+	removed, err := remove(changed)
+	require.NoError(t, err)
+	require.Len(t, removed.Spec.Containers, 1)
+	assert.Equal(t, "my-app", removed.Spec.Containers[0].Name)
+}
+
+func TestAddSidecarWithConfigReloadAndSecurityContext(t *testing.T) {
+	user := int64(1000)
+	securityContext := &corev1.SecurityContext{RunAsUser: &user}
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "otelcol-reload", Namespace: "some-app"},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:                v1alpha1.ModeSidecar,
+			SidecarConfigReload: true,
+			Config:              "extensions:\n  health_check:\nservice:\n  extensions: [health_check]\n",
+			SecurityContext:     securityContext,
+		},
+	}
+	cfg := config.New(config.WithCollectorImage("some-default-image"))
+
+	changed, err := add(cfg, logger, otelcol, corev1.Pod{}, nil)
+
+	require.NoError(t, err)
+	require.Len(t, changed.Spec.Containers, 2)
+	// the collector's health check still backs its liveness probe
+	require.NotNil(t, changed.Spec.Containers[0].LivenessProbe)
+	assert.Equal(t, "/", changed.Spec.Containers[0].LivenessProbe.HTTPGet.Path)
+	// and the reloader runs as the collector's user, to be allowed to signal it
+	assert.Equal(t, securityContext, changed.Spec.Containers[1].SecurityContext)
+}
+
+func TestConfigHash(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "otelcol-hash", Namespace: "some-app"},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: "receivers:\n  otlp:\n",
+		},
+	}
+	cfg := config.New(config.WithCollectorImage("some-default-image"))
+	first, err := ConfigHash(cfg, otelcol, "some-app")
+	require.NoError(t, err)
+
+	// the hash matches the annotation of the injected pods, regardless of the pod's resource attributes
+	pod, err := add(cfg, logger, otelcol, corev1.Pod{}, []corev1.EnvVar{{Name: "OTEL_RESOURCE_ATTRIBUTES", Value: "k8s.pod.name=my-pod"}})
+	require.NoError(t, err)
+	assert.Equal(t, first, pod.Annotations[ConfigHashAnnotation])
+
+	otelcol.Spec.Config = "receivers:\n  jaeger:\n"
+	changedConfig, err := ConfigHash(cfg, otelcol, "some-app")
+	require.NoError(t, err)
+	assert.NotEqual(t, first, changedConfig)
+
+	otelcol.Spec.Image = "some-other-image"
+	changedImage, err := ConfigHash(cfg, otelcol, "some-app")
+	require.NoError(t, err)
+	assert.NotEqual(t, changedConfig, changedImage)
+
+	// with config reload, the configuration is not part of the sidecar in the collector's namespace
+	otelcol.Spec.SidecarConfigReload = true
+	reloading, err := ConfigHash(cfg, otelcol, "some-app")
+	require.NoError(t, err)
+	otelcol.Spec.Config = "receivers:\n  otlp:\n"
+	reloadingChangedConfig, err := ConfigHash(cfg, otelcol, "some-app")
+	require.NoError(t, err)
+	assert.Equal(t, reloading, reloadingChangedConfig)
+
+	otherNamespace, err := ConfigHash(cfg, otelcol, "other-app")
+	require.NoError(t, err)
+	assert.NotEqual(t, reloadingChangedConfig, otherNamespace)
+}
@@ -24,6 +24,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
@@ -35,41 +36,40 @@ var (
 	errMultipleInstancesPossible = errors.New("multiple OpenTelemetry Collector instances available, cannot determine which one to select")
 	errNoInstancesAvailable      = errors.New("no OpenTelemetry Collector instances available")
 	errInstanceNotSidecar        = errors.New("the OpenTelemetry Collector's mode is not set to sidecar")
+	errNamespaceNotAllowed       = errors.New("the OpenTelemetry Collector doesn't allow the pod's namespace to use it as a sidecar")
 )
 
+// failureReason is the reason of the events reporting the sidecars that couldn't be injected.
+const failureReason = "SidecarInjectionFailed"
+
 type sidecarPodMutator struct {
-	client client.Client
-	logger logr.Logger
-	config config.Config
+	client   client.Client
+	logger   logr.Logger
+	config   config.Config
+	reporter *podmutation.FailureReporter
 }
 
 var _ podmutation.PodMutator = (*sidecarPodMutator)(nil)
 
-func NewMutator(logger logr.Logger, config config.Config, client client.Client) *sidecarPodMutator {
+func NewMutator(logger logr.Logger, config config.Config, client client.Client, recorder record.EventRecorder) *sidecarPodMutator {
 	return &sidecarPodMutator{
-		config: config,
-		logger: logger,
-		client: client,
+		config:   config,
+		logger:   logger,
+		client:   client,
+		reporter: podmutation.NewFailureReporter(client, recorder),
 	}
 }
 
 func (p *sidecarPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod corev1.Pod) (corev1.Pod, error) {
 	logger := p.logger.WithValues("namespace", pod.Namespace, "name", pod.Name)
 
-	// if no annotations are found at all, just return the same pod
-	annValue := annotationValue(ns, pod)
-	if len(annValue) == 0 {
-		logger.V(1).Info("annotation not present in deployment, skipping sidecar injection")
-		return pod, nil
-	}
-
 	// is the annotation value 'false'? if so, we need a pod without the sidecar (ie, remove if exists)
+	annValue := annotationValue(ns, pod)
 	if strings.EqualFold(annValue, "false") {
 		logger.V(1).Info("pod explicitly refuses sidecar injection, attempting to remove sidecar if it exists")
 		return remove(pod)
 	}
 
-	// from this point and on, a sidecar is wanted
 	// check whether there's a sidecar already -- return the same pod if that's the case.
 	if existsIn(pod) {
 		logger.V(1).Info("pod already has sidecar in it, skipping injection")
@@ -77,17 +77,24 @@ func (p *sidecarPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod
 	}
 
 	// which instance should it talk to?
-	otelcol, err := p.getCollectorInstance(ctx, ns, annValue)
+	otelcol, err := p.getCollectorInstance(ctx, ns, pod, annValue)
 	if err != nil {
-		if errors.Is(err, errMultipleInstancesPossible) || errors.Is(err, errNoInstancesAvailable) || errors.Is(err, errInstanceNotSidecar) {
-			// we still allow the pod to be created, but we log a message to the operator's logs
+		if errors.Is(err, errMultipleInstancesPossible) || errors.Is(err, errNoInstancesAvailable) || errors.Is(err, errInstanceNotSidecar) || errors.Is(err, errNamespaceNotAllowed) {
+			// unless the failure policy says otherwise, we still allow the pod to be created, but we log a message to the operator's logs
 			logger.Error(err, "failed to select an OpenTelemetry Collector instance for this pod's sidecar")
-			return pod, nil
+			return pod, p.reportFailure(ctx, ns, pod, otelcol, err)
 		}
 
 		// something else happened, better fail here
+		if rejection := p.reportFailure(ctx, ns, pod, otelcol, err); rejection != nil {
+			return pod, rejection
+		}
 		return pod, err
 	}
+	if otelcol == nil {
+		logger.V(1).Info("annotation not present in deployment and no sidecar selector matches, skipping sidecar injection")
+		return pod, nil
+	}
 
 	// getting pod references, if any
 	references := p.podReferences(ctx, pod.OwnerReferences, ns)
@@ -97,12 +104,49 @@ func (p *sidecarPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod
 	// we should add the sidecar.
 	logger.V(1).Info("injecting sidecar into pod", "otelcol-namespace", otelcol.Namespace, "otelcol-name", otelcol.Name)
 
-	return add(p.config, p.logger, otelcol, pod, attributes)
+	if otelcol.Spec.SidecarConfigReload && otelcol.Namespace != ns.Name {
+		logger.Info("the collector's configuration can't be reloaded from another namespace, the sidecar won't pick up configuration changes until the pod is restarted", "otelcol-namespace", otelcol.Namespace, "otelcol-name", otelcol.Name)
+	}
+
+	injected, err := add(p.config, p.logger, forNamespace(*otelcol, ns.Name), pod, attributes)
+	if err != nil {
+		logger.Error(err, "failed to inject the sidecar into the pod", "otelcol-namespace", otelcol.Namespace, "otelcol-name", otelcol.Name)
+		if rejection := p.reportFailure(ctx, ns, pod, otelcol, err); rejection != nil {
+			return pod, rejection
+		}
+		return pod, err
+	}
+	return injected, nil
 }
 
-func (p *sidecarPodMutator) getCollectorInstance(ctx context.Context, ns corev1.Namespace, ann string) (v1alpha1.OpenTelemetryCollector, error) {
-	if strings.EqualFold(ann, "true") {
-		return p.selectCollectorInstance(ctx, ns)
+// reportFailure reports the sidecar that couldn't be injected into the pod, and returns the error rejecting the pod
+// when the failure policy of the namespace or of the selected collector, if any, requires it.
+func (p *sidecarPodMutator) reportFailure(ctx context.Context, ns corev1.Namespace, pod corev1.Pod, otelcol *v1alpha1.OpenTelemetryCollector, err error) error {
+	var policy v1alpha1.InjectionFailurePolicy
+	if otelcol != nil {
+		policy = otelcol.Spec.InjectionFailurePolicy
+	}
+	return p.reporter.Report(ctx, ns, pod, podmutation.FailurePolicy(ns, policy), failureReason, err)
+}
+
+// getCollectorInstance returns the collector to inject into the given pod, or nil when no sidecar is wanted.
+// An instance named by the annotation takes precedence over the sidecar selectors, which take precedence
+// over the single sidecar instance of the namespace selected by the annotation set to 'true'.
+func (p *sidecarPodMutator) getCollectorInstance(ctx context.Context, ns corev1.Namespace, pod corev1.Pod, ann string) (*v1alpha1.OpenTelemetryCollector, error) {
+	if len(ann) == 0 || strings.EqualFold(ann, "true") {
+		if len(ann) == 0 && !pod.CreationTimestamp.IsZero() {
+			// the pod is being updated, its containers can't be changed anymore
+			return nil, nil
+		}
+		selected, err := p.selectBySelectors(ctx, ns, pod)
+		if err != nil || selected != nil || len(ann) == 0 {
+			return selected, err
+		}
+		otelcol, err := p.selectCollectorInstance(ctx, ns)
+		if err != nil {
+			return nil, err
+		}
+		return &otelcol, nil
 	}
 
 	otelcol := v1alpha1.OpenTelemetryCollector{}
@@ -115,14 +159,19 @@ func (p *sidecarPodMutator) getCollectorInstance(ctx context.Context, ns corev1.
 	}
 	err := p.client.Get(ctx, nsnOtelcol, &otelcol)
 	if err != nil {
-		return otelcol, err
+		return nil, err
 	}
 
 	if otelcol.Spec.Mode != v1alpha1.ModeSidecar {
-		return v1alpha1.OpenTelemetryCollector{}, errInstanceNotSidecar
+		return nil, errInstanceNotSidecar
+	}
+
+	if !allowedIn(otelcol, ns.Name) {
+		// the collector is returned anyway, so that its failure policy applies
+		return &otelcol, errNamespaceNotAllowed
 	}
 
-	return otelcol, nil
+	return &otelcol, nil
 }
 
 func (p *sidecarPodMutator) selectCollectorInstance(ctx context.Context, ns corev1.Namespace) (v1alpha1.OpenTelemetryCollector, error) {
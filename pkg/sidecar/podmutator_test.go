@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sidecar
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/webhook/podmutation"
+)
+
+func TestMutateInjectionFailurePolicy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	failingCollector := sharedSidecarCollector([]string{"platform"}, nil)
+	failingCollector.Spec.InjectionFailurePolicy = v1alpha1.InjectionFailurePolicyFail
+	pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:        "my-pod",
+		Namespace:   "app",
+		Annotations: map[string]string{Annotation: "true"},
+	}}
+
+	for _, tt := range []struct {
+		desc       string
+		collectors []client.Object
+		ns         corev1.Namespace
+		pod        corev1.Pod
+		rejected   bool
+	}{
+		{
+			desc: "ignored by default",
+			ns:   corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}},
+			pod:  pod,
+		},
+		{
+			desc: "rejected by the namespace policy",
+			ns: corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:        "app",
+				Annotations: map[string]string{podmutation.InjectionFailurePolicyAnnotation: "fail"},
+			}},
+			pod:      pod,
+			rejected: true,
+		},
+		{
+			desc:       "rejected by the collector policy",
+			collectors: []client.Object{failingCollector},
+			ns:         corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}},
+			pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Name:        "my-pod",
+				Namespace:   "app",
+				Annotations: map[string]string{Annotation: "platform/standard-sidecar"},
+			}},
+			rejected: true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.collectors...).Build()
+			recorder := record.NewFakeRecorder(1)
+			mutator := NewMutator(logger, config.New(), cli, recorder)
+
+			mutated, err := mutator.Mutate(context.Background(), tt.ns, tt.pod)
+
+			if tt.rejected {
+				var rejection *podmutation.RejectionError
+				assert.ErrorAs(t, err, &rejection)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.pod, mutated)
+			require.Len(t, recorder.Events, 1)
+			assert.Contains(t, <-recorder.Events, "Warning SidecarInjectionFailed")
+		})
+	}
+}
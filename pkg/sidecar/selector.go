@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sidecar
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/webhook/podmutation"
+)
+
+// selectBySelectors returns the sidecar collector selecting the given pod with its sidecar selector, if any.
+// Only the collectors of the pod's namespace and of the namespaces allowed to select the pods of other namespaces
+// by the operator's configuration are considered. When several collectors select the pod, the one with the highest
+// priority is returned, then the one in the pod's namespace. errMultipleInstancesPossible is returned when that's
+// still not enough to decide.
+func (p *sidecarPodMutator) selectBySelectors(ctx context.Context, ns corev1.Namespace, pod corev1.Pod) (*v1alpha1.OpenTelemetryCollector, error) {
+	var candidates []v1alpha1.OpenTelemetryCollector
+	for _, namespace := range podmutation.SelectorNamespaces(p.config, ns.Name) {
+		otelcols := v1alpha1.OpenTelemetryCollectorList{}
+		if err := p.client.List(ctx, &otelcols, client.InNamespace(namespace)); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, otelcols.Items...)
+	}
+
+	var selected []v1alpha1.OpenTelemetryCollector
+	for _, otelcol := range candidates {
+		if otelcol.Spec.Mode != v1alpha1.ModeSidecar || otelcol.Spec.SidecarSelector == nil || !allowedIn(otelcol, ns.Name) {
+			continue
+		}
+		matches, err := selects(*otelcol.Spec.SidecarSelector, otelcol.Namespace, ns, pod)
+		if err != nil {
+			p.logger.Error(err, "invalid sidecar selector", "otelcol-namespace", otelcol.Namespace, "otelcol-name", otelcol.Name)
+			continue
+		}
+		if !matches {
+			continue
+		}
+
+		if len(selected) > 0 {
+			switch precedence(otelcol, selected[0], ns) {
+			case 1:
+				selected = selected[:0]
+			case -1:
+				continue
+			}
+		}
+		selected = append(selected, otelcol)
+	}
+
+	switch len(selected) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &selected[0], nil
+	default:
+		return nil, errMultipleInstancesPossible
+	}
+}
+
+// allowedIn returns whether the pods of the given namespace may use the given collector as a sidecar.
+func allowedIn(otelcol v1alpha1.OpenTelemetryCollector, namespace string) bool {
+	if otelcol.Spec.SidecarAllowedNamespaces == nil || otelcol.Namespace == namespace {
+		return true
+	}
+	for _, allowed := range otelcol.Spec.SidecarAllowedNamespaces {
+		if allowed == "*" || allowed == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// selects returns whether the given sidecar selector, of a collector in the given namespace, selects the given pod.
+func selects(selector v1alpha1.SidecarSelector, otelcolNamespace string, ns corev1.Namespace, pod corev1.Pod) (bool, error) {
+	if selector.NamespaceSelector == nil {
+		if otelcolNamespace != ns.Name {
+			return false, nil
+		}
+	} else {
+		nsSelector, err := metav1.LabelSelectorAsSelector(selector.NamespaceSelector)
+		if err != nil {
+			return false, err
+		}
+		if !nsSelector.Matches(labels.Set(ns.Labels)) {
+			return false, nil
+		}
+	}
+
+	if selector.PodSelector == nil {
+		return true, nil
+	}
+	podSelector, err := metav1.LabelSelectorAsSelector(selector.PodSelector)
+	if err != nil {
+		return false, err
+	}
+	return podSelector.Matches(labels.Set(pod.Labels)), nil
+}
+
+// precedence compares two collectors selecting a pod in the given namespace: 1 when a takes precedence over b,
+// -1 when b takes precedence over a, and 0 when neither does.
+func precedence(a, b v1alpha1.OpenTelemetryCollector, ns corev1.Namespace) int {
+	switch {
+	case a.Spec.SidecarSelector.Priority > b.Spec.SidecarSelector.Priority:
+		return 1
+	case a.Spec.SidecarSelector.Priority < b.Spec.SidecarSelector.Priority:
+		return -1
+	case a.Namespace == ns.Name && b.Namespace != ns.Name:
+		return 1
+	case a.Namespace != ns.Name && b.Namespace == ns.Name:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,283 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sidecar
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func sidecarCollector(namespace, name string, selector *v1alpha1.SidecarSelector) *v1alpha1.OpenTelemetryCollector {
+	return &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:            v1alpha1.ModeSidecar,
+			SidecarSelector: selector,
+		},
+	}
+}
+
+func sharedSidecarCollector(allowed []string, selector *v1alpha1.SidecarSelector) *v1alpha1.OpenTelemetryCollector {
+	otelcol := sidecarCollector("platform", "standard-sidecar", selector)
+	otelcol.Spec.SidecarAllowedNamespaces = allowed
+	return otelcol
+}
+
+func TestGetCollectorInstanceWithSelectors(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	appNs := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app", Labels: map[string]string{"team": "payments"}}}
+	otherNs := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}}
+	frontend := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Labels: map[string]string{"tier": "frontend"}}}
+	backend := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Labels: map[string]string{"tier": "backend"}}}
+	teamSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}}
+	frontendSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "frontend"}}
+
+	for _, tt := range []struct {
+		desc       string
+		collectors []client.Object
+		ns         corev1.Namespace
+		pod        corev1.Pod
+		annotation string
+		expected   string
+		err        error
+	}{
+		{
+			desc: "no selector",
+			collectors: []client.Object{
+				sidecarCollector("app", "plain", nil),
+			},
+			ns:  appNs,
+			pod: frontend,
+		},
+		{
+			desc: "pod selector in the collector's namespace",
+			collectors: []client.Object{
+				sidecarCollector("app", "frontend", &v1alpha1.SidecarSelector{PodSelector: frontendSelector}),
+			},
+			ns:       appNs,
+			pod:      frontend,
+			expected: "app/frontend",
+		},
+		{
+			desc: "pod selector not matching",
+			collectors: []client.Object{
+				sidecarCollector("app", "frontend", &v1alpha1.SidecarSelector{PodSelector: frontendSelector}),
+			},
+			ns:  appNs,
+			pod: backend,
+		},
+		{
+			desc: "no namespace selector doesn't select other namespaces",
+			collectors: []client.Object{
+				sidecarCollector("platform", "all", &v1alpha1.SidecarSelector{}),
+			},
+			ns:  appNs,
+			pod: frontend,
+		},
+		{
+			desc: "namespace selector",
+			collectors: []client.Object{
+				sidecarCollector("platform", "payments", &v1alpha1.SidecarSelector{NamespaceSelector: teamSelector}),
+			},
+			ns:       appNs,
+			pod:      backend,
+			expected: "platform/payments",
+		},
+		{
+			desc: "namespace selector of a namespace not allowed to select other namespaces",
+			collectors: []client.Object{
+				sidecarCollector("tenant", "payments", &v1alpha1.SidecarSelector{NamespaceSelector: teamSelector}),
+			},
+			ns:  appNs,
+			pod: backend,
+		},
+		{
+			desc: "namespace selector not matching",
+			collectors: []client.Object{
+				sidecarCollector("platform", "payments", &v1alpha1.SidecarSelector{NamespaceSelector: teamSelector}),
+			},
+			ns:  otherNs,
+			pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "other"}},
+		},
+		{
+			desc: "highest priority wins",
+			collectors: []client.Object{
+				sidecarCollector("app", "local", &v1alpha1.SidecarSelector{}),
+				sidecarCollector("platform", "payments", &v1alpha1.SidecarSelector{NamespaceSelector: teamSelector, Priority: 10}),
+			},
+			ns:       appNs,
+			pod:      frontend,
+			expected: "platform/payments",
+		},
+		{
+			desc: "pod's namespace wins with the same priority",
+			collectors: []client.Object{
+				sidecarCollector("platform", "payments", &v1alpha1.SidecarSelector{NamespaceSelector: teamSelector}),
+				sidecarCollector("app", "local", &v1alpha1.SidecarSelector{}),
+			},
+			ns:       appNs,
+			pod:      frontend,
+			expected: "app/local",
+		},
+		{
+			desc: "ambiguous selection",
+			collectors: []client.Object{
+				sidecarCollector("app", "local", &v1alpha1.SidecarSelector{}),
+				sidecarCollector("app", "frontend", &v1alpha1.SidecarSelector{PodSelector: frontendSelector}),
+			},
+			ns:  appNs,
+			pod: frontend,
+			err: errMultipleInstancesPossible,
+		},
+		{
+			desc: "annotation naming an instance wins",
+			collectors: []client.Object{
+				sidecarCollector("app", "frontend", &v1alpha1.SidecarSelector{PodSelector: frontendSelector, Priority: 10}),
+				sidecarCollector("app", "plain", nil),
+			},
+			ns:         appNs,
+			pod:        frontend,
+			annotation: "plain",
+			expected:   "app/plain",
+		},
+		{
+			desc: "annotation set to true falls back to the namespace's single instance",
+			collectors: []client.Object{
+				sidecarCollector("app", "plain", nil),
+			},
+			ns:         appNs,
+			pod:        frontend,
+			annotation: "true",
+			expected:   "app/plain",
+		},
+		{
+			desc: "annotation set to true prefers the selectors",
+			collectors: []client.Object{
+				sidecarCollector("app", "plain", nil),
+				sidecarCollector("app", "frontend", &v1alpha1.SidecarSelector{PodSelector: frontendSelector}),
+			},
+			ns:         appNs,
+			pod:        frontend,
+			annotation: "true",
+			expected:   "app/frontend",
+		},
+		{
+			desc: "reference to another namespace without allow-list",
+			collectors: []client.Object{
+				sharedSidecarCollector(nil, nil),
+			},
+			ns:         appNs,
+			pod:        frontend,
+			annotation: "platform/standard-sidecar",
+			expected:   "platform/standard-sidecar",
+		},
+		{
+			desc: "reference to another namespace allowed",
+			collectors: []client.Object{
+				sharedSidecarCollector([]string{"other", "app"}, nil),
+			},
+			ns:         appNs,
+			pod:        frontend,
+			annotation: "platform/standard-sidecar",
+			expected:   "platform/standard-sidecar",
+		},
+		{
+			desc: "reference to another namespace allowed with a wildcard",
+			collectors: []client.Object{
+				sharedSidecarCollector([]string{"*"}, nil),
+			},
+			ns:         appNs,
+			pod:        frontend,
+			annotation: "platform/standard-sidecar",
+			expected:   "platform/standard-sidecar",
+		},
+		{
+			desc: "reference to another namespace not allowed",
+			collectors: []client.Object{
+				sharedSidecarCollector([]string{"other"}, nil),
+			},
+			ns:         appNs,
+			pod:        frontend,
+			annotation: "platform/standard-sidecar",
+			err:        errNamespaceNotAllowed,
+		},
+		{
+			desc: "reference from the collector's namespace is always allowed",
+			collectors: []client.Object{
+				sharedSidecarCollector([]string{"other"}, nil),
+			},
+			ns:         corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "platform"}},
+			pod:        corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "platform"}},
+			annotation: "standard-sidecar",
+			expected:   "platform/standard-sidecar",
+		},
+		{
+			desc: "namespace selector limited by the allow-list",
+			collectors: []client.Object{
+				sharedSidecarCollector([]string{"other"}, &v1alpha1.SidecarSelector{NamespaceSelector: teamSelector}),
+			},
+			ns:  appNs,
+			pod: frontend,
+		},
+		{
+			desc: "selectors don't apply to existing pods",
+			collectors: []client.Object{
+				sidecarCollector("app", "frontend", &v1alpha1.SidecarSelector{PodSelector: frontendSelector}),
+			},
+			ns: appNs,
+			pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "app",
+				Labels:            map[string]string{"tier": "frontend"},
+				CreationTimestamp: metav1.Now(),
+			}},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.collectors...).Build()
+			cfg := config.New(config.WithCrossNamespaceSelectorNamespaces([]string{"platform"}))
+			mutator := NewMutator(logger, cfg, cli, record.NewFakeRecorder(10))
+
+			otelcol, err := mutator.getCollectorInstance(context.Background(), tt.ns, tt.pod, tt.annotation)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.expected == "" {
+				assert.Nil(t, otelcol)
+				return
+			}
+			require.NotNil(t, otelcol)
+			assert.Equal(t, tt.expected, otelcol.Namespace+"/"+otelcol.Name)
+		})
+	}
+}